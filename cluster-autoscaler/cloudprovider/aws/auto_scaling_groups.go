@@ -19,6 +19,7 @@ package aws
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
@@ -35,6 +36,7 @@ type asgCache struct {
 	registeredAsgs []*asg
 	asgToInstances map[AwsRef][]AwsInstanceRef
 	instanceToAsg  map[AwsInstanceRef]*asg
+	instanceStatus map[AwsInstanceRef]*cloudprovider.InstanceStatus
 	mutex          sync.Mutex
 	service        autoScalingWrapper
 	interrupt      chan struct{}
@@ -55,6 +57,10 @@ type asg struct {
 	LaunchTemplateVersion   string
 	LaunchConfigurationName string
 	Tags                    []*autoscaling.TagDescription
+
+	// InstanceTypeOverrides are the instance types of the MixedInstancesPolicy of the ASG, empty
+	// if it has none. Instances of such ASGs can be of any of these types.
+	InstanceTypeOverrides []string
 }
 
 func newASGCache(service autoScalingWrapper, explicitSpecs []string, autoDiscoverySpecs []cloudprovider.ASGAutoDiscoveryConfig) (*asgCache, error) {
@@ -63,6 +69,7 @@ func newASGCache(service autoScalingWrapper, explicitSpecs []string, autoDiscove
 		service:               service,
 		asgToInstances:        make(map[AwsRef][]AwsInstanceRef),
 		instanceToAsg:         make(map[AwsInstanceRef]*asg),
+		instanceStatus:        make(map[AwsInstanceRef]*cloudprovider.InstanceStatus),
 		interrupt:             make(chan struct{}),
 		asgAutoDiscoverySpecs: autoDiscoverySpecs,
 		explicitlyConfigured:  make(map[AwsRef]bool),
@@ -116,6 +123,7 @@ func (m *asgCache) register(asg *asg) bool {
 			existing.LaunchTemplateName = asg.LaunchTemplateName
 			existing.LaunchTemplateVersion = asg.LaunchTemplateVersion
 			existing.Tags = asg.Tags
+			existing.InstanceTypeOverrides = asg.InstanceTypeOverrides
 
 			return true
 		}
@@ -186,6 +194,14 @@ func (m *asgCache) InstancesByAsg(ref AwsRef) ([]AwsInstanceRef, error) {
 	return nil, fmt.Errorf("Error while looking for instances of ASG: %s", ref)
 }
 
+// InstanceStatus returns the status of an instance, nil if it's unknown.
+func (m *asgCache) InstanceStatus(instance AwsInstanceRef) *cloudprovider.InstanceStatus {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.instanceStatus[instance]
+}
+
 // Fetch automatically discovered ASGs. These ASGs should be unregistered if
 // they no longer exist in AWS.
 func (m *asgCache) fetchAutoAsgNames() ([]string, error) {
@@ -238,6 +254,7 @@ func (m *asgCache) regenerate() error {
 
 	newInstanceToAsgCache := make(map[AwsInstanceRef]*asg)
 	newAsgToInstancesCache := make(map[AwsRef][]AwsInstanceRef)
+	newInstanceStatusCache := make(map[AwsInstanceRef]*cloudprovider.InstanceStatus)
 
 	// Build list of knowns ASG names
 	refreshNames, err := m.buildAsgNames()
@@ -269,6 +286,7 @@ func (m *asgCache) regenerate() error {
 			ref := m.buildInstanceRefFromAWS(instance)
 			newInstanceToAsgCache[ref] = asg
 			newAsgToInstancesCache[asg.AwsRef][i] = ref
+			newInstanceStatusCache[ref] = instanceStatusFromLifecycleState(aws.StringValue(instance.LifecycleState))
 		}
 	}
 
@@ -281,6 +299,7 @@ func (m *asgCache) regenerate() error {
 
 	m.asgToInstances = newAsgToInstancesCache
 	m.instanceToAsg = newInstanceToAsgCache
+	m.instanceStatus = newInstanceStatusCache
 	return nil
 }
 
@@ -308,7 +327,8 @@ func (m *asgCache) buildAsgFromAWS(g *autoscaling.Group) (*asg, error) {
 		LaunchConfigurationName: aws.StringValue(g.LaunchConfigurationName),
 		LaunchTemplateName:      launchTemplateName,
 		LaunchTemplateVersion:   launchTemplateVersion,
-		Tags: g.Tags,
+		Tags:                    g.Tags,
+		InstanceTypeOverrides:   m.buildInstanceTypeOverrides(g),
 	}
 
 	return asg, nil
@@ -318,10 +338,29 @@ func (m *asgCache) buildLaunchTemplateParams(g *autoscaling.Group) (string, stri
 	if g.LaunchTemplate != nil {
 		return aws.StringValue(g.LaunchTemplate.LaunchTemplateName), aws.StringValue(g.LaunchTemplate.Version)
 	}
+	if g.MixedInstancesPolicy != nil && g.MixedInstancesPolicy.LaunchTemplate != nil {
+		if spec := g.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification; spec != nil {
+			return aws.StringValue(spec.LaunchTemplateName), aws.StringValue(spec.Version)
+		}
+	}
 
 	return "", ""
 }
 
+func (m *asgCache) buildInstanceTypeOverrides(g *autoscaling.Group) []string {
+	if g.MixedInstancesPolicy == nil || g.MixedInstancesPolicy.LaunchTemplate == nil {
+		return nil
+	}
+
+	var instanceTypes []string
+	for _, override := range g.MixedInstancesPolicy.LaunchTemplate.Overrides {
+		if instanceType := aws.StringValue(override.InstanceType); instanceType != "" {
+			instanceTypes = append(instanceTypes, instanceType)
+		}
+	}
+	return instanceTypes
+}
+
 func (m *asgCache) buildInstanceRefFromAWS(instance *autoscaling.Instance) AwsInstanceRef {
 	providerID := fmt.Sprintf("aws:///%s/%s", aws.StringValue(instance.AvailabilityZone), aws.StringValue(instance.InstanceId))
 	return AwsInstanceRef{
@@ -330,6 +369,24 @@ func (m *asgCache) buildInstanceRefFromAWS(instance *autoscaling.Instance) AwsIn
 	}
 }
 
+// instanceStatusFromLifecycleState maps the lifecycle state of an ASG instance to its status. ASGs don't
+// tie failed launches to instances, so the status never has error info.
+func instanceStatusFromLifecycleState(lifecycleState string) *cloudprovider.InstanceStatus {
+	switch {
+	case lifecycleState == "":
+		return nil
+	case strings.HasPrefix(lifecycleState, autoscaling.LifecycleStatePending):
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating}
+	case strings.HasPrefix(lifecycleState, autoscaling.LifecycleStateTerminating),
+		lifecycleState == autoscaling.LifecycleStateTerminated,
+		lifecycleState == autoscaling.LifecycleStateDetaching,
+		lifecycleState == autoscaling.LifecycleStateDetached:
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceDeleting}
+	default:
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceRunning}
+	}
+}
+
 // Cleanup closes the channel to signal the go routine to stop that is handling the cache
 func (m *asgCache) Cleanup() {
 	close(m.interrupt)
@@ -19,6 +19,10 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +45,60 @@ func TestBuildAsg(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBuildAsgFromMixedInstancesPolicy(t *testing.T) {
+	asgCache := &asgCache{}
+
+	asg, err := asgCache.buildAsgFromAWS(&autoscaling.Group{
+		AutoScalingGroupName: aws.String("mixed-asg"),
+		MinSize:              aws.Int64(1),
+		MaxSize:              aws.Int64(5),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateName: aws.String("launcher"),
+					Version:            aws.String("$Latest"),
+				},
+				Overrides: []*autoscaling.LaunchTemplateOverrides{
+					{InstanceType: aws.String("m5.large")},
+					{InstanceType: aws.String("c5.large")},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	validateAsg(t, asg, "mixed-asg", 1, 5)
+	assert.Equal(t, "launcher", asg.LaunchTemplateName)
+	assert.Equal(t, "$Latest", asg.LaunchTemplateVersion)
+	assert.Equal(t, []string{"m5.large", "c5.large"}, asg.InstanceTypeOverrides)
+
+	asg, err = asgCache.buildAsgFromAWS(&autoscaling.Group{
+		AutoScalingGroupName:    aws.String("asg"),
+		MinSize:                 aws.Int64(1),
+		MaxSize:                 aws.Int64(5),
+		LaunchConfigurationName: aws.String("config"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "config", asg.LaunchConfigurationName)
+	assert.Empty(t, asg.InstanceTypeOverrides)
+}
+
+func TestInstanceStatusFromLifecycleState(t *testing.T) {
+	assert.Nil(t, instanceStatusFromLifecycleState(""))
+	for lifecycleState, expected := range map[string]cloudprovider.InstanceState{
+		"Pending":             cloudprovider.InstanceCreating,
+		"Pending:Wait":        cloudprovider.InstanceCreating,
+		"InService":           cloudprovider.InstanceRunning,
+		"Standby":             cloudprovider.InstanceRunning,
+		"Terminating:Proceed": cloudprovider.InstanceDeleting,
+		"Terminated":          cloudprovider.InstanceDeleting,
+		"Detaching":           cloudprovider.InstanceDeleting,
+	} {
+		status := instanceStatusFromLifecycleState(lifecycleState)
+		assert.Equal(t, expected, status.State, lifecycleState)
+		assert.Nil(t, status.ErrorInfo)
+	}
+}
+
 func validateAsg(t *testing.T, asg *asg, name string, minSize int, maxSize int) {
 	assert.Equal(t, name, asg.Name)
 	assert.Equal(t, minSize, asg.minSize)
@@ -25,6 +25,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
@@ -37,13 +38,18 @@ const (
 // awsCloudProvider implements CloudProvider interface.
 type awsCloudProvider struct {
 	awsManager      *AwsManager
+	priceModel      *AwsPriceModel
 	resourceLimiter *cloudprovider.ResourceLimiter
 }
 
 // BuildAwsCloudProvider builds CloudProvider implementation for AWS.
-func BuildAwsCloudProvider(awsManager *AwsManager, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
+func BuildAwsCloudProvider(awsManager *AwsManager, priceModel *AwsPriceModel, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
+	if priceModel != nil {
+		priceModel.instanceTypeOf = awsManager.instanceTypeOfNode
+	}
 	aws := &awsCloudProvider{
 		awsManager:      awsManager,
+		priceModel:      priceModel,
 		resourceLimiter: resourceLimiter,
 	}
 	return aws, nil
@@ -98,7 +104,7 @@ func (aws *awsCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.N
 
 // Pricing returns pricing model for this cloud provider or error if not available.
 func (aws *awsCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
-	return nil, cloudprovider.ErrNotImplemented
+	return aws.priceModel, nil
 }
 
 // GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
@@ -194,6 +200,12 @@ func (ng *AwsNodeGroup) Delete() error {
 	return cloudprovider.ErrNotImplemented
 }
 
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Options are overridden by the autoscaling-options tags of the ASG.
+func (ng *AwsNodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return extractAutoscalingOptionsFromAsg(ng.asg.Tags, defaults), nil
+}
+
 // IncreaseSize increases Asg size
 func (ng *AwsNodeGroup) IncreaseSize(delta int) error {
 	if delta <= 0 {
@@ -279,16 +291,19 @@ func (ng *AwsNodeGroup) Debug() string {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (ng *AwsNodeGroup) Nodes() ([]string, error) {
+func (ng *AwsNodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 	asgNodes, err := ng.awsManager.GetAsgNodes(ng.asg.AwsRef)
 	if err != nil {
 		return nil, err
 	}
 
-	nodes := make([]string, len(asgNodes))
+	nodes := make([]cloudprovider.Instance, len(asgNodes))
 
 	for i, asgNode := range asgNodes {
-		nodes[i] = asgNode.ProviderID
+		nodes[i] = cloudprovider.Instance{
+			Id:     asgNode.ProviderID,
+			Status: ng.awsManager.GetInstanceStatus(asgNode),
+		}
 	}
 	return nodes, nil
 }
@@ -18,6 +18,7 @@ package aws
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -26,6 +27,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
 )
 
 type AutoScalingMock struct {
@@ -61,6 +63,11 @@ type EC2Mock struct {
 	mock.Mock
 }
 
+func (e *EC2Mock) DescribeInstancesPages(i *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	args := e.Called(i, fn)
+	return args.Error(0)
+}
+
 func (e *EC2Mock) DescribeLaunchTemplateVersions(i *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
 	args := e.Called(i)
 	return args.Get(0).(*ec2.DescribeLaunchTemplateVersionsOutput), nil
@@ -113,6 +120,7 @@ func testNamedDescribeAutoScalingGroupsOutput(groupName string, desiredCap int64
 		instances = append(instances, &autoscaling.Instance{
 			InstanceId:       aws.String(id),
 			AvailabilityZone: aws.String("us-east-1a"),
+			LifecycleState:   aws.String(autoscaling.LifecycleStateInService),
 		})
 	}
 	return &autoscaling.DescribeAutoScalingGroupsOutput{
@@ -133,7 +141,7 @@ func testProvider(t *testing.T, m *AwsManager) *awsCloudProvider {
 		map[string]int64{cloudprovider.ResourceNameCores: 1, cloudprovider.ResourceNameMemory: 10000000},
 		map[string]int64{cloudprovider.ResourceNameCores: 10, cloudprovider.ResourceNameMemory: 100000000})
 
-	provider, err := BuildAwsCloudProvider(m, resourceLimiter)
+	provider, err := BuildAwsCloudProvider(m, &AwsPriceModel{}, resourceLimiter)
 	assert.NoError(t, err)
 	return provider.(*awsCloudProvider)
 }
@@ -143,7 +151,7 @@ func TestBuildAwsCloudProvider(t *testing.T) {
 		map[string]int64{cloudprovider.ResourceNameCores: 1, cloudprovider.ResourceNameMemory: 10000000},
 		map[string]int64{cloudprovider.ResourceNameCores: 10, cloudprovider.ResourceNameMemory: 100000000})
 
-	_, err := BuildAwsCloudProvider(testAwsManager, resourceLimiter)
+	_, err := BuildAwsCloudProvider(testAwsManager, &AwsPriceModel{}, resourceLimiter)
 	assert.NoError(t, err)
 }
 
@@ -239,7 +247,10 @@ func TestNodeGroupForNode(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	assert.Equal(t, nodes, []string{"aws:///us-east-1a/test-instance-id"})
+	assert.Equal(t, nodes, []cloudprovider.Instance{{
+		Id:     "aws:///us-east-1a/test-instance-id",
+		Status: &cloudprovider.InstanceStatus{State: cloudprovider.InstanceRunning},
+	}})
 	service.AssertNumberOfCalls(t, "DescribeAutoScalingGroupsPages", 1)
 
 	// test node in cluster that is not in a group managed by cluster autoscaler
@@ -428,3 +439,90 @@ func TestCleanup(t *testing.T) {
 	err := provider.Cleanup()
 	assert.NoError(t, err)
 }
+
+func describeInstancesInput(ids ...string) *ec2.DescribeInstancesInput {
+	return &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-id"),
+			Values: aws.StringSlice(ids),
+		}},
+	}
+}
+
+func TestMixedInstancesPolicyInstanceTypes(t *testing.T) {
+	service := &AutoScalingMock{}
+	ec2Service := &EC2Mock{}
+	m := newTestAwsManagerWithAsgs(t, service, []string{"1:5:mixed-asg"})
+	m.ec2Service = ec2Wrapper{ec2Service}
+	provider := testProvider(t, m)
+
+	instanceIds := []string{"i-1", "i-2"}
+	service.On("DescribeAutoScalingGroupsPages",
+		&autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: aws.StringSlice([]string{"mixed-asg"}),
+			MaxRecords:            aws.Int64(maxRecordsReturnedByAPI),
+		},
+		mock.AnythingOfType("func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool"),
+	).Run(func(args mock.Arguments) {
+		output := testNamedDescribeAutoScalingGroupsOutput("mixed-asg", 2, instanceIds...)
+		output.AutoScalingGroups[0].MixedInstancesPolicy = &autoscaling.MixedInstancesPolicy{
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				Overrides: []*autoscaling.LaunchTemplateOverrides{
+					{InstanceType: aws.String("m5.large")},
+					{InstanceType: aws.String("m5.xlarge")},
+				},
+			},
+		}
+		fn := args.Get(1).(func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool)
+		fn(output, false)
+	}).Return(nil)
+	ec2Service.On("DescribeInstancesPages", describeInstancesInput("i-1", "i-2"),
+		mock.AnythingOfType("func(*ec2.DescribeInstancesOutput, bool) bool"),
+	).Run(func(args mock.Arguments) {
+		fn := args.Get(1).(func(*ec2.DescribeInstancesOutput, bool) bool)
+		fn(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{
+				Instances: []*ec2.Instance{
+					{InstanceId: aws.String("i-1"), InstanceType: aws.String("m5.large")},
+					{InstanceId: aws.String("i-2"), InstanceType: aws.String("m5.xlarge")},
+				},
+			}},
+		}, false)
+	}).Return(nil).Once()
+
+	assert.NoError(t, m.forceRefresh())
+	assert.Equal(t, "m5.large", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-1", Name: "i-1"}))
+	assert.Equal(t, "m5.xlarge", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-2", Name: "i-2"}))
+
+	// Nodes missing the instance type label are priced by the type of their instance.
+	node := &apiv1.Node{Spec: apiv1.NodeSpec{ProviderID: "aws:///us-east-1a/i-2"}}
+	node.Labels = map[string]string{kubeletapis.LabelZoneRegion: "us-east-1"}
+	pricing, _ := provider.Pricing()
+	now := time.Now()
+	price, err := pricing.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, InstancePrices["us-east-1"]["m5.xlarge"], price)
+
+	// Only the types of new instances are looked up, those of gone instances are forgotten. Instances
+	// gone before being described don't keep the others from being described.
+	instanceIds = []string{"i-2", "i-3", "i-4"}
+	ec2Service.On("DescribeInstancesPages", describeInstancesInput("i-3", "i-4"),
+		mock.AnythingOfType("func(*ec2.DescribeInstancesOutput, bool) bool"),
+	).Run(func(args mock.Arguments) {
+		fn := args.Get(1).(func(*ec2.DescribeInstancesOutput, bool) bool)
+		fn(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{
+				Instances: []*ec2.Instance{
+					{InstanceId: aws.String("i-3"), InstanceType: aws.String("m5.large")},
+				},
+			}},
+		}, false)
+	}).Return(nil).Once()
+
+	assert.NoError(t, m.forceRefresh())
+	assert.Equal(t, "", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-1", Name: "i-1"}))
+	assert.Equal(t, "m5.xlarge", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-2", Name: "i-2"}))
+	assert.Equal(t, "m5.large", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-3", Name: "i-3"}))
+	assert.Equal(t, "", m.GetInstanceType(AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-4", Name: "i-4"}))
+	ec2Service.AssertExpectations(t)
+}
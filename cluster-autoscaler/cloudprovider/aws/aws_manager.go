@@ -23,6 +23,7 @@ import (
 	"io"
 	"math/rand"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	provider_aws "k8s.io/kubernetes/pkg/cloudprovider/providers/aws"
 	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
@@ -46,6 +48,24 @@ const (
 	operationPollInterval   = 100 * time.Millisecond
 	maxRecordsReturnedByAPI = 100
 	refreshInterval         = 10 * time.Second
+
+	autoscalingOptionsTagPrefix         = "k8s.io/cluster-autoscaler/node-template/autoscaling-options/"
+	scaleDownUtilizationThresholdOption = "scaledownutilizationthreshold"
+	scaleDownUnneededTimeOption         = "scaledownunneededtime"
+	scaleDownUnreadyTimeOption          = "scaledownunreadytime"
+	maxNodeProvisionTimeOption          = "maxnodeprovisiontime"
+	headroomNodesOption                 = "headroomnodes"
+	headroomCoresOption                 = "headroomcores"
+	headroomMemoryOption                = "headroommemory"
+
+	// instanceTypeTag overrides the instance type of the template node of an ASG. The template
+	// node of an ASG with a MixedInstancesPolicy otherwise has the smallest of its instance types.
+	instanceTypeTag = "k8s.io/cluster-autoscaler/node-template/instance-type"
+
+	// Tags with these prefixes set the capacity and the allocatable amount of a resource of the
+	// template node, e.g. ephemeral-storage, hugepages-2Mi or an extended resource.
+	resourcesTagPrefix   = "k8s.io/cluster-autoscaler/node-template/resources/"
+	allocatableTagPrefix = "k8s.io/cluster-autoscaler/node-template/allocatable/"
 )
 
 // AwsManager is handles aws communication and data caching.
@@ -54,6 +74,10 @@ type AwsManager struct {
 	ec2Service         ec2Wrapper
 	asgCache           *asgCache
 	lastRefresh        time.Time
+
+	// instanceTypes are the instance types of the instances of ASGs with a MixedInstancesPolicy,
+	// which can differ from the instance type of the template node.
+	instanceTypes map[AwsInstanceRef]string
 }
 
 type asgTemplate struct {
@@ -104,6 +128,7 @@ func createAWSManagerInternal(
 		autoScalingService: *autoScalingService,
 		ec2Service:         *ec2Service,
 		asgCache:           cache,
+		instanceTypes:      make(map[AwsInstanceRef]string),
 	}
 
 	if err := manager.forceRefresh(); err != nil {
@@ -132,11 +157,70 @@ func (m *AwsManager) forceRefresh() error {
 		glog.Errorf("Failed to regenerate ASG cache: %v", err)
 		return err
 	}
+	m.refreshInstanceTypes()
 	m.lastRefresh = time.Now()
 	glog.V(2).Infof("Refreshed ASG list, next refresh after %v", m.lastRefresh.Add(refreshInterval))
 	return nil
 }
 
+// refreshInstanceTypes looks up the instance types of new instances of ASGs with a
+// MixedInstancesPolicy and forgets the ones of instances that are gone.
+func (m *AwsManager) refreshInstanceTypes() {
+	instanceTypes := make(map[AwsInstanceRef]string)
+	var unknown []AwsInstanceRef
+	for _, asg := range m.getAsgs() {
+		if len(asg.InstanceTypeOverrides) == 0 {
+			continue
+		}
+		instances, err := m.asgCache.InstancesByAsg(asg.AwsRef)
+		if err != nil {
+			continue
+		}
+		for _, instance := range instances {
+			if instanceType, found := m.instanceTypes[instance]; found {
+				instanceTypes[instance] = instanceType
+			} else {
+				unknown = append(unknown, instance)
+			}
+		}
+	}
+
+	if len(unknown) > 0 {
+		ids := make([]string, len(unknown))
+		for i, instance := range unknown {
+			ids[i] = instance.Name
+		}
+		byId, err := m.ec2Service.getInstanceTypesByIds(ids)
+		if err != nil {
+			// Retried on the next refresh, the types are only needed once nodes are running.
+			glog.Warningf("Failed to get the instance types of %d instances: %v", len(ids), err)
+		}
+		for _, instance := range unknown {
+			if instanceType := byId[instance.Name]; instanceType != "" {
+				instanceTypes[instance] = instanceType
+			}
+		}
+	}
+
+	m.instanceTypes = instanceTypes
+}
+
+// GetInstanceType returns the instance type of an instance of an ASG with a MixedInstancesPolicy,
+// empty if it's unknown.
+func (m *AwsManager) GetInstanceType(instance AwsInstanceRef) string {
+	return m.instanceTypes[instance]
+}
+
+// instanceTypeOfNode returns the instance type of a node of an ASG with a MixedInstancesPolicy,
+// empty if it's unknown.
+func (m *AwsManager) instanceTypeOfNode(node *apiv1.Node) string {
+	ref, err := AwsRefFromProviderId(node.Spec.ProviderID)
+	if err != nil {
+		return ""
+	}
+	return m.GetInstanceType(*ref)
+}
+
 // GetAsgForInstance returns AsgConfig of the given Instance
 func (m *AwsManager) GetAsgForInstance(instance AwsInstanceRef) *asg {
 	return m.asgCache.FindForInstance(instance)
@@ -209,6 +293,11 @@ func (m *AwsManager) GetAsgNodes(ref AwsRef) ([]AwsInstanceRef, error) {
 	return m.asgCache.InstancesByAsg(ref)
 }
 
+// GetInstanceStatus returns the status of an instance, nil if it's unknown.
+func (m *AwsManager) GetInstanceStatus(instance AwsInstanceRef) *cloudprovider.InstanceStatus {
+	return m.asgCache.InstanceStatus(instance)
+}
+
 func (m *AwsManager) getAsgTemplate(asg *asg) (*asgTemplate, error) {
 	if len(asg.AvailabilityZones) < 1 {
 		return nil, fmt.Errorf("Unable to get first AvailabilityZone for %s", asg.Name)
@@ -226,8 +315,13 @@ func (m *AwsManager) getAsgTemplate(asg *asg) (*asgTemplate, error) {
 		return nil, err
 	}
 
+	instanceType, found := InstanceTypes[instanceTypeName]
+	if !found {
+		return nil, fmt.Errorf("Unknown instance type %q of %s", instanceTypeName, asg.Name)
+	}
+
 	return &asgTemplate{
-		InstanceType: InstanceTypes[instanceTypeName],
+		InstanceType: instanceType,
 		Region:       region,
 		Zone:         az,
 		Tags:         asg.Tags,
@@ -235,13 +329,42 @@ func (m *AwsManager) getAsgTemplate(asg *asg) (*asgTemplate, error) {
 }
 
 func (m *AwsManager) buildInstanceType(asg *asg) (string, error) {
+	for _, tag := range asg.Tags {
+		if aws.StringValue(tag.Key) == instanceTypeTag && aws.StringValue(tag.Value) != "" {
+			return aws.StringValue(tag.Value), nil
+		}
+	}
+	if instanceType := smallestInstanceType(asg.InstanceTypeOverrides); instanceType != "" {
+		return instanceType, nil
+	}
 	if asg.LaunchConfigurationName != "" {
 		return m.autoScalingService.getInstanceTypeByLCName(asg.LaunchConfigurationName)
 	} else if asg.LaunchTemplateName != "" && asg.LaunchTemplateVersion != "" {
 		return m.ec2Service.getInstanceTypeByLT(asg.LaunchTemplateName, asg.LaunchTemplateVersion)
 	}
 
-	return "", fmt.Errorf("Unable to get instance type from launch config or launch template")
+	return "", fmt.Errorf("Unable to get instance type from launch config, launch template or %s tag", instanceTypeTag)
+}
+
+// smallestInstanceType returns the instance type with the fewest CPUs, then the least memory, so
+// that pods fitting a template node of that type fit any instance of a mixed ASG. Types missing
+// from InstanceTypes are ignored.
+func smallestInstanceType(instanceTypeNames []string) string {
+	var smallest *instanceType
+	for _, name := range instanceTypeNames {
+		candidate, found := InstanceTypes[name]
+		if !found {
+			continue
+		}
+		if smallest == nil || candidate.VCPU < smallest.VCPU ||
+			(candidate.VCPU == smallest.VCPU && candidate.MemoryMb < smallest.MemoryMb) {
+			smallest = candidate
+		}
+	}
+	if smallest == nil {
+		return ""
+	}
+	return smallest.InstanceType
 }
 
 func (m *AwsManager) buildNodeFromTemplate(asg *asg, template *asgTemplate) (*apiv1.Node, error) {
@@ -263,9 +386,18 @@ func (m *AwsManager) buildNodeFromTemplate(asg *asg, template *asgTemplate) (*ap
 	node.Status.Capacity[apiv1.ResourceCPU] = *resource.NewQuantity(template.InstanceType.VCPU, resource.DecimalSI)
 	node.Status.Capacity[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(template.InstanceType.GPU, resource.DecimalSI)
 	node.Status.Capacity[apiv1.ResourceMemory] = *resource.NewQuantity(template.InstanceType.MemoryMb*1024*1024, resource.DecimalSI)
+	for name, quantity := range extractResourcesFromAsg(template.Tags, resourcesTagPrefix) {
+		node.Status.Capacity[name] = quantity
+	}
 
 	// TODO: use proper allocatable!!
-	node.Status.Allocatable = node.Status.Capacity
+	node.Status.Allocatable = apiv1.ResourceList{}
+	for name, quantity := range node.Status.Capacity {
+		node.Status.Allocatable[name] = quantity
+	}
+	for name, quantity := range extractResourcesFromAsg(template.Tags, allocatableTagPrefix) {
+		node.Status.Allocatable[name] = quantity
+	}
 
 	// NodeLabels
 	node.Labels = cloudprovider.JoinStringMaps(node.Labels, extractLabelsFromAsg(template.Tags))
@@ -310,6 +442,91 @@ func extractLabelsFromAsg(tags []*autoscaling.TagDescription) map[string]string
 	return result
 }
 
+// extractResourcesFromAsg returns the resources set by the tags with the given prefix. The
+// resource name follows the prefix, the tag value is its quantity. Tags with invalid
+// quantities are ignored.
+func extractResourcesFromAsg(tags []*autoscaling.TagDescription, prefix string) apiv1.ResourceList {
+	result := apiv1.ResourceList{}
+
+	for _, tag := range tags {
+		k := aws.StringValue(tag.Key)
+		if !strings.HasPrefix(k, prefix) || len(k) == len(prefix) {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(aws.StringValue(tag.Value))
+		if err != nil {
+			glog.Warningf("Invalid value of tag %s: %v", k, err)
+			continue
+		}
+		result[apiv1.ResourceName(k[len(prefix):])] = quantity
+	}
+
+	return result
+}
+
+// extractAutoscalingOptionsFromAsg returns the defaults overridden by the autoscaling options tags
+// of the ASG, or nil if there are no such tags. Tags with invalid values are ignored.
+func extractAutoscalingOptionsFromAsg(tags []*autoscaling.TagDescription, defaults config.NodeGroupAutoscalingOptions) *config.NodeGroupAutoscalingOptions {
+	var options *config.NodeGroupAutoscalingOptions
+	for _, tag := range tags {
+		splits := strings.Split(*tag.Key, autoscalingOptionsTagPrefix)
+		if len(splits) < 2 {
+			continue
+		}
+		if options == nil {
+			options = &defaults
+		}
+		option, value := splits[1], *tag.Value
+		switch option {
+		case scaleDownUtilizationThresholdOption:
+			threshold, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				glog.Warningf("Invalid value of tag %s: %v", *tag.Key, err)
+				continue
+			}
+			options.ScaleDownUtilizationThreshold = threshold
+		case scaleDownUnneededTimeOption, scaleDownUnreadyTimeOption, maxNodeProvisionTimeOption:
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				glog.Warningf("Invalid value of tag %s: %v", *tag.Key, err)
+				continue
+			}
+			switch option {
+			case scaleDownUnneededTimeOption:
+				options.ScaleDownUnneededTime = duration
+			case scaleDownUnreadyTimeOption:
+				options.ScaleDownUnreadyTime = duration
+			default:
+				options.MaxNodeProvisionTime = duration
+			}
+		case headroomNodesOption:
+			headroomNodes, err := strconv.Atoi(value)
+			if err != nil {
+				glog.Warningf("Invalid value of tag %s: %v", *tag.Key, err)
+				continue
+			}
+			options.HeadroomNodes = headroomNodes
+		case headroomCoresOption:
+			headroomCores, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				glog.Warningf("Invalid value of tag %s: %v", *tag.Key, err)
+				continue
+			}
+			options.HeadroomCores = headroomCores
+		case headroomMemoryOption:
+			headroomMemory, err := resource.ParseQuantity(value)
+			if err != nil {
+				glog.Warningf("Invalid value of tag %s: %v", *tag.Key, err)
+				continue
+			}
+			options.HeadroomMemory = headroomMemory.Value()
+		default:
+			glog.Warningf("Unknown autoscaling option in tag %s", *tag.Key)
+		}
+	}
+	return options
+}
+
 func extractTaintsFromAsg(tags []*autoscaling.TagDescription) []apiv1.Taint {
 	taints := make([]apiv1.Taint, 0)
 
@@ -19,6 +19,7 @@ package aws
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -26,7 +27,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
 )
 
@@ -64,6 +67,58 @@ func TestExtractLabelsFromAsg(t *testing.T) {
 	assert.Equal(t, "bar", labels["foo"])
 }
 
+func TestExtractAutoscalingOptionsFromAsg(t *testing.T) {
+	defaults := config.NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		ScaleDownUnneededTime:         10 * time.Minute,
+		ScaleDownUnreadyTime:          20 * time.Minute,
+		MaxNodeProvisionTime:          15 * time.Minute,
+	}
+	assert.Nil(t, extractAutoscalingOptionsFromAsg([]*autoscaling.TagDescription{
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/label/foo"),
+			Value: aws.String("bar"),
+		},
+	}, defaults))
+
+	tags := []*autoscaling.TagDescription{
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/scaledownutilizationthreshold"),
+			Value: aws.String("0.8"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/scaledownunneededtime"),
+			Value: aws.String("1h"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/maxnodeprovisiontime"),
+			Value: aws.String("forever"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/headroomnodes"),
+			Value: aws.String("2"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/headroomcores"),
+			Value: aws.String("4"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/autoscaling-options/headroommemory"),
+			Value: aws.String("8Gi"),
+		},
+	}
+	options := extractAutoscalingOptionsFromAsg(tags, defaults)
+	assert.Equal(t, &config.NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.8,
+		ScaleDownUnneededTime:         time.Hour,
+		ScaleDownUnreadyTime:          20 * time.Minute,
+		MaxNodeProvisionTime:          15 * time.Minute,
+		HeadroomNodes:                 2,
+		HeadroomCores:                 4,
+		HeadroomMemory:                8 * 1024 * 1024 * 1024,
+	}, options)
+}
+
 func TestExtractTaintsFromAsg(t *testing.T) {
 	tags := []*autoscaling.TagDescription{
 		{
@@ -115,6 +170,82 @@ func TestExtractTaintsFromAsg(t *testing.T) {
 	assert.Equal(t, makeTaintSet(expectedTaints), makeTaintSet(taints))
 }
 
+func TestExtractResourcesFromAsg(t *testing.T) {
+	tags := []*autoscaling.TagDescription{
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"),
+			Value: aws.String("100Gi"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/hugepages-2Mi"),
+			Value: aws.String("1Gi"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/example.com/dongle"),
+			Value: aws.String("4"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/invalid"),
+			Value: aws.String("lots"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/"),
+			Value: aws.String("1"),
+		},
+		{
+			Key:   aws.String("k8s.io/cluster-autoscaler/node-template/allocatable/ephemeral-storage"),
+			Value: aws.String("90Gi"),
+		},
+	}
+
+	assert.Equal(t, apiv1.ResourceList{
+		apiv1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+		"hugepages-2Mi":                resource.MustParse("1Gi"),
+		"example.com/dongle":           resource.MustParse("4"),
+	}, extractResourcesFromAsg(tags, resourcesTagPrefix))
+	assert.Equal(t, apiv1.ResourceList{
+		apiv1.ResourceEphemeralStorage: resource.MustParse("90Gi"),
+	}, extractResourcesFromAsg(tags, allocatableTagPrefix))
+}
+
+func TestBuildNodeFromTemplateWithResources(t *testing.T) {
+	asg := &asg{AwsRef: AwsRef{Name: "test-asg"}}
+	template := &asgTemplate{
+		InstanceType: InstanceTypes["m5.large"],
+		Region:       "us-east-1",
+		Zone:         "us-east-1a",
+		Tags: []*autoscaling.TagDescription{
+			{
+				Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"),
+				Value: aws.String("100Gi"),
+			},
+			{
+				Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/example.com/dongle"),
+				Value: aws.String("4"),
+			},
+			{
+				Key:   aws.String("k8s.io/cluster-autoscaler/node-template/allocatable/ephemeral-storage"),
+				Value: aws.String("90Gi"),
+			},
+			{
+				Key:   aws.String("k8s.io/cluster-autoscaler/node-template/allocatable/memory"),
+				Value: aws.String("7Gi"),
+			},
+		},
+	}
+
+	node, err := (&AwsManager{}).buildNodeFromTemplate(asg, template)
+	assert.NoError(t, err)
+
+	assert.Equal(t, resource.MustParse("100Gi"), node.Status.Capacity[apiv1.ResourceEphemeralStorage])
+	assert.Equal(t, resource.MustParse("90Gi"), node.Status.Allocatable[apiv1.ResourceEphemeralStorage])
+	assert.Equal(t, resource.MustParse("4"), node.Status.Capacity["example.com/dongle"])
+	assert.Equal(t, resource.MustParse("4"), node.Status.Allocatable["example.com/dongle"])
+	assert.Equal(t, resource.MustParse("7Gi"), node.Status.Allocatable[apiv1.ResourceMemory])
+	assert.Equal(t, *resource.NewQuantity(template.InstanceType.MemoryMb*1024*1024, resource.DecimalSI), node.Status.Capacity[apiv1.ResourceMemory])
+	assert.Equal(t, node.Status.Capacity[apiv1.ResourceCPU], node.Status.Allocatable[apiv1.ResourceCPU])
+}
+
 func makeTaintSet(taints []apiv1.Taint) map[apiv1.Taint]bool {
 	set := make(map[apiv1.Taint]bool)
 	for _, taint := range taints {
@@ -197,6 +328,48 @@ func TestBuildInstanceType(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, instanceType, builtInstanceType)
+
+	// The tag takes precedence over the launch template.
+	asg.Tags = []*autoscaling.TagDescription{
+		{
+			Key:   aws.String(instanceTypeTag),
+			Value: aws.String("m5.large"),
+		},
+	}
+	builtInstanceType, err = m.buildInstanceType(&asg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "m5.large", builtInstanceType)
+
+	// ASGs with a MixedInstancesPolicy use their smallest instance type, unless overridden by the tag.
+	asg.InstanceTypeOverrides = []string{"m5.xlarge", "unknown.large", "m5.large", "c5.large"}
+	builtInstanceType, err = m.buildInstanceType(&asg)
+	assert.NoError(t, err)
+	assert.Equal(t, "m5.large", builtInstanceType)
+
+	asg.Tags = nil
+	builtInstanceType, err = m.buildInstanceType(&asg)
+	assert.NoError(t, err)
+	assert.Equal(t, "c5.large", builtInstanceType)
+
+	// Only the launch template is left if no instance type of the policy is known.
+	asg.InstanceTypeOverrides = []string{"unknown.large"}
+	builtInstanceType, err = m.buildInstanceType(&asg)
+	assert.NoError(t, err)
+	assert.Equal(t, instanceType, builtInstanceType)
+
+	asg.LaunchTemplateName, asg.LaunchTemplateVersion = "", ""
+	_, err = m.buildInstanceType(&asg)
+	assert.Error(t, err)
+}
+
+func TestSmallestInstanceType(t *testing.T) {
+	assert.Equal(t, "", smallestInstanceType(nil))
+	assert.Equal(t, "", smallestInstanceType([]string{"unknown.large"}))
+	assert.Equal(t, "m5.large", smallestInstanceType([]string{"m5.xlarge", "m5.large"}))
+	// Fewer CPUs win over less memory.
+	assert.Equal(t, "m5.large", smallestInstanceType([]string{"c5.xlarge", "m5.large"}))
+	assert.Equal(t, "c5.large", smallestInstanceType([]string{"m5.large", "c5.large"}))
 }
 
 /* Disabled due to flakiness. See https://github.com/kubernetes/autoscaler/issues/608
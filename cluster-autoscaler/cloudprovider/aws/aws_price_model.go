@@ -0,0 +1,140 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+)
+
+const (
+	// Prices of resources of instance types missing from the price tables, based on
+	// general purpose instances.
+	cpuPricePerHour         = 0.0325
+	memoryPricePerHourPerGb = 0.0040
+	gpuPricePerHour         = 0.900
+)
+
+// AwsPriceModel implements PriceModel interface for AWS. Instances are priced by region and
+// instance type, using the on-demand prices in InstancePrices, unless overridden.
+type AwsPriceModel struct {
+	// overrides are prices by region and instance type taking precedence over InstancePrices.
+	overrides map[string]map[string]float64
+	// instanceTypeOf returns the instance type of a node missing the instance type label, empty
+	// if it's unknown.
+	instanceTypeOf func(node *apiv1.Node) string
+}
+
+// NewAwsPriceModel builds an AwsPriceModel. Prices of InstancePrices can be overridden, for
+// example with negotiated or spot prices, by a JSON file mapping regions to instance types to
+// hourly prices in USD:
+//
+//   {"us-east-1": {"m5.large": 0.035}}
+func NewAwsPriceModel(overridesFile string) (*AwsPriceModel, error) {
+	if overridesFile == "" {
+		return &AwsPriceModel{}, nil
+	}
+	f, err := os.Open(overridesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return newAwsPriceModelFromReader(f)
+}
+
+func newAwsPriceModelFromReader(overrides io.Reader) (*AwsPriceModel, error) {
+	model := &AwsPriceModel{}
+	if err := json.NewDecoder(overrides).Decode(&model.overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse price overrides: %v", err)
+	}
+	return model, nil
+}
+
+// NodePrice returns a price of running the given node for a given period of time.
+// All prices are in USD.
+func (model *AwsPriceModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	instanceType := node.Labels[kubeletapis.LabelInstanceType]
+	if instanceType == "" && model.instanceTypeOf != nil {
+		instanceType = model.instanceTypeOf(node)
+	}
+	if basePricePerHour, found := model.instancePrice(node.Labels[kubeletapis.LabelZoneRegion], instanceType); found {
+		return basePricePerHour * getHours(startTime, endTime), nil
+	}
+	price := getBasePrice(node.Status.Capacity, startTime, endTime)
+	price += getAdditionalPrice(node.Status.Capacity, startTime, endTime)
+	return price, nil
+}
+
+// instancePrice returns the hourly price of the instance type in the region.
+func (model *AwsPriceModel) instancePrice(region, instanceType string) (float64, bool) {
+	if instanceType == "" {
+		return 0, false
+	}
+	if price, found := model.overrides[region][instanceType]; found {
+		return price, true
+	}
+	price, found := InstancePrices[region][instanceType]
+	return price, found
+}
+
+// PodPrice returns a theoretical minimum price of running a pod for a given
+// period of time on a perfectly matching machine.
+func (model *AwsPriceModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	price := 0.0
+	for _, container := range pod.Spec.Containers {
+		price += getBasePrice(container.Resources.Requests, startTime, endTime)
+		price += getAdditionalPrice(container.Resources.Requests, startTime, endTime)
+	}
+	return price, nil
+}
+
+func getHours(startTime time.Time, endTime time.Time) float64 {
+	minutes := math.Ceil(float64(endTime.Sub(startTime)) / float64(time.Minute))
+	hours := minutes / 60.0
+	return hours
+}
+
+func getBasePrice(resources apiv1.ResourceList, startTime time.Time, endTime time.Time) float64 {
+	if len(resources) == 0 {
+		return 0
+	}
+	hours := getHours(startTime, endTime)
+	price := 0.0
+	cpu := resources[apiv1.ResourceCPU]
+	mem := resources[apiv1.ResourceMemory]
+	price += float64(cpu.MilliValue()) / 1000.0 * cpuPricePerHour * hours
+	price += float64(mem.Value()) / float64(units.Gigabyte) * memoryPricePerHourPerGb * hours
+	return price
+}
+
+func getAdditionalPrice(resources apiv1.ResourceList, startTime time.Time, endTime time.Time) float64 {
+	if len(resources) == 0 {
+		return 0
+	}
+	hours := getHours(startTime, endTime)
+	gpus := resources[gpu.ResourceNvidiaGPU]
+	return float64(gpus.MilliValue()) / 1000.0 * gpuPricePerHour * hours
+}
@@ -23,7 +23,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
+// maxInstanceIdsPerDescribe is the number of instance ids filtered for by a single DescribeInstances query.
+const maxInstanceIdsPerDescribe = 100
+
 type ec2I interface {
+	DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error
 	DescribeLaunchTemplateVersions(input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error)
 }
 
@@ -55,3 +59,34 @@ func (m ec2Wrapper) getInstanceTypeByLT(name string, version string) (string, er
 
 	return aws.StringValue(instanceType), nil
 }
+
+// getInstanceTypesByIds returns the instance types of the given instances by instance id. Instances
+// that no longer exist are missing from the result.
+func (m ec2Wrapper) getInstanceTypesByIds(ids []string) (map[string]string, error) {
+	instanceTypes := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += maxInstanceIdsPerDescribe {
+		end := start + maxInstanceIdsPerDescribe
+		if end > len(ids) {
+			end = len(ids)
+		}
+		// Instances are filtered by id instead of being listed in InstanceIds, which fails the whole
+		// query with InvalidInstanceID.NotFound when any of the instances is already gone.
+		input := &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{{
+				Name:   aws.String("instance-id"),
+				Values: aws.StringSlice(ids[start:end]),
+			}},
+		}
+		if err := m.DescribeInstancesPages(input, func(output *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, reservation := range output.Reservations {
+				for _, instance := range reservation.Instances {
+					instanceTypes[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.InstanceType)
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return instanceTypes, nil
+}
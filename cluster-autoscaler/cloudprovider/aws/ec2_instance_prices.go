@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+// InstancePrices is a map of hourly on-demand prices in USD of Linux ec2 instances, by region
+// and instance type. The checked-in table is a hand-picked subset of the c4, c5, m4, m5, p2, p3,
+// r4, r5 and t2 types in us-east-1 and us-west-2. Running go generate replaces it with the prices
+// of all instance types in all regions.
+var InstancePrices = map[string]map[string]float64{
+	"us-east-1": {
+		"c4.2xlarge":  0.398,
+		"c4.4xlarge":  0.796,
+		"c4.8xlarge":  1.591,
+		"c4.large":    0.1,
+		"c4.xlarge":   0.199,
+		"c5.18xlarge": 3.06,
+		"c5.2xlarge":  0.34,
+		"c5.4xlarge":  0.68,
+		"c5.9xlarge":  1.53,
+		"c5.large":    0.085,
+		"c5.xlarge":   0.17,
+		"m4.10xlarge": 2,
+		"m4.16xlarge": 3.2,
+		"m4.2xlarge":  0.4,
+		"m4.4xlarge":  0.8,
+		"m4.large":    0.1,
+		"m4.xlarge":   0.2,
+		"m5.12xlarge": 2.304,
+		"m5.24xlarge": 4.608,
+		"m5.2xlarge":  0.384,
+		"m5.4xlarge":  0.768,
+		"m5.large":    0.096,
+		"m5.xlarge":   0.192,
+		"p2.16xlarge": 14.4,
+		"p2.8xlarge":  7.2,
+		"p2.xlarge":   0.9,
+		"p3.16xlarge": 24.48,
+		"p3.2xlarge":  3.06,
+		"p3.8xlarge":  12.24,
+		"r4.16xlarge": 4.256,
+		"r4.2xlarge":  0.532,
+		"r4.4xlarge":  1.064,
+		"r4.8xlarge":  2.128,
+		"r4.large":    0.133,
+		"r4.xlarge":   0.266,
+		"r5.12xlarge": 3.024,
+		"r5.24xlarge": 6.048,
+		"r5.2xlarge":  0.504,
+		"r5.4xlarge":  1.008,
+		"r5.large":    0.126,
+		"r5.xlarge":   0.252,
+		"t2.2xlarge":  0.3712,
+		"t2.large":    0.0928,
+		"t2.medium":   0.0464,
+		"t2.micro":    0.0116,
+		"t2.nano":     0.0058,
+		"t2.small":    0.023,
+		"t2.xlarge":   0.1856,
+	},
+	"us-west-2": {
+		"c4.2xlarge":  0.398,
+		"c4.4xlarge":  0.796,
+		"c4.8xlarge":  1.591,
+		"c4.large":    0.1,
+		"c4.xlarge":   0.199,
+		"c5.18xlarge": 3.06,
+		"c5.2xlarge":  0.34,
+		"c5.4xlarge":  0.68,
+		"c5.9xlarge":  1.53,
+		"c5.large":    0.085,
+		"c5.xlarge":   0.17,
+		"m4.10xlarge": 2,
+		"m4.16xlarge": 3.2,
+		"m4.2xlarge":  0.4,
+		"m4.4xlarge":  0.8,
+		"m4.large":    0.1,
+		"m4.xlarge":   0.2,
+		"m5.12xlarge": 2.304,
+		"m5.24xlarge": 4.608,
+		"m5.2xlarge":  0.384,
+		"m5.4xlarge":  0.768,
+		"m5.large":    0.096,
+		"m5.xlarge":   0.192,
+		"p2.16xlarge": 14.4,
+		"p2.8xlarge":  7.2,
+		"p2.xlarge":   0.9,
+		"p3.16xlarge": 24.48,
+		"p3.2xlarge":  3.06,
+		"p3.8xlarge":  12.24,
+		"r4.16xlarge": 4.256,
+		"r4.2xlarge":  0.532,
+		"r4.4xlarge":  1.064,
+		"r4.8xlarge":  2.128,
+		"r4.large":    0.133,
+		"r4.xlarge":   0.266,
+		"r5.12xlarge": 3.024,
+		"r5.24xlarge": 6.048,
+		"r5.2xlarge":  0.504,
+		"r5.4xlarge":  1.008,
+		"r5.large":    0.126,
+		"r5.xlarge":   0.252,
+		"t2.2xlarge":  0.3712,
+		"t2.large":    0.0928,
+		"t2.medium":   0.0464,
+		"t2.micro":    0.0116,
+		"t2.nano":     0.0058,
+		"t2.small":    0.023,
+		"t2.xlarge":   0.1856,
+	},
+}
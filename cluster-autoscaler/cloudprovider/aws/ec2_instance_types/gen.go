@@ -35,17 +35,36 @@ import (
 
 type response struct {
 	Products map[string]product `json:"products"`
+	Terms    terms              `json:"terms"`
 }
 
 type product struct {
+	Sku        string            `json:"sku"`
 	Attributes productAttributes `json:"attributes"`
 }
 
 type productAttributes struct {
-	InstanceType string `json:"instanceType"`
-	VCPU         string `json:"vcpu"`
-	Memory       string `json:"memory"`
-	GPU          string `json:"gpu"`
+	InstanceType    string `json:"instanceType"`
+	VCPU            string `json:"vcpu"`
+	Memory          string `json:"memory"`
+	GPU             string `json:"gpu"`
+	OperatingSystem string `json:"operatingSystem"`
+	Tenancy         string `json:"tenancy"`
+	PreInstalledSw  string `json:"preInstalledSw"`
+	CapacityStatus  string `json:"capacitystatus"`
+}
+
+type terms struct {
+	OnDemand map[string]map[string]term `json:"OnDemand"`
+}
+
+type term struct {
+	PriceDimensions map[string]priceDimension `json:"priceDimensions"`
+}
+
+type priceDimension struct {
+	Unit         string            `json:"unit"`
+	PricePerUnit map[string]string `json:"pricePerUnit"`
 }
 
 type instanceType struct {
@@ -95,11 +114,45 @@ var InstanceTypes = map[string]*instanceType{
 }
 `))
 
+var pricesTemplate = template.Must(template.New("").Parse(`/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by go generate; DO NOT EDIT
+
+package aws
+
+// InstancePrices is a map of hourly on-demand prices in USD of Linux ec2 instances, by region
+// and instance type.
+var InstancePrices = map[string]map[string]float64{
+{{- range $region, $prices := .InstancePrices }}
+	"{{ $region }}": {
+	{{- range $instanceType, $price := $prices }}
+		"{{ $instanceType }}": {{ $price }},
+	{{- end }}
+	},
+{{- end }}
+}
+`))
+
 func main() {
 	flag.Parse()
 	defer glog.Flush()
 
 	instanceTypes := make(map[string]*instanceType)
+	instancePrices := make(map[string]map[string]float64)
 
 	resolver := endpoints.DefaultResolver()
 	partitions := resolver.(endpoints.EnumPartitions).Partitions()
@@ -144,6 +197,12 @@ func main() {
 					if attr.GPU != "" {
 						instanceTypes[attr.InstanceType].GPU = parseCPU(attr.GPU)
 					}
+					if price, found := onDemandPrice(product, unmarshalled.Terms); found {
+						if instancePrices[r.ID()] == nil {
+							instancePrices[r.ID()] = make(map[string]float64)
+						}
+						instancePrices[r.ID()][attr.InstanceType] = price
+					}
 				}
 			}
 		}
@@ -165,6 +224,49 @@ func main() {
 	if err != nil {
 		glog.Fatal(err)
 	}
+
+	pf, err := os.Create("ec2_instance_prices.go")
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	defer pf.Close()
+
+	err = pricesTemplate.Execute(pf, struct {
+		InstancePrices map[string]map[string]float64
+	}{
+		InstancePrices: instancePrices,
+	})
+
+	if err != nil {
+		glog.Fatal(err)
+	}
+}
+
+// onDemandPrice returns the hourly on-demand price of the product, if it is a plain
+// Linux instance on shared tenancy.
+func onDemandPrice(p product, t terms) (float64, bool) {
+	attr := p.Attributes
+	if attr.OperatingSystem != "Linux" || attr.Tenancy != "Shared" || attr.PreInstalledSw != "NA" {
+		return 0, false
+	}
+	// Capacity reservations are listed as separate products.
+	if attr.CapacityStatus != "" && attr.CapacityStatus != "Used" {
+		return 0, false
+	}
+	for _, term := range t.OnDemand[p.Sku] {
+		for _, dimension := range term.PriceDimensions {
+			if dimension.Unit != "Hrs" {
+				continue
+			}
+			price, err := strconv.ParseFloat(dimension.PricePerUnit["USD"], 64)
+			if err != nil || price == 0 {
+				continue
+			}
+			return price, true
+		}
+	}
+	return 0, false
 }
 
 func parseMemory(memory string) int64 {
@@ -31,6 +31,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
@@ -111,6 +112,12 @@ func (as *AgentPool) Autoprovisioned() bool {
 	return false
 }
 
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (as *AgentPool) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
 // MaxSize returns maximum size of the node group.
 func (as *AgentPool) MaxSize() int {
 	return as.maxSize
@@ -380,21 +387,24 @@ func (as *AgentPool) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (as *AgentPool) Nodes() ([]string, error) {
+func (as *AgentPool) Nodes() ([]cloudprovider.Instance, error) {
 	instances, err := as.GetVirtualMachines()
 	if err != nil {
 		return nil, err
 	}
 
-	nodes := make([]string, 0, len(instances))
+	nodes := make([]cloudprovider.Instance, 0, len(instances))
 	for _, instance := range instances {
 		if len(*instance.ID) == 0 {
 			continue
 		}
 
 		// To keep consistent with providerID from kubernetes cloud provider, do not convert ID to lower case.
-		name := "azure://" + *instance.ID
-		nodes = append(nodes, name)
+		node := cloudprovider.Instance{Id: "azure://" + *instance.ID}
+		if instance.VirtualMachineProperties != nil {
+			node.Status = instanceStatusFromVM(instance.ProvisioningState, instance.InstanceView)
+		}
+		nodes = append(nodes, node)
 	}
 
 	return nodes, nil
@@ -33,13 +33,15 @@ const (
 // AzureCloudProvider provides implementation of CloudProvider interface for Azure.
 type AzureCloudProvider struct {
 	azureManager    *AzureManager
+	priceModel      *AzurePriceModel
 	resourceLimiter *cloudprovider.ResourceLimiter
 }
 
 // BuildAzureCloudProvider creates new AzureCloudProvider
-func BuildAzureCloudProvider(azureManager *AzureManager, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
+func BuildAzureCloudProvider(azureManager *AzureManager, priceModel *AzurePriceModel, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
 	azure := &AzureCloudProvider{
 		azureManager:    azureManager,
+		priceModel:      priceModel,
 		resourceLimiter: resourceLimiter,
 	}
 
@@ -80,7 +82,7 @@ func (azure *AzureCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovid
 
 // Pricing returns pricing model for this cloud provider or error if not available.
 func (azure *AzureCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
-	return nil, cloudprovider.ErrNotImplemented
+	return azure.priceModel, nil
 }
 
 // GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
@@ -77,7 +77,7 @@ func TestBuildAzureCloudProvider(t *testing.T) {
 		map[string]int64{cloudprovider.ResourceNameCores: 1, cloudprovider.ResourceNameMemory: 10000000},
 		map[string]int64{cloudprovider.ResourceNameCores: 10, cloudprovider.ResourceNameMemory: 100000000})
 	m := newTestAzureManager(t)
-	_, err := BuildAzureCloudProvider(m, resourceLimiter)
+	_, err := BuildAzureCloudProvider(m, newAzurePriceModel(nil), resourceLimiter)
 	assert.NoError(t, err)
 }
 
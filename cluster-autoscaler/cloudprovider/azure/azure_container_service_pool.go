@@ -25,6 +25,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
@@ -390,21 +391,13 @@ func (agentPool *ContainerServiceAgentPool) IsContainerServiceNode(tags map[stri
 //GetNodes extracts the node list from the underlying vm service and returns back
 //equivalent providerIDs  as list.
 func (agentPool *ContainerServiceAgentPool) GetNodes() ([]string, error) {
-	ctx, cancel := getContextWithCancel()
-	defer cancel()
-	vmList, err := agentPool.manager.azClient.virtualMachinesClient.List(ctx, agentPool.nodeResourceGroup)
+	instances, err := agentPool.Nodes()
 	if err != nil {
-		glog.Error("Error", err)
 		return nil, err
 	}
 	var nodeArray []string
-	for _, node := range vmList {
-		glog.V(5).Infof("Node Name: %s, ID: %s", *node.Name, *node.ID)
-		if agentPool.IsContainerServiceNode(node.Tags) {
-			providerID := agentPool.GetProviderID(*node.ID)
-			glog.V(5).Infof("Returning back the providerID: %s", providerID)
-			nodeArray = append(nodeArray, providerID)
-		}
+	for _, instance := range instances {
+		nodeArray = append(nodeArray, instance.Id)
 	}
 	return nodeArray, nil
 }
@@ -444,9 +437,29 @@ func (agentPool *ContainerServiceAgentPool) Debug() string {
 	return fmt.Sprintf("%s (%d:%d)", agentPool.Id(), agentPool.MinSize(), agentPool.MaxSize())
 }
 
-//Nodes returns the list of nodes in the agentPool.
-func (agentPool *ContainerServiceAgentPool) Nodes() ([]string, error) {
-	return agentPool.GetNodes()
+//Nodes returns the list of nodes in the agentPool, with the status of their VMs.
+func (agentPool *ContainerServiceAgentPool) Nodes() ([]cloudprovider.Instance, error) {
+	ctx, cancel := getContextWithCancel()
+	defer cancel()
+	vmList, err := agentPool.manager.azClient.virtualMachinesClient.List(ctx, agentPool.nodeResourceGroup)
+	if err != nil {
+		glog.Error("Error", err)
+		return nil, err
+	}
+	var instances []cloudprovider.Instance
+	for _, node := range vmList {
+		glog.V(5).Infof("Node Name: %s, ID: %s", *node.Name, *node.ID)
+		if agentPool.IsContainerServiceNode(node.Tags) {
+			providerID := agentPool.GetProviderID(*node.ID)
+			glog.V(5).Infof("Returning back the providerID: %s", providerID)
+			instance := cloudprovider.Instance{Id: providerID}
+			if node.VirtualMachineProperties != nil {
+				instance.Status = instanceStatusFromVM(node.ProvisioningState, node.InstanceView)
+			}
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
 }
 
 //TemplateNodeInfo is not implemented.
@@ -476,3 +489,9 @@ func (agentPool *ContainerServiceAgentPool) Delete() error {
 func (agentPool *ContainerServiceAgentPool) Autoprovisioned() bool {
 	return false
 }
+
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (agentPool *ContainerServiceAgentPool) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+// InstancePrices is a map of hourly pay-as-you-go prices in USD of Linux VMs, by region and
+// VM size. Prices of other regions and sizes can be given in a price override file.
+var InstancePrices = map[string]map[string]float64{
+	"eastus": {
+		"Standard_A1_v2":    0.043,
+		"Standard_A2_v2":    0.091,
+		"Standard_A4_v2":    0.191,
+		"Standard_A8_v2":    0.4,
+		"Standard_B1ms":     0.0207,
+		"Standard_B1s":      0.0104,
+		"Standard_B2ms":     0.0832,
+		"Standard_B2s":      0.0416,
+		"Standard_B4ms":     0.166,
+		"Standard_B8ms":     0.333,
+		"Standard_D16_v3":   0.768,
+		"Standard_D16s_v3":  0.768,
+		"Standard_D1_v2":    0.057,
+		"Standard_D2_v2":    0.114,
+		"Standard_D2_v3":    0.096,
+		"Standard_D2s_v3":   0.096,
+		"Standard_D32_v3":   1.536,
+		"Standard_D32s_v3":  1.536,
+		"Standard_D3_v2":    0.229,
+		"Standard_D4_v2":    0.458,
+		"Standard_D4_v3":    0.192,
+		"Standard_D4s_v3":   0.192,
+		"Standard_D5_v2":    0.916,
+		"Standard_D64_v3":   3.072,
+		"Standard_D64s_v3":  3.072,
+		"Standard_D8_v3":    0.384,
+		"Standard_D8s_v3":   0.384,
+		"Standard_DS1_v2":   0.057,
+		"Standard_DS2_v2":   0.114,
+		"Standard_DS3_v2":   0.229,
+		"Standard_DS4_v2":   0.458,
+		"Standard_DS5_v2":   0.916,
+		"Standard_E16_v3":   1.008,
+		"Standard_E16s_v3":  1.008,
+		"Standard_E2_v3":    0.126,
+		"Standard_E2s_v3":   0.126,
+		"Standard_E32_v3":   2.016,
+		"Standard_E32s_v3":  2.016,
+		"Standard_E4_v3":    0.252,
+		"Standard_E4s_v3":   0.252,
+		"Standard_E64_v3":   4.032,
+		"Standard_E64s_v3":  4.032,
+		"Standard_E8_v3":    0.504,
+		"Standard_E8s_v3":   0.504,
+		"Standard_F16s_v2":  0.677,
+		"Standard_F2s_v2":   0.085,
+		"Standard_F32s_v2":  1.353,
+		"Standard_F4s_v2":   0.169,
+		"Standard_F64s_v2":  2.706,
+		"Standard_F8s_v2":   0.338,
+		"Standard_NC12":     1.8,
+		"Standard_NC12s_v2": 4.14,
+		"Standard_NC12s_v3": 6.12,
+		"Standard_NC24":     3.6,
+		"Standard_NC24s_v2": 8.28,
+		"Standard_NC24s_v3": 12.24,
+		"Standard_NC6":      0.9,
+		"Standard_NC6s_v2":  2.07,
+		"Standard_NC6s_v3":  3.06,
+	},
+	"westus2": {
+		"Standard_A1_v2":    0.043,
+		"Standard_A2_v2":    0.091,
+		"Standard_A4_v2":    0.191,
+		"Standard_A8_v2":    0.4,
+		"Standard_B1ms":     0.0207,
+		"Standard_B1s":      0.0104,
+		"Standard_B2ms":     0.0832,
+		"Standard_B2s":      0.0416,
+		"Standard_B4ms":     0.166,
+		"Standard_B8ms":     0.333,
+		"Standard_D16_v3":   0.768,
+		"Standard_D16s_v3":  0.768,
+		"Standard_D1_v2":    0.057,
+		"Standard_D2_v2":    0.114,
+		"Standard_D2_v3":    0.096,
+		"Standard_D2s_v3":   0.096,
+		"Standard_D32_v3":   1.536,
+		"Standard_D32s_v3":  1.536,
+		"Standard_D3_v2":    0.229,
+		"Standard_D4_v2":    0.458,
+		"Standard_D4_v3":    0.192,
+		"Standard_D4s_v3":   0.192,
+		"Standard_D5_v2":    0.916,
+		"Standard_D64_v3":   3.072,
+		"Standard_D64s_v3":  3.072,
+		"Standard_D8_v3":    0.384,
+		"Standard_D8s_v3":   0.384,
+		"Standard_DS1_v2":   0.057,
+		"Standard_DS2_v2":   0.114,
+		"Standard_DS3_v2":   0.229,
+		"Standard_DS4_v2":   0.458,
+		"Standard_DS5_v2":   0.916,
+		"Standard_E16_v3":   1.008,
+		"Standard_E16s_v3":  1.008,
+		"Standard_E2_v3":    0.126,
+		"Standard_E2s_v3":   0.126,
+		"Standard_E32_v3":   2.016,
+		"Standard_E32s_v3":  2.016,
+		"Standard_E4_v3":    0.252,
+		"Standard_E4s_v3":   0.252,
+		"Standard_E64_v3":   4.032,
+		"Standard_E64s_v3":  4.032,
+		"Standard_E8_v3":    0.504,
+		"Standard_E8s_v3":   0.504,
+		"Standard_F16s_v2":  0.677,
+		"Standard_F2s_v2":   0.085,
+		"Standard_F32s_v2":  1.353,
+		"Standard_F4s_v2":   0.169,
+		"Standard_F64s_v2":  2.706,
+		"Standard_F8s_v2":   0.338,
+		"Standard_NC12":     1.8,
+		"Standard_NC12s_v2": 4.14,
+		"Standard_NC12s_v3": 6.12,
+		"Standard_NC24":     3.6,
+		"Standard_NC24s_v2": 8.28,
+		"Standard_NC24s_v3": 12.24,
+		"Standard_NC6":      0.9,
+		"Standard_NC6s_v2":  2.07,
+		"Standard_NC6s_v3":  3.06,
+	},
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+)
+
+const (
+	// Prices of resources of VM sizes missing from the price tables, based on
+	// general purpose VMs.
+	cpuPricePerHour         = 0.0325
+	memoryPricePerHourPerGb = 0.0040
+	gpuPricePerHour         = 0.900
+)
+
+// AzurePriceModel implements PriceModel interface for Azure. VMs of scale sets and agent pools
+// are priced by region and VM size, using the prices in InstancePrices, unless overridden.
+type AzurePriceModel struct {
+	// prices by lower case region and VM size, with the overrides applied.
+	prices map[string]map[string]float64
+}
+
+// NewAzurePriceModel builds an AzurePriceModel. Prices of InstancePrices can be overridden, for
+// example with negotiated or low priority prices, by a JSON file mapping regions to VM sizes to
+// hourly prices in USD:
+//
+//   {"eastus": {"Standard_D2_v3": 0.05}}
+func NewAzurePriceModel(overridesFile string) (*AzurePriceModel, error) {
+	if overridesFile == "" {
+		return newAzurePriceModel(nil), nil
+	}
+	f, err := os.Open(overridesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return newAzurePriceModelFromReader(f)
+}
+
+func newAzurePriceModelFromReader(overridesReader io.Reader) (*AzurePriceModel, error) {
+	var overrides map[string]map[string]float64
+	if err := json.NewDecoder(overridesReader).Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse price overrides: %v", err)
+	}
+	return newAzurePriceModel(overrides), nil
+}
+
+func newAzurePriceModel(overrides map[string]map[string]float64) *AzurePriceModel {
+	model := &AzurePriceModel{
+		prices: make(map[string]map[string]float64),
+	}
+	// Nodes report VM sizes and regions with varying case.
+	for _, prices := range []map[string]map[string]float64{InstancePrices, overrides} {
+		for region, regionPrices := range prices {
+			region = strings.ToLower(region)
+			if model.prices[region] == nil {
+				model.prices[region] = make(map[string]float64)
+			}
+			for vmSize, price := range regionPrices {
+				model.prices[region][strings.ToLower(vmSize)] = price
+			}
+		}
+	}
+	return model
+}
+
+// NodePrice returns a price of running the given node for a given period of time.
+// All prices are in USD.
+func (model *AzurePriceModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	region := strings.ToLower(node.Labels[kubeletapis.LabelZoneRegion])
+	vmSize := strings.ToLower(node.Labels[kubeletapis.LabelInstanceType])
+	if basePricePerHour, found := model.prices[region][vmSize]; found && vmSize != "" {
+		return basePricePerHour * getHours(startTime, endTime), nil
+	}
+	price := getBasePrice(node.Status.Capacity, startTime, endTime)
+	price += getAdditionalPrice(node.Status.Capacity, startTime, endTime)
+	return price, nil
+}
+
+// PodPrice returns a theoretical minimum price of running a pod for a given
+// period of time on a perfectly matching machine.
+func (model *AzurePriceModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	price := 0.0
+	for _, container := range pod.Spec.Containers {
+		price += getBasePrice(container.Resources.Requests, startTime, endTime)
+		price += getAdditionalPrice(container.Resources.Requests, startTime, endTime)
+	}
+	return price, nil
+}
+
+func getHours(startTime time.Time, endTime time.Time) float64 {
+	minutes := math.Ceil(float64(endTime.Sub(startTime)) / float64(time.Minute))
+	hours := minutes / 60.0
+	return hours
+}
+
+func getBasePrice(resources apiv1.ResourceList, startTime time.Time, endTime time.Time) float64 {
+	if len(resources) == 0 {
+		return 0
+	}
+	hours := getHours(startTime, endTime)
+	price := 0.0
+	cpu := resources[apiv1.ResourceCPU]
+	mem := resources[apiv1.ResourceMemory]
+	price += float64(cpu.MilliValue()) / 1000.0 * cpuPricePerHour * hours
+	price += float64(mem.Value()) / float64(units.Gigabyte) * memoryPricePerHourPerGb * hours
+	return price
+}
+
+func getAdditionalPrice(resources apiv1.ResourceList, startTime time.Time, endTime time.Time) float64 {
+	if len(resources) == 0 {
+		return 0
+	}
+	hours := getHours(startTime, endTime)
+	gpus := resources[gpu.ResourceNvidiaGPU]
+	return float64(gpus.MilliValue()) / 1000.0 * gpuPricePerHour * hours
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+)
+
+func TestGetNodePrice(t *testing.T) {
+	model, err := NewAzurePriceModel("")
+	assert.NoError(t, err)
+	now := time.Now()
+
+	// known VM size, reported with a different case
+	node1 := BuildTestNode("node1", 2000, 8*1024*1024*1024)
+	node1.Labels = map[string]string{
+		kubeletapis.LabelZoneRegion:   "eastus",
+		kubeletapis.LabelInstanceType: "standard_d2_v3",
+	}
+	price1, err := model.NodePrice(node1, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, InstancePrices["eastus"]["Standard_D2_v3"], price1)
+
+	// unknown VM size, priced by its resources close to a similar known one
+	node2 := BuildTestNode("node2", 2000, 8*1024*1024*1024)
+	price2, err := model.NodePrice(node2, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, math.Abs(price1-price2) < 0.2*price1)
+
+	// unknown VM size with gpu
+	node3 := BuildTestNode("node3", 2000, 8*1024*1024*1024)
+	node3.Status.Capacity[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(1, resource.DecimalSI)
+	price3, err := model.NodePrice(node3, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, price2+gpuPricePerHour, price3, 1e-9)
+}
+
+func TestGetNodePriceWithOverrides(t *testing.T) {
+	model, err := newAzurePriceModelFromReader(strings.NewReader(`{"eastus": {"Standard_D2_v3": 0.05}, "northeurope": {"Standard_D2_v3": 0.11}}`))
+	assert.NoError(t, err)
+	now := time.Now()
+
+	node := BuildTestNode("node1", 2000, 8*1024*1024*1024)
+	node.Labels = map[string]string{
+		kubeletapis.LabelZoneRegion:   "eastus",
+		kubeletapis.LabelInstanceType: "Standard_D2_v3",
+	}
+	price, err := model.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.05, price)
+
+	node.Labels[kubeletapis.LabelZoneRegion] = "northeurope"
+	price, err = model.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.11, price)
+
+	// other regions keep the embedded price
+	node.Labels[kubeletapis.LabelZoneRegion] = "westus2"
+	price, err = model.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, InstancePrices["westus2"]["Standard_D2_v3"], price)
+
+	_, err = newAzurePriceModelFromReader(strings.NewReader(`["eastus"]`))
+	assert.Error(t, err)
+}
+
+func TestGetPodPrice(t *testing.T) {
+	pod1 := BuildTestPod("a1", 100, 500*1024*1024)
+	pod2 := BuildTestPod("a2", 2*100, 2*500*1024*1024)
+
+	model := newAzurePriceModel(nil)
+	now := time.Now()
+
+	price1, err := model.PodPrice(pod1, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	price2, err := model.PodPrice(pod2, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	// 2 times bigger pod should cost twice as much.
+	assert.True(t, math.Abs(price1*2-price2) < 0.001)
+}
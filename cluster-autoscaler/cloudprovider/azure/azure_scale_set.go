@@ -19,6 +19,7 @@ package azure
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
@@ -90,6 +92,12 @@ func (scaleSet *ScaleSet) Autoprovisioned() bool {
 	return false
 }
 
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (scaleSet *ScaleSet) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
 // MaxSize returns maximum size of the node group.
 func (scaleSet *ScaleSet) MaxSize() int {
 	return scaleSet.maxSize
@@ -372,6 +380,16 @@ func buildInstanceOS(template compute.VirtualMachineScaleSet) string {
 	return instanceOS
 }
 
+// buildZone returns the zone of the nodes of a scale set, labeled <location>-<zone> like zonal
+// nodes are. Nodes of scale sets that are not pinned to a single zone are labeled with their
+// fault domain, which is unknown until they are created.
+func buildZone(template compute.VirtualMachineScaleSet) string {
+	if template.Zones != nil && len(*template.Zones) == 1 {
+		return fmt.Sprintf("%s-%s", strings.ToLower(*template.Location), (*template.Zones)[0])
+	}
+	return "0"
+}
+
 func buildGenericLabels(template compute.VirtualMachineScaleSet, nodeName string) map[string]string {
 	result := make(map[string]string)
 
@@ -379,7 +397,7 @@ func buildGenericLabels(template compute.VirtualMachineScaleSet, nodeName string
 	result[kubeletapis.LabelOS] = buildInstanceOS(template)
 	result[kubeletapis.LabelInstanceType] = *template.Sku.Name
 	result[kubeletapis.LabelZoneRegion] = *template.Location
-	result[kubeletapis.LabelZoneFailureDomain] = "0"
+	result[kubeletapis.LabelZoneFailureDomain] = buildZone(template)
 	result[kubeletapis.LabelHostname] = nodeName
 	return result
 }
@@ -446,19 +464,22 @@ func (scaleSet *ScaleSet) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (scaleSet *ScaleSet) Nodes() ([]string, error) {
+func (scaleSet *ScaleSet) Nodes() ([]cloudprovider.Instance, error) {
 	vms, err := scaleSet.GetScaleSetVms()
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]string, 0, len(vms))
+	result := make([]cloudprovider.Instance, 0, len(vms))
 	for i := range vms {
 		if len(*vms[i].ID) == 0 {
 			continue
 		}
-		name := "azure://" + *vms[i].ID
-		result = append(result, name)
+		instance := cloudprovider.Instance{Id: "azure://" + *vms[i].ID}
+		if vms[i].VirtualMachineScaleSetVMProperties != nil {
+			instance.Status = instanceStatusFromVM(vms[i].ProvisioningState, vms[i].InstanceView)
+		}
+		result = append(result, instance)
 	}
 
 	return result, nil
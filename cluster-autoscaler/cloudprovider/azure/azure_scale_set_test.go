@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2017-12-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -121,7 +122,7 @@ func TestDeleteNodes(t *testing.T) {
 	resourceLimiter := cloudprovider.NewResourceLimiter(
 		map[string]int64{cloudprovider.ResourceNameCores: 1, cloudprovider.ResourceNameMemory: 10000000},
 		map[string]int64{cloudprovider.ResourceNameCores: 10, cloudprovider.ResourceNameMemory: 100000000})
-	provider, err := BuildAzureCloudProvider(manager, resourceLimiter)
+	provider, err := BuildAzureCloudProvider(manager, newAzurePriceModel(nil), resourceLimiter)
 	assert.NoError(t, err)
 
 	registered := manager.RegisterAsg(
@@ -158,3 +159,16 @@ func TestDebug(t *testing.T) {
 	asg.Name = "test-scale-set"
 	assert.Equal(t, asg.Debug(), "test-scale-set (5:55)")
 }
+
+func TestBuildZone(t *testing.T) {
+	location := "EastUS2"
+	template := compute.VirtualMachineScaleSet{Location: &location}
+	assert.Equal(t, "0", buildZone(template))
+
+	zones := []string{"2"}
+	template.Zones = &zones
+	assert.Equal(t, "eastus2-2", buildZone(template))
+
+	zones = []string{"1", "2"}
+	assert.Equal(t, "0", buildZone(template))
+}
@@ -602,3 +602,52 @@ func checkResourceExistsFromError(err error) (bool, error) {
 	}
 	return false, v
 }
+
+// provisioningFailedPrefix prefixes the code of the instance view status of a VM that failed to be provisioned,
+// e.g. ProvisioningState/failed/AllocationFailed.
+const provisioningFailedPrefix = "ProvisioningState/failed/"
+
+// provisioningErrorClasses maps the error codes of failed provisionings to classes of instance creation errors.
+var provisioningErrorClasses = map[string]cloudprovider.InstanceErrorClass{
+	"AllocationFailed":                 cloudprovider.StockoutErrorClass,
+	"ZonalAllocationFailed":            cloudprovider.StockoutErrorClass,
+	"OverconstrainedAllocationRequest": cloudprovider.StockoutErrorClass,
+	"SkuNotAvailable":                  cloudprovider.StockoutErrorClass,
+	"QuotaExceeded":                    cloudprovider.QuotaExceededErrorClass,
+	"OperationNotAllowed":              cloudprovider.QuotaExceededErrorClass,
+	"InvalidParameter":                 cloudprovider.InvalidConfigurationErrorClass,
+	"ImageNotFound":                    cloudprovider.InvalidConfigurationErrorClass,
+}
+
+// instanceStatusFromVM returns the status of a VM from its provisioning state and, if it was fetched,
+// its instance view. A VM that failed to be provisioned is reported as an instance that couldn't be created.
+func instanceStatusFromVM(provisioningState *string, instanceView *compute.VirtualMachineInstanceView) *cloudprovider.InstanceStatus {
+	if provisioningState == nil {
+		return nil
+	}
+	switch {
+	case strings.EqualFold(*provisioningState, "Creating"):
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating}
+	case strings.EqualFold(*provisioningState, "Deleting"):
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceDeleting}
+	case strings.EqualFold(*provisioningState, "Failed"):
+		errorInfo := &cloudprovider.InstanceErrorInfo{ErrorClass: cloudprovider.OtherErrorClass}
+		if instanceView != nil && instanceView.Statuses != nil {
+			for _, status := range *instanceView.Statuses {
+				code := to.String(status.Code)
+				if !strings.HasPrefix(code, provisioningFailedPrefix) {
+					continue
+				}
+				errorInfo.ErrorCode = strings.TrimPrefix(code, provisioningFailedPrefix)
+				errorInfo.ErrorMessage = to.String(status.Message)
+				if class, found := provisioningErrorClasses[errorInfo.ErrorCode]; found {
+					errorInfo.ErrorClass = class
+				}
+				break
+			}
+		}
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating, ErrorInfo: errorInfo}
+	default:
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceRunning}
+	}
+}
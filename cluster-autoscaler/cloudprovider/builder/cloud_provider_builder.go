@@ -24,6 +24,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/azure"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gke"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/kubemark"
@@ -46,6 +47,7 @@ var AvailableCloudProviders = []string{
 	gke.ProviderNameGKE,
 	kubemark.ProviderName,
 	clusterapi.ProviderName,
+	externalgrpc.ProviderName,
 }
 
 // DefaultCloudProvider is GCE.
@@ -80,6 +82,11 @@ func NewCloudProvider(opts config.AutoscalingOptions) cloudprovider.CloudProvide
 		return buildKubemark(opts, do, rl)
 	case clusterapi.ProviderName:
 		return buildClusterAPI(clusterapi.ProviderName, opts, do, rl)
+	case externalgrpc.ProviderName:
+		if do.DiscoverySpecified() {
+			glog.Fatalf("externalgrpc gets nodegroup specification from its server, command line specs are not allowed")
+		}
+		return buildExternalGrpc(opts, rl)
 	case "":
 		// Ideally this would be an error, but several unit tests of the
 		// StaticAutoscaler depend on this behaviour.
@@ -153,7 +160,12 @@ func buildAWS(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscover
 		glog.Fatalf("Failed to create AWS Manager: %v", err)
 	}
 
-	provider, err := aws.BuildAwsCloudProvider(manager, rl)
+	priceModel, err := aws.NewAwsPriceModel(opts.AWSPricingOverrideFile)
+	if err != nil {
+		glog.Fatalf("Failed to read AWS pricing overrides %s: %v", opts.AWSPricingOverrideFile, err)
+	}
+
+	provider, err := aws.BuildAwsCloudProvider(manager, priceModel, rl)
 	if err != nil {
 		glog.Fatalf("Failed to create AWS cloud provider: %v", err)
 	}
@@ -177,7 +189,11 @@ func buildAzure(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscov
 	if err != nil {
 		glog.Fatalf("Failed to create Azure Manager: %v", err)
 	}
-	provider, err := azure.BuildAzureCloudProvider(manager, rl)
+	priceModel, err := azure.NewAzurePriceModel(opts.AzurePricingOverrideFile)
+	if err != nil {
+		glog.Fatalf("Failed to read Azure pricing overrides %s: %v", opts.AzurePricingOverrideFile, err)
+	}
+	provider, err := azure.BuildAzureCloudProvider(manager, priceModel, rl)
 	if err != nil {
 		glog.Fatalf("Failed to create Azure cloud provider: %v", err)
 	}
@@ -237,3 +253,20 @@ func buildClusterAPI(name string, opts config.AutoscalingOptions, do cloudprovid
 
 	return provider
 }
+
+func buildExternalGrpc(opts config.AutoscalingOptions, rl *cloudprovider.ResourceLimiter) cloudprovider.CloudProvider {
+	if opts.CloudConfig == "" {
+		glog.Fatalf("externalgrpc cloud provider requires a cloud provider configuration with the address of its server")
+	}
+	config, err := os.Open(opts.CloudConfig)
+	if err != nil {
+		glog.Fatalf("Couldn't open cloud provider configuration %s: %#v", opts.CloudConfig, err)
+	}
+	defer config.Close()
+
+	provider, err := externalgrpc.BuildExternalGrpcCloudProvider(config, rl)
+	if err != nil {
+		glog.Fatalf("Failed to create externalgrpc cloud provider: %v", err)
+	}
+	return provider
+}
@@ -25,6 +25,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
@@ -78,6 +79,108 @@ var ErrAlreadyExist errors.AutoscalerError = errors.NewAutoscalerError(errors.In
 // configuration that is not supported by cloudprovider.
 var ErrIllegalConfiguration errors.AutoscalerError = errors.NewAutoscalerError(errors.InternalError, "Configuration not allowed by cloud provider")
 
+// InstanceErrorClass describes why a cloud provider failed to create instances.
+type InstanceErrorClass string
+
+const (
+	// QuotaExceededErrorClass means that a quota of the account or project doesn't allow more instances.
+	QuotaExceededErrorClass InstanceErrorClass = "quotaExceeded"
+	// StockoutErrorClass means that the cloud provider is out of capacity for the instance type
+	// in the location of the node group, e.g. a zonal stockout.
+	StockoutErrorClass InstanceErrorClass = "stockout"
+	// InvalidConfigurationErrorClass means that instances can't be created from the node group
+	// template, e.g. because of a missing image or an invalid launch configuration.
+	InvalidConfigurationErrorClass InstanceErrorClass = "invalidConfiguration"
+	// TimeoutErrorClass means that instances were requested but didn't become nodes in time.
+	TimeoutErrorClass InstanceErrorClass = "timeout"
+	// OtherErrorClass is used for all errors that don't belong to any other class.
+	OtherErrorClass InstanceErrorClass = "other"
+)
+
+// InstanceCreationError is returned by NodeGroup.IncreaseSize when the cloud provider
+// knows why instances couldn't be created. The class decides how long the node group is
+// backed off and whether scale-up falls back to another node group.
+type InstanceCreationError struct {
+	// Class of the error.
+	Class InstanceErrorClass
+	// Code is the cloud provider specific error code, if any.
+	Code string
+	msg  string
+}
+
+// NewInstanceCreationError returns an InstanceCreationError with a message constructed from format string.
+func NewInstanceCreationError(class InstanceErrorClass, code string, msg string, args ...interface{}) InstanceCreationError {
+	return InstanceCreationError{
+		Class: class,
+		Code:  code,
+		msg:   fmt.Sprintf(msg, args...),
+	}
+}
+
+// Error implements golang error interface
+func (e InstanceCreationError) Error() string {
+	return e.msg
+}
+
+// Type returns the type of AutoscalerError
+func (e InstanceCreationError) Type() errors.AutoscalerErrorType {
+	return errors.CloudProviderError
+}
+
+// AddPrefix adds a prefix to error message, keeping the class and code.
+func (e InstanceCreationError) AddPrefix(msg string, args ...interface{}) errors.AutoscalerError {
+	e.msg = fmt.Sprintf(msg, args...) + e.msg
+	return e
+}
+
+// Instance is an instance of a node group on the cloud provider side. It doesn't need to
+// have a node in Kubernetes, e.g. while it's being created.
+type Instance struct {
+	// Id of the instance, equal to the provider id of its node.
+	Id string
+	// Status of the instance, nil if the cloud provider doesn't know it.
+	Status *InstanceStatus
+}
+
+// InstanceStatus is the status of an instance.
+type InstanceStatus struct {
+	// State tells whether the instance is running, being created or being deleted.
+	State InstanceState
+	// ErrorInfo is set if something went wrong with the instance, e.g. it couldn't be created.
+	ErrorInfo *InstanceErrorInfo
+}
+
+// InstanceState tells whether an instance is running, being created or being deleted.
+type InstanceState int
+
+const (
+	// InstanceRunning means that the instance is running.
+	InstanceRunning InstanceState = 1
+	// InstanceCreating means that the instance is being created.
+	InstanceCreating InstanceState = 2
+	// InstanceDeleting means that the instance is being deleted.
+	InstanceDeleting InstanceState = 3
+)
+
+// InstanceErrorInfo describes what went wrong with an instance.
+type InstanceErrorInfo struct {
+	// ErrorClass is the class of the error.
+	ErrorClass InstanceErrorClass
+	// ErrorCode is the cloud provider specific error code, if any.
+	ErrorCode string
+	// ErrorMessage is a human readable description of the error.
+	ErrorMessage string
+}
+
+// GetInstanceErrorClass returns the class of the given error, OtherErrorClass if it's not
+// an InstanceCreationError.
+func GetInstanceErrorClass(err error) InstanceErrorClass {
+	if e, ok := err.(InstanceCreationError); ok {
+		return e.Class
+	}
+	return OtherErrorClass
+}
+
 // NodeGroup contains configuration info and functions to control a set
 // of nodes that have the same capacity and set of labels.
 type NodeGroup interface {
@@ -95,7 +198,8 @@ type NodeGroup interface {
 
 	// IncreaseSize increases the size of the node group. To delete a node you need
 	// to explicitly name it and use DeleteNode. This function should wait until
-	// node group size is updated. Implementation required.
+	// node group size is updated. If the cloud provider knows why instances couldn't be
+	// created, an InstanceCreationError should be returned. Implementation required.
 	IncreaseSize(delta int) error
 
 	// DeleteNodes deletes nodes from this node group. Error is returned either on
@@ -116,8 +220,10 @@ type NodeGroup interface {
 	// Debug returns a string containing all information regarding this node group.
 	Debug() string
 
-	// Nodes returns a list of all nodes that belong to this node group.
-	Nodes() ([]string, error)
+	// Nodes returns a list of all instances that belong to this node group, including the ones
+	// that are still being created and have no node yet. The status of instances is optional,
+	// if it's known CA can give up early on instances that failed to be created.
+	Nodes() ([]Instance, error)
 
 	// TemplateNodeInfo returns a schedulercache.NodeInfo structure of an empty
 	// (as if just started) node. This will be used in scale-up simulations to
@@ -142,6 +248,11 @@ type NodeGroup interface {
 	// Autoprovisioned returns true if the node group is autoprovisioned. An autoprovisioned group
 	// was created by CA and can be deleted when scaled to 0.
 	Autoprovisioned() bool
+
+	// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+	// NodeGroup, starting from the given defaults. Returning a nil result means the defaults
+	// should be used. Implementation optional.
+	GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error)
 }
 
 // PricingModel contains information about the node price and how it changes in time.
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+
+	v1alpha1apis "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+// clusterMachineDeployment is a node group backed by a MachineDeployment. Nodes of all
+// MachineSets owned by the deployment belong to it, and it is scaled through the
+// deployment, so that the deployment controller doesn't revert the change.
+type clusterMachineDeployment struct {
+	*clusterManager
+	*v1alpha1apis.MachineDeployment
+}
+
+func (m *clusterMachineDeployment) Id() string {
+	return string(machineDeploymentID(m.MachineDeployment))
+}
+
+func (m *clusterMachineDeployment) Name() string {
+	return m.MachineDeployment.Name
+}
+
+func (m *clusterMachineDeployment) Namespace() string {
+	return m.MachineDeployment.Namespace
+}
+
+func (m *clusterMachineDeployment) MinSize() int {
+	return parseSize("machinedeployment", &m.MachineDeployment.ObjectMeta, minSizeKey)
+}
+
+func (m *clusterMachineDeployment) MaxSize() int {
+	return parseSize("machinedeployment", &m.MachineDeployment.ObjectMeta, maxSizeKey)
+}
+
+func (m *clusterMachineDeployment) Replicas() int {
+	if m.MachineDeployment.Spec.Replicas == nil {
+		return 0
+	}
+	glog.Infof("machinedeployment: %q has %d replicas", m.MachineDeployment.Name, *m.MachineDeployment.Spec.Replicas)
+	return int(*m.MachineDeployment.Spec.Replicas)
+}
+
+func (m *clusterMachineDeployment) SetSize(nreplicas int) error {
+	md, err := m.clusterapi.MachineDeployments(m.MachineDeployment.Namespace).Get(m.MachineDeployment.Name, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get machinedeployment %q: %v", m.MachineDeployment.Name, err)
+	}
+
+	newMachineDeployment := md.DeepCopy()
+	replicas := int32(nreplicas)
+	newMachineDeployment.Spec.Replicas = &replicas
+
+	_, err = m.clusterapi.MachineDeployments(m.MachineDeployment.Namespace).Update(newMachineDeployment)
+	if err != nil {
+		return fmt.Errorf("Unable to update number of replicas of machinedeployment %q: %v", m.MachineDeployment.Name, err)
+	}
+
+	return nil
+}
+
+func (m *clusterMachineDeployment) GetAnnotations() map[string]string {
+	return m.MachineDeployment.Annotations
+}
+
+func (m *clusterMachineDeployment) Nodes() ([]cloudprovider.Instance, error) {
+	result := make([]cloudprovider.Instance, 0)
+	for _, ms := range m.machineSetsOfMachineDeployment(m.MachineDeployment) {
+		result = append(result, machineInstances(m.machinesOfMachineSet(ms))...)
+	}
+	return result, nil
+}
+
+func (m *clusterMachineDeployment) DeleteNodes(nodenames []string) error {
+	if len(nodenames) == 0 {
+		return nil
+	}
+
+	if err := markMachinesForDeletion(m.clusterManager, machineDeploymentID(m.MachineDeployment), nodenames); err != nil {
+		return err
+	}
+
+	replicas := m.Replicas()
+	if replicas-len(nodenames) < 0 {
+		return fmt.Errorf("unable to delete %d machines in %s, machine replicas are < 0 ", len(nodenames), m)
+	}
+
+	return m.SetSize(replicas - len(nodenames))
+}
+
+func (m *clusterMachineDeployment) String() string {
+	return fmt.Sprintf("%s/%s", m.Namespace(), m.Name())
+}
+
+func newClusterMachineDeployment(m *clusterManager, md *v1alpha1apis.MachineDeployment) *clusterMachineDeployment {
+	return &clusterMachineDeployment{
+		clusterManager:    m,
+		MachineDeployment: md,
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDeploymentObjects returns a MachineDeployment owning a MachineSet, and a MachineSet of its own,
+// with a machine each.
+func testDeploymentObjects() []runtime.Object {
+	md := testMachineDeployment("default", "md", 2, testSizeAnnotations("1", "5"))
+	return []runtime.Object{
+		md,
+		testMachineSet("default", "md-abc", 2, nil, md),
+		testMachineSet("default", "ms", 1, testSizeAnnotations("0", "3"), nil),
+		testMachine("default", "md-abc-1", "md-abc", "node1"),
+		testMachine("default", "md-abc-2", "md-abc", ""),
+		testMachine("default", "ms-1", "ms", "node2"),
+		testMachine("default", "orphan", "none", "node3"),
+	}
+}
+
+func TestMachineDeploymentDiscovery(t *testing.T) {
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll}, testDeploymentObjects()...)
+
+	// The MachineSet owned by the deployment is not a node group of its own.
+	nodeGroups, err := m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default/ms", "machinedeployment/default/md"}, nodeGroupIds(nodeGroups))
+
+	for _, ng := range nodeGroups {
+		if ng.Id() != "machinedeployment/default/md" {
+			continue
+		}
+		assert.Equal(t, "md", ng.Name())
+		assert.Equal(t, "default", ng.Namespace())
+		assert.Equal(t, 1, ng.MinSize())
+		assert.Equal(t, 5, ng.MaxSize())
+		assert.Equal(t, 2, ng.Replicas())
+		// Machines without a node yet are reported under their own name.
+		instances, err := ng.Nodes()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"md-abc-2", "node1"}, instanceIds(instances))
+	}
+}
+
+func TestMachineDeploymentOwnership(t *testing.T) {
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll}, testDeploymentObjects()...)
+
+	// Machine -> MachineSet -> MachineDeployment.
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, "machinedeployment/default/md", ng.Id())
+
+	// Machine -> MachineSet without a deployment.
+	ng, err = m.MachineSetForNode("node2")
+	assert.NoError(t, err)
+	assert.Equal(t, "default/ms", ng.Id())
+
+	// Machine selected by no MachineSet.
+	ng, err = m.MachineSetForNode("node3")
+	assert.NoError(t, err)
+	assert.Nil(t, ng)
+
+	// No machine.
+	ng, err = m.MachineSetForNode("unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, ng)
+}
+
+func TestMachineDeploymentOwnedByUnknownDeployment(t *testing.T) {
+	md := testMachineDeployment("default", "md", 2, testSizeAnnotations("1", "5"))
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll},
+		testMachineSet("default", "md-abc", 2, testSizeAnnotations("1", "5"), md),
+		testMachine("default", "md-abc-1", "md-abc", "node1"))
+
+	nodeGroups, err := m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Empty(t, nodeGroups)
+
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+	assert.Nil(t, ng)
+}
+
+func TestMachineDeploymentScaling(t *testing.T) {
+	m, client := newTestClusterManager(t, []string{v1.NamespaceAll}, testDeploymentObjects()...)
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+
+	// The machine of a deleted node is marked for deletion before the deployment is scaled down.
+	assert.NoError(t, ng.DeleteNodes([]string{"node1"}))
+	machine, err := client.ClusterV1alpha1().Machines("default").Get("md-abc-1", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, machine.Annotations, machineDeleteAnnotationKey)
+	md, err := client.ClusterV1alpha1().MachineDeployments("default").Get("md", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), *md.Spec.Replicas)
+	other, err := client.ClusterV1alpha1().Machines("default").Get("md-abc-2", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, other.Annotations, machineDeleteAnnotationKey)
+
+	// Nodes of other node groups can't be deleted through the deployment.
+	assert.Error(t, ng.DeleteNodes([]string{"node2"}))
+	machine, err = client.ClusterV1alpha1().Machines("default").Get("ms-1", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, machine.Annotations, machineDeleteAnnotationKey)
+
+	// The deployment is scaled, not its MachineSet.
+	assert.NoError(t, ng.SetSize(3))
+	md, err = client.ClusterV1alpha1().MachineDeployments("default").Get("md", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), *md.Spec.Replicas)
+	ms, err := client.ClusterV1alpha1().MachineSets("default").Get("md-abc", v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), *ms.Spec.Replicas)
+}
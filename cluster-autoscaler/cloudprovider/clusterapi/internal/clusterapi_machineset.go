@@ -2,26 +2,21 @@ package internal
 
 import (
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/golang/glog"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 
 	v1alpha1apis "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
-const (
-	machineDeleteAnnotationKey = "sigs.k8s.io/cluster-api-delete-machine"
-)
-
 type clusterMachineSet struct {
 	*clusterManager
 	*v1alpha1apis.MachineSet
-	min     int
-	max     int
-	nodes   []string
-	nodeSet map[string]bool
+}
+
+func (m *clusterMachineSet) Id() string {
+	return string(machineSetID(m.MachineSet))
 }
 
 func (m *clusterMachineSet) Name() string {
@@ -33,11 +28,11 @@ func (m *clusterMachineSet) Namespace() string {
 }
 
 func (m *clusterMachineSet) MinSize() int {
-	return m.min
+	return parseSize("machineset", &m.MachineSet.ObjectMeta, minSizeKey)
 }
 
 func (m *clusterMachineSet) MaxSize() int {
-	return m.max
+	return parseSize("machineset", &m.MachineSet.ObjectMeta, maxSizeKey)
 }
 
 func (m *clusterMachineSet) Replicas() int {
@@ -66,8 +61,12 @@ func (m *clusterMachineSet) SetSize(nreplicas int) error {
 	return nil
 }
 
-func (m *clusterMachineSet) Nodes() ([]string, error) {
-	return m.nodes, nil
+func (m *clusterMachineSet) GetAnnotations() map[string]string {
+	return m.MachineSet.Annotations
+}
+
+func (m *clusterMachineSet) Nodes() ([]cloudprovider.Instance, error) {
+	return machineInstances(m.machinesOfMachineSet(m.MachineSet)), nil
 }
 
 func (m *clusterMachineSet) DeleteNodes(nodenames []string) error {
@@ -75,37 +74,13 @@ func (m *clusterMachineSet) DeleteNodes(nodenames []string) error {
 		return nil
 	}
 
-	snapshot := m.getClusterState()
-
-	for _, nodename := range nodenames {
-		name, exists := snapshot.NodeMap[machineSetID(m.MachineSet)][nodename]
-		if !exists {
-			return fmt.Errorf("cannot map nodename %q to machine", nodename)
-		}
-		machine, err := m.clusterapi.Machines(m.MachineSet.Namespace).Get(name, v1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("cannot get machine %s/%s: %v", m.MachineSet.Namespace, name, err)
-		}
-
-		machine = machine.DeepCopy()
-
-		if machine.Annotations == nil {
-			machine.Annotations = map[string]string{}
-		}
-
-		// Annotate machine that it is the chosen one.
-		// TODO(frobware) - don't hardcode this name
-		machine.Annotations["sigs.k8s.io/cluster-api-delete-machine"] = time.Now().String()
-
-		_, err = m.clusterapi.Machines(m.MachineSet.Namespace).Update(machine)
-		if err != nil {
-			return fmt.Errorf("unable to update machine %q: %v", machine.Name, err)
-		}
+	if err := markMachinesForDeletion(m.clusterManager, machineSetID(m.MachineSet), nodenames); err != nil {
+		return err
 	}
 
 	replicas := m.Replicas()
-	if replicas-len(nodenames) <= 0 {
-		return fmt.Errorf("unable to delete %d machines in %s, machine replicas are <= 0 ", len(nodenames), m)
+	if replicas-len(nodenames) < 0 {
+		return fmt.Errorf("unable to delete %d machines in %s, machine replicas are < 0 ", len(nodenames), m)
 	}
 
 	return m.SetSize(replicas - len(nodenames))
@@ -115,36 +90,9 @@ func (m *clusterMachineSet) String() string {
 	return fmt.Sprintf("%s/%s", m.Namespace(), m.Name())
 }
 
-func parseLabel(ms *v1alpha1apis.MachineSet, label string, result *int) {
-	val, exists := ms.Labels[label]
-	if !exists {
-		glog.Infof("machineset %s/%s has no label named %q", ms.Namespace, ms.Name, label)
-		return
-	}
-
-	u, err := strconv.ParseUint(val, 10, 32)
-	if err != nil {
-		glog.Errorf("machineset %s/%s: cannot parse %q as an integral value: %v", ms.Namespace, ms.Name, val, err)
-		return
-	}
-
-	*result = int(u)
-}
-
-func newClusterMachineSet(m *clusterManager, ms *v1alpha1apis.MachineSet, nodes []string) *clusterMachineSet {
-	cms := clusterMachineSet{
+func newClusterMachineSet(m *clusterManager, ms *v1alpha1apis.MachineSet) *clusterMachineSet {
+	return &clusterMachineSet{
 		clusterManager: m,
 		MachineSet:     ms,
-		nodes:          nodes,
-		nodeSet:        make(map[string]bool),
 	}
-
-	parseLabel(ms, "sigs.k8s.io/cluster-api-autoscaler-node-group-min-size", &cms.min)
-	parseLabel(ms, "sigs.k8s.io/cluster-api-autoscaler-node-group-max-size", &cms.max)
-
-	for i := range nodes {
-		cms.nodeSet[nodes[i]] = true
-	}
-
-	return &cms
 }
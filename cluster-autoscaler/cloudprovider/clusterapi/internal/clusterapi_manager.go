@@ -3,33 +3,47 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"sync"
 	"time"
 
+	"github.com/golang/glog"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi/types"
-	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	v1alpha1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 	"sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset"
 	v1alpha1apis "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
 )
 
 const (
-	refreshInterval = 30 * time.Second
+	resyncPeriod = 10 * time.Minute
+
+	// machineNodeNameIndex indexes machines by the name of their node, see machineNodeName.
+	machineNodeNameIndex = "machineByNodeName"
 )
 
+// namespaceInformers watch the cluster-api objects of a namespace, or of all namespaces.
+type namespaceInformers struct {
+	machines           cache.SharedIndexInformer
+	machineSets        cache.SharedIndexInformer
+	machineDeployments cache.SharedIndexInformer
+}
+
 type clusterManager struct {
-	lastRefresh          time.Time
-	clusterSnapshot      *clusterSnapshot
-	clusterSnapshotMutex sync.Mutex
-	clusterapi           v1alpha1apis.ClusterV1alpha1Interface
-	kubeclient           *kubeclient.Clientset
-	resourceLimits       *cloudprovider.ResourceLimiter
+	clusterapi     v1alpha1apis.ClusterV1alpha1Interface
+	informers      []namespaceInformers
+	stopCh         chan struct{}
+	resourceLimits *cloudprovider.ResourceLimiter
 }
 
 func (m *clusterManager) Cleanup() error {
+	close(m.stopCh)
 	return nil
 }
 
@@ -40,9 +54,19 @@ func (m *clusterManager) GetResourceLimiter() (*cloudprovider.ResourceLimiter, e
 func (m *clusterManager) GetMachineSets(namespace string) ([]types.MachineSet, error) {
 	result := []types.MachineSet{}
 
-	for _, ms := range m.getClusterState().MachineSetMap {
-		if ms.MaxSize()-ms.MinSize() > 0 {
-			result = append(result, ms)
+	for _, ms := range m.machineSetsInNamespace(namespace) {
+		// Machines of a MachineSet owned by a MachineDeployment belong to the
+		// deployment, scaling the MachineSet itself would be reverted.
+		if owningMachineDeployment(ms) != "" {
+			continue
+		}
+		if cms := newClusterMachineSet(m, ms); cms.MaxSize()-cms.MinSize() > 0 {
+			result = append(result, cms)
+		}
+	}
+	for _, md := range m.machineDeploymentsInNamespace(namespace) {
+		if cmd := newClusterMachineDeployment(m, md); cmd.MaxSize()-cmd.MinSize() > 0 {
+			result = append(result, cmd)
 		}
 	}
 
@@ -50,40 +74,192 @@ func (m *clusterManager) GetMachineSets(namespace string) ([]types.MachineSet, e
 }
 
 func (m *clusterManager) MachineSetForNode(nodename string) (types.MachineSet, error) {
-	snapshot := m.getClusterState()
-	if key, exists := snapshot.NodeToMachineSetMap[nodename]; exists {
-		return snapshot.MachineSetMap[key], nil
+	_, ms := m.nodeGroupForNode(nodename)
+	if ms == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return ms, nil
+}
+
+// Refresh checks that the informers have synced. The cluster state is kept up to date by
+// the informers, so there is nothing else to do.
+func (m *clusterManager) Refresh() error {
+	for _, informers := range m.informers {
+		if !informers.machines.HasSynced() || !informers.machineSets.HasSynced() || !informers.machineDeployments.HasSynced() {
+			return errors.New("cluster-api informers have not synced yet")
+		}
+	}
+	return nil
+}
+
+func (m *clusterManager) machineForNode(nodename string) *v1alpha1.Machine {
+	for _, informers := range m.informers {
+		objs, err := informers.machines.GetIndexer().ByIndex(machineNodeNameIndex, nodename)
+		if err != nil {
+			glog.Errorf("unable to look up machine of node %q: %v", nodename, err)
+			return nil
+		}
+		if len(objs) > 0 {
+			return objs[0].(*v1alpha1.Machine)
+		}
+	}
+	return nil
+}
+
+func (m *clusterManager) machinesInNamespace(namespace string) []*v1alpha1.Machine {
+	result := make([]*v1alpha1.Machine, 0)
+	for _, informers := range m.informers {
+		for _, obj := range listByNamespace(informers.machines, namespace) {
+			result = append(result, obj.(*v1alpha1.Machine))
+		}
+	}
+	return result
 }
 
-func (m *clusterManager) getClusterState() *clusterSnapshot {
-	m.clusterSnapshotMutex.Lock()
-	defer m.clusterSnapshotMutex.Unlock()
-	return m.clusterSnapshot
+func (m *clusterManager) machineSetsInNamespace(namespace string) []*v1alpha1.MachineSet {
+	result := make([]*v1alpha1.MachineSet, 0)
+	for _, informers := range m.informers {
+		for _, obj := range listByNamespace(informers.machineSets, namespace) {
+			result = append(result, obj.(*v1alpha1.MachineSet))
+		}
+	}
+	return result
 }
 
-func (m *clusterManager) setClusterState(s *clusterSnapshot) {
-	m.clusterSnapshotMutex.Lock()
-	defer m.clusterSnapshotMutex.Unlock()
-	m.clusterSnapshot = s
+func (m *clusterManager) machineDeploymentsInNamespace(namespace string) []*v1alpha1.MachineDeployment {
+	result := make([]*v1alpha1.MachineDeployment, 0)
+	for _, informers := range m.informers {
+		for _, obj := range listByNamespace(informers.machineDeployments, namespace) {
+			result = append(result, obj.(*v1alpha1.MachineDeployment))
+		}
+	}
+	return result
 }
 
-func (m *clusterManager) Refresh() error {
-	if m.lastRefresh.Add(refreshInterval).After(time.Now()) && m.clusterSnapshot != nil {
+func (m *clusterManager) machineDeployment(namespace, name string) *v1alpha1.MachineDeployment {
+	for _, informers := range m.informers {
+		obj, exists, err := informers.machineDeployments.GetIndexer().GetByKey(namespace + "/" + name)
+		if err != nil {
+			glog.Errorf("unable to get machinedeployment %s/%s: %v", namespace, name, err)
+			return nil
+		}
+		if exists {
+			return obj.(*v1alpha1.MachineDeployment)
+		}
+	}
+	return nil
+}
+
+// listByNamespace lists the objects of the informer in the namespace, or in all namespaces
+// if namespace is empty.
+func listByNamespace(informer cache.SharedIndexInformer, namespace string) []interface{} {
+	if namespace == v1.NamespaceAll {
+		return informer.GetStore().List()
+	}
+	objs, err := informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		glog.Errorf("unable to list objects in namespace %q: %v", namespace, err)
 		return nil
 	}
+	return objs
+}
+
+func indexMachineByNodeName(obj interface{}) ([]string, error) {
+	machine, ok := obj.(*v1alpha1.Machine)
+	if !ok {
+		return nil, fmt.Errorf("expected a machine, got %T", obj)
+	}
+	if name := machineNodeName(machine); name != "" {
+		return []string{name}, nil
+	}
+	return nil, nil
+}
+
+func newNamespaceInformers(clusterapi v1alpha1apis.ClusterV1alpha1Interface, namespace string) namespaceInformers {
+	return namespaceInformers{
+		machines: cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+					return clusterapi.Machines(namespace).List(options)
+				},
+				WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+					return clusterapi.Machines(namespace).Watch(options)
+				},
+			},
+			&v1alpha1.Machine{},
+			resyncPeriod,
+			cache.Indexers{
+				cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+				machineNodeNameIndex: indexMachineByNodeName,
+			},
+		),
+		machineSets: cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+					return clusterapi.MachineSets(namespace).List(options)
+				},
+				WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+					return clusterapi.MachineSets(namespace).Watch(options)
+				},
+			},
+			&v1alpha1.MachineSet{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		),
+		machineDeployments: cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+					return clusterapi.MachineDeployments(namespace).List(options)
+				},
+				WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+					return clusterapi.MachineDeployments(namespace).Watch(options)
+				},
+			},
+			&v1alpha1.MachineDeployment{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		),
+	}
+}
 
-	s, err := getClusterSnapshot(m)
-	if err == nil {
-		m.lastRefresh = time.Now()
-		m.setClusterState(s)
+// autoDiscoveryNamespaces returns the namespaces given by the clusterapi:namespace=<namespace>
+// auto discovery specs, or all namespaces if there are none.
+func autoDiscoveryNamespaces(do cloudprovider.NodeGroupDiscoveryOptions) ([]string, error) {
+	specs, err := do.ParseClusterAPIAutoDiscoverySpecs()
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		return []string{v1.NamespaceAll}, nil
 	}
+	namespaces := make([]string, len(specs))
+	for i, spec := range specs {
+		namespaces[i] = spec.Namespace
+	}
+	return namespaces, nil
+}
 
-	return err
+// newClusterManager creates a cluster manager with informers for the namespaces. The informers
+// are not started.
+func newClusterManager(clusterapi v1alpha1apis.ClusterV1alpha1Interface, namespaces []string) *clusterManager {
+	m := &clusterManager{
+		clusterapi: clusterapi,
+		stopCh:     make(chan struct{}),
+	}
+	for _, namespace := range namespaces {
+		m.informers = append(m.informers, newNamespaceInformers(clusterapi, namespace))
+	}
+	return m
 }
 
+// NewClusterManager creates a cluster manager watching the namespaces given by the
+// clusterapi:namespace=<namespace> auto discovery specs, or all namespaces if there are none.
 func NewClusterManager(do cloudprovider.NodeGroupDiscoveryOptions) (*clusterManager, error) {
+	namespaces, err := autoDiscoveryNamespaces(do)
+	if err != nil {
+		return nil, err
+	}
+
 	kubeconfig, err := clientcmd.BuildConfigFromFlags("", "")
 	if err != nil {
 		kubeconfigPath := os.Getenv("KUBECONFIG")
@@ -93,19 +269,17 @@ func NewClusterManager(do cloudprovider.NodeGroupDiscoveryOptions) (*clusterMana
 		}
 	}
 
-	kubeclient, err := kubeclient.NewForConfig(kubeconfig)
-	if err != nil {
-		return nil, err
-	}
-
 	clusterapi, err := clientset.NewForConfig(kubeconfig)
 	if err != nil {
 		return nil, fmt.Errorf("could not create client for talking to the apiserver: %v", err)
 	}
 
-	return &clusterManager{
-		clusterSnapshot: newEmptySnapshot(),
-		clusterapi:      clusterapi.ClusterV1alpha1(),
-		kubeclient:      kubeclient,
-	}, nil
+	m := newClusterManager(clusterapi.ClusterV1alpha1(), namespaces)
+	for _, informers := range m.informers {
+		go informers.machines.Run(m.stopCh)
+		go informers.machineSets.Run(m.stopCh)
+		go informers.machineDeployments.Run(m.stopCh)
+	}
+
+	return m, nil
 }
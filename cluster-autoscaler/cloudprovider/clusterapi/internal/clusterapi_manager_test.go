@@ -0,0 +1,272 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"sort"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi/types"
+	v1alpha1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	"sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClusterManager returns a cluster manager whose informers hold the given objects, without
+// running them. The objects are also served by the returned fake client.
+func newTestClusterManager(t *testing.T, namespaces []string, objects ...runtime.Object) (*clusterManager, *fake.Clientset) {
+	client := fake.NewSimpleClientset(objects...)
+	m := newClusterManager(client.ClusterV1alpha1(), namespaces)
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		assert.NoError(t, err)
+		for i, informers := range m.informers {
+			if namespaces[i] != v1.NamespaceAll && namespaces[i] != accessor.GetNamespace() {
+				continue
+			}
+			switch obj.(type) {
+			case *v1alpha1.Machine:
+				err = informers.machines.GetIndexer().Add(obj)
+			case *v1alpha1.MachineSet:
+				err = informers.machineSets.GetIndexer().Add(obj)
+			case *v1alpha1.MachineDeployment:
+				err = informers.machineDeployments.GetIndexer().Add(obj)
+			}
+			assert.NoError(t, err)
+		}
+	}
+	return m, client
+}
+
+func testSizeAnnotations(min, max string) map[string]string {
+	return map[string]string{minSizeKey: min, maxSizeKey: max}
+}
+
+func testMachineDeployment(namespace, name string, replicas int32, annotations map[string]string) *v1alpha1.MachineDeployment {
+	return &v1alpha1.MachineDeployment{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			UID:         kube_types.UID(namespace + "-" + name),
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Selector: v1.LabelSelector{MatchLabels: map[string]string{"deployment": name}},
+		},
+	}
+}
+
+func testMachineSet(namespace, name string, replicas int32, annotations map[string]string, owner *v1alpha1.MachineDeployment) *v1alpha1.MachineSet {
+	ms := &v1alpha1.MachineSet{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.MachineSetSpec{
+			Replicas: &replicas,
+			Selector: v1.LabelSelector{MatchLabels: map[string]string{"machineset": name}},
+		},
+	}
+	if owner != nil {
+		controller := true
+		ms.OwnerReferences = []v1.OwnerReference{{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "MachineDeployment",
+			Name:       owner.Name,
+			UID:        owner.UID,
+			Controller: &controller,
+		}}
+	}
+	return ms
+}
+
+func testMachine(namespace, name, machineSet, nodeName string) *v1alpha1.Machine {
+	machine := &v1alpha1.Machine{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"machineset": machineSet},
+		},
+	}
+	if nodeName != "" {
+		machine.Status.NodeRef = &apiv1.ObjectReference{Kind: "Node", Name: nodeName}
+	}
+	return machine
+}
+
+func nodeGroupIds(nodeGroups []types.MachineSet) []string {
+	result := make([]string, len(nodeGroups))
+	for i, ng := range nodeGroups {
+		result[i] = ng.Id()
+	}
+	sort.Strings(result)
+	return result
+}
+
+func instanceIds(instances []cloudprovider.Instance) []string {
+	result := make([]string, len(instances))
+	for i, instance := range instances {
+		result[i] = instance.Id
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestAutoDiscoveryNamespaces(t *testing.T) {
+	namespaces, err := autoDiscoveryNamespaces(cloudprovider.NodeGroupDiscoveryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{v1.NamespaceAll}, namespaces)
+
+	namespaces, err = autoDiscoveryNamespaces(cloudprovider.NodeGroupDiscoveryOptions{
+		NodeGroupAutoDiscoverySpecs: []string{"clusterapi:namespace=ns1", "clusterapi:namespace=ns2"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1", "ns2"}, namespaces)
+
+	_, err = autoDiscoveryNamespaces(cloudprovider.NodeGroupDiscoveryOptions{
+		NodeGroupAutoDiscoverySpecs: []string{"clusterapi:selector=foo"},
+	})
+	assert.Error(t, err)
+}
+
+func TestClusterManagerDiscoveredNamespaces(t *testing.T) {
+	m, _ := newTestClusterManager(t, []string{"ns1", "ns2"},
+		testMachineSet("ns1", "ms", 1, testSizeAnnotations("0", "3"), nil),
+		testMachineSet("ns2", "ms", 1, testSizeAnnotations("0", "3"), nil),
+		testMachineSet("ns3", "ms", 1, testSizeAnnotations("0", "3"), nil),
+		testMachine("ns1", "ms-1", "ms", "node1"),
+		testMachine("ns3", "ms-1", "ms", "node3"))
+
+	// Only the objects of the watched namespaces are known.
+	nodeGroups, err := m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1/ms", "ns2/ms"}, nodeGroupIds(nodeGroups))
+
+	nodeGroups, err = m.GetMachineSets("ns2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns2/ms"}, nodeGroupIds(nodeGroups))
+
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/ms", ng.Id())
+
+	ng, err = m.MachineSetForNode("node3")
+	assert.NoError(t, err)
+	assert.Nil(t, ng)
+}
+
+func TestClusterManagerMachineIndex(t *testing.T) {
+	otherRef := testMachine("default", "other-ref", "ms", "")
+	otherRef.Status.NodeRef = &apiv1.ObjectReference{Kind: "Pod", Name: "pod"}
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll},
+		testMachine("default", "ms-1", "ms", "node1"),
+		testMachine("default", "ms-2", "ms", ""),
+		otherRef)
+
+	// Machines are indexed by the name of their node, or by their own name until they have one.
+	assert.Equal(t, "ms-1", m.machineForNode("node1").Name)
+	assert.Nil(t, m.machineForNode("ms-1"))
+	assert.Equal(t, "ms-2", m.machineForNode("ms-2").Name)
+	assert.Nil(t, m.machineForNode("pod"))
+	assert.Nil(t, m.machineForNode("other-ref"))
+
+	// The index follows the updates of the informer.
+	assert.NoError(t, m.informers[0].machines.GetIndexer().Update(testMachine("default", "ms-2", "ms", "node2")))
+	assert.Equal(t, "ms-2", m.machineForNode("node2").Name)
+	assert.Nil(t, m.machineForNode("ms-2"))
+}
+
+func TestClusterManagerMachineSetSelector(t *testing.T) {
+	empty := testMachineSet("default", "empty", 1, testSizeAnnotations("0", "3"), nil)
+	empty.Spec.Selector = v1.LabelSelector{}
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll},
+		testMachineSet("default", "ms", 1, testSizeAnnotations("0", "3"), nil),
+		empty,
+		testMachine("default", "ms-1", "ms", "node1"),
+		testMachine("other", "ms-1", "ms", "node2"),
+		testMachine("default", "unlabeled", "", "node3"))
+
+	// Only the machines matching the selector in the namespace of the MachineSet belong to it.
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, "default/ms", ng.Id())
+	instances, err := ng.Nodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node1"}, instanceIds(instances))
+
+	// Machines of other namespaces belong to no MachineSet, and an empty selector selects no machine.
+	for _, node := range []string{"node2", "node3"} {
+		ng, err = m.MachineSetForNode(node)
+		assert.NoError(t, err)
+		assert.Nil(t, ng)
+	}
+}
+
+func TestClusterManagerLiveSizes(t *testing.T) {
+	ms := testMachineSet("default", "ms", 1, testSizeAnnotations("1", "3"), nil)
+	m, _ := newTestClusterManager(t, []string{v1.NamespaceAll}, ms,
+		testMachine("default", "ms-1", "ms", "node1"))
+
+	nodeGroups, err := m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(nodeGroups))
+	assert.Equal(t, 1, nodeGroups[0].MinSize())
+	assert.Equal(t, 3, nodeGroups[0].MaxSize())
+
+	// The sizes are read from the annotations seen by the informer on every call.
+	ms = ms.DeepCopy()
+	ms.Annotations = testSizeAnnotations("2", "5")
+	assert.NoError(t, m.informers[0].machineSets.GetIndexer().Update(ms))
+	nodeGroups, err = m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(nodeGroups))
+	assert.Equal(t, 2, nodeGroups[0].MinSize())
+	assert.Equal(t, 5, nodeGroups[0].MaxSize())
+	ng, err := m.MachineSetForNode("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ng.MinSize())
+	assert.Equal(t, 5, ng.MaxSize())
+
+	// Labels are used if there are no annotations.
+	ms = ms.DeepCopy()
+	ms.Annotations = nil
+	ms.Labels = testSizeAnnotations("0", "4")
+	assert.NoError(t, m.informers[0].machineSets.GetIndexer().Update(ms))
+	nodeGroups, err = m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(nodeGroups))
+	assert.Equal(t, 0, nodeGroups[0].MinSize())
+	assert.Equal(t, 4, nodeGroups[0].MaxSize())
+
+	// A MachineSet whose max size is not above its min size is not autoscaled.
+	ms = ms.DeepCopy()
+	ms.Labels = nil
+	ms.Annotations = testSizeAnnotations("3", "3")
+	assert.NoError(t, m.informers[0].machineSets.GetIndexer().Update(ms))
+	nodeGroups, err = m.GetMachineSets(v1.NamespaceAll)
+	assert.NoError(t, err)
+	assert.Empty(t, nodeGroups)
+}
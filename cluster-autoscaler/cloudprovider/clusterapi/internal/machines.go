@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi/types"
+	v1alpha1apis "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	machineDeleteAnnotationKey = "sigs.k8s.io/cluster-api-delete-machine"
+	// The min and max size of a node group are read from these annotations, or from labels
+	// with the same keys.
+	minSizeKey = "sigs.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	maxSizeKey = "sigs.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+// MachineSetID identifies a node group.
+type MachineSetID string
+
+// machineErrorClasses maps the error reasons set on machines to instance error classes.
+var machineErrorClasses = map[string]cloudprovider.InstanceErrorClass{
+	"InvalidConfiguration":  cloudprovider.InvalidConfigurationErrorClass,
+	"InsufficientResources": cloudprovider.QuotaExceededErrorClass,
+}
+
+// machineNodeName returns the name under which the machine is known to the autoscaler: the
+// name of its node, or the name of the machine itself if it doesn't have a node yet. Reporting
+// such machines allows deleting them if they fail to be created.
+func machineNodeName(machine *v1alpha1apis.Machine) string {
+	if machine.Status.NodeRef == nil {
+		return machine.Name
+	}
+	if machine.Status.NodeRef.Kind != "Node" {
+		glog.Errorf("Status.NodeRef of machine %q does not reference a node (rather %q)", machine.Name, machine.Status.NodeRef.Kind)
+		return ""
+	}
+	return machine.Status.NodeRef.Name
+}
+
+// machineInstanceStatus derives the status of the instance of a machine. Machines without a
+// node are being created, and an error reported on them means the creation failed.
+func machineInstanceStatus(machine *v1alpha1apis.Machine) *cloudprovider.InstanceStatus {
+	if machine.DeletionTimestamp != nil {
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceDeleting}
+	}
+	if machine.Status.NodeRef != nil {
+		return &cloudprovider.InstanceStatus{State: cloudprovider.InstanceRunning}
+	}
+	status := &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating}
+	if machine.Status.ErrorReason != nil {
+		reason := string(*machine.Status.ErrorReason)
+		errorClass, found := machineErrorClasses[reason]
+		if !found {
+			errorClass = cloudprovider.OtherErrorClass
+		}
+		status.ErrorInfo = &cloudprovider.InstanceErrorInfo{
+			ErrorClass: errorClass,
+			ErrorCode:  reason,
+		}
+		if machine.Status.ErrorMessage != nil {
+			status.ErrorInfo.ErrorMessage = *machine.Status.ErrorMessage
+		}
+	}
+	return status
+}
+
+// machineInstances returns the instances of the given machines.
+func machineInstances(machines []*v1alpha1apis.Machine) []cloudprovider.Instance {
+	result := make([]cloudprovider.Instance, 0, len(machines))
+	for _, machine := range machines {
+		if name := machineNodeName(machine); name != "" {
+			result = append(result, cloudprovider.Instance{
+				Id:     name,
+				Status: machineInstanceStatus(machine),
+			})
+		}
+	}
+	return result
+}
+
+func machineSetID(m *v1alpha1apis.MachineSet) MachineSetID {
+	return MachineSetID(fmt.Sprintf("%s/%s", m.Namespace, m.Name))
+}
+
+func machineDeploymentID(m *v1alpha1apis.MachineDeployment) MachineSetID {
+	return MachineSetID(fmt.Sprintf("machinedeployment/%s/%s", m.Namespace, m.Name))
+}
+
+// machineSetSelects checks whether the machine is selected by the MachineSet.
+func machineSetSelects(ms *v1alpha1apis.MachineSet, machine *v1alpha1apis.Machine) bool {
+	if ms.Namespace != machine.Namespace {
+		return false
+	}
+	selector, err := v1.LabelSelectorAsSelector(&ms.Spec.Selector)
+	if err != nil {
+		glog.Errorf("invalid selector of machineset %s/%s: %v", ms.Namespace, ms.Name, err)
+		return false
+	}
+	return !selector.Empty() && selector.Matches(labels.Set(machine.Labels))
+}
+
+// owningMachineDeployment returns the name of the MachineDeployment controlling the
+// MachineSet, or an empty string if there is none.
+func owningMachineDeployment(ms *v1alpha1apis.MachineSet) string {
+	if owner := v1.GetControllerOf(ms); owner != nil && owner.Kind == "MachineDeployment" {
+		return owner.Name
+	}
+	return ""
+}
+
+// machinesOfMachineSet returns the machines selected by the MachineSet.
+func (m *clusterManager) machinesOfMachineSet(ms *v1alpha1apis.MachineSet) []*v1alpha1apis.Machine {
+	result := make([]*v1alpha1apis.Machine, 0)
+	for _, machine := range m.machinesInNamespace(ms.Namespace) {
+		if machineSetSelects(ms, machine) {
+			result = append(result, machine)
+		}
+	}
+	return result
+}
+
+// machineSetsOfMachineDeployment returns the MachineSets controlled by the MachineDeployment.
+func (m *clusterManager) machineSetsOfMachineDeployment(md *v1alpha1apis.MachineDeployment) []*v1alpha1apis.MachineSet {
+	result := make([]*v1alpha1apis.MachineSet, 0)
+	for _, ms := range m.machineSetsInNamespace(md.Namespace) {
+		if owningMachineDeployment(ms) == md.Name {
+			result = append(result, ms)
+		}
+	}
+	return result
+}
+
+// machineSetOfMachine returns the MachineSet selecting the machine, or nil if there is none.
+func (m *clusterManager) machineSetOfMachine(machine *v1alpha1apis.Machine) *v1alpha1apis.MachineSet {
+	for _, ms := range m.machineSetsInNamespace(machine.Namespace) {
+		if machineSetSelects(ms, machine) {
+			return ms
+		}
+	}
+	return nil
+}
+
+// nodeGroupForMachineSet returns the node group the machines of the MachineSet belong to:
+// the MachineDeployment controlling it if there is one, the MachineSet itself otherwise.
+// Returns nil if the controlling MachineDeployment is unknown.
+func (m *clusterManager) nodeGroupForMachineSet(ms *v1alpha1apis.MachineSet) (MachineSetID, types.MachineSet) {
+	name := owningMachineDeployment(ms)
+	if name == "" {
+		return machineSetID(ms), newClusterMachineSet(m, ms)
+	}
+	md := m.machineDeployment(ms.Namespace, name)
+	if md == nil {
+		glog.Warningf("machineset %s/%s is owned by unknown machinedeployment %q", ms.Namespace, ms.Name, name)
+		return "", nil
+	}
+	return machineDeploymentID(md), newClusterMachineDeployment(m, md)
+}
+
+// nodeGroupForNode returns the node group the node belongs to, or nil if there is none.
+func (m *clusterManager) nodeGroupForNode(nodename string) (MachineSetID, types.MachineSet) {
+	machine := m.machineForNode(nodename)
+	if machine == nil {
+		return "", nil
+	}
+	ms := m.machineSetOfMachine(machine)
+	if ms == nil {
+		return "", nil
+	}
+	return m.nodeGroupForMachineSet(ms)
+}
+
+// markMachinesForDeletion annotates the machines of the given nodes, so that they are the ones
+// deleted when the number of replicas is decreased. The machines have to belong to the node group.
+func markMachinesForDeletion(m *clusterManager, nodeGroup MachineSetID, nodenames []string) error {
+	for _, nodename := range nodenames {
+		if id, _ := m.nodeGroupForNode(nodename); id != nodeGroup {
+			return fmt.Errorf("cannot map nodename %q to machine of %s", nodename, nodeGroup)
+		}
+		cached := m.machineForNode(nodename)
+		machine, err := m.clusterapi.Machines(cached.Namespace).Get(cached.Name, v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("cannot get machine %s/%s: %v", cached.Namespace, cached.Name, err)
+		}
+
+		machine = machine.DeepCopy()
+
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+
+		// Annotate machine that it is the chosen one.
+		machine.Annotations[machineDeleteAnnotationKey] = time.Now().String()
+
+		_, err = m.clusterapi.Machines(machine.Namespace).Update(machine)
+		if err != nil {
+			return fmt.Errorf("unable to update machine %q: %v", machine.Name, err)
+		}
+	}
+	return nil
+}
+
+// parseSize reads the min or max size of a node group from its annotations, falling back to
+// its labels. Returns 0 if the size is not set.
+func parseSize(kind string, meta *v1.ObjectMeta, key string) int {
+	val, exists := meta.Annotations[key]
+	if !exists {
+		val, exists = meta.Labels[key]
+	}
+	if !exists {
+		glog.V(4).Infof("%s %s/%s has no annotation named %q", kind, meta.Namespace, meta.Name, key)
+		return 0
+	}
+
+	u, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		glog.Errorf("%s %s/%s: cannot parse %q as an integral value: %v", kind, meta.Namespace, meta.Name, val, err)
+		return 0
+	}
+
+	return int(u)
+}
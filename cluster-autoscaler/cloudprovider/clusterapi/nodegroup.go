@@ -6,6 +6,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi/types"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
 
@@ -100,7 +101,7 @@ func (ng *NodeGroup) DecreaseTargetSize(delta int) error {
 
 // Id returns an unique identifier of the node group.
 func (ng *NodeGroup) Id() string {
-	return ng.machineSet.Name()
+	return ng.machineSet.Id()
 }
 
 // Debug returns a string containing all information regarding this node group.
@@ -109,7 +110,7 @@ func (ng *NodeGroup) Debug() string {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (ng *NodeGroup) Nodes() ([]string, error) {
+func (ng *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 	return ng.machineSet.Nodes()
 }
 
@@ -119,8 +120,16 @@ func (ng *NodeGroup) Nodes() ([]string, error) {
 // NodeInfo is expected to have a fully populated Node object, with all of the labels,
 // capacity and allocatable information as well as all pods that are started on
 // the node by default, using manifest (most likely only kube-proxy). Implementation optional.
+// The template is built from the node template annotations of the MachineSet.
 func (ng *NodeGroup) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
-	return nil, cloudprovider.ErrNotImplemented
+	node, err := buildNodeFromTemplate(ng.machineSet.Name(), ng.machineSet.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo := schedulercache.NewNodeInfo(cloudprovider.BuildKubeProxy(ng.machineSet.Name()))
+	nodeInfo.SetNode(node)
+	return nodeInfo, nil
 }
 
 // Exist checks if the node group really exists on the cloud provider side. Allows to tell the
@@ -146,3 +155,9 @@ func (ng *NodeGroup) Delete() error {
 func (ng *NodeGroup) Autoprovisioned() bool {
 	return false
 }
+
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (ng *NodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
@@ -20,11 +20,9 @@ import (
 	"reflect"
 	"testing"
 
-	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/clusterapi/types"
-	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
 
 type fakeCluster struct {
@@ -51,10 +49,6 @@ func (f *fakeCluster) Cleanup() error {
 	panic("should not be called")
 }
 
-func (f *fakeCluster) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
-	panic("should not be called")
-}
-
 func (f *fakeCluster) GetMachineSets(namespace string) ([]types.MachineSet, error) {
 	result := make([]types.MachineSet, len(f.machineSets))
 
@@ -65,10 +59,22 @@ func (f *fakeCluster) GetMachineSets(namespace string) ([]types.MachineSet, erro
 	return result, nil
 }
 
+func (f *fakeCluster) MachineSetForNode(name string) (types.MachineSet, error) {
+	return nil, nil
+}
+
+func (f *fakeMachineSet) Id() string {
+	return f.name
+}
+
 func (f *fakeMachineSet) Name() string {
 	return f.name
 }
 
+func (f *fakeMachineSet) Namespace() string {
+	return ""
+}
+
 func (f *fakeMachineSet) MinSize() int {
 	return f.minSize
 }
@@ -81,53 +87,21 @@ func (f *fakeMachineSet) Replicas() int {
 	return f.curSize
 }
 
-func (f *fakeMachineSet) TargetSize() (int, error) {
-	return f.curSize, nil
-}
-
-func (f *fakeMachineSet) IncreaseSize(delta int) error {
-	f.curSize += delta
+func (f *fakeMachineSet) SetSize(n int) error {
+	f.curSize = n
 	return nil
 }
 
-func (f *fakeMachineSet) DeleteNodes([]*apiv1.Node) error {
-	return cloudprovider.ErrNotImplemented
-}
-
-func (f *fakeMachineSet) DecreaseTargetSize(delta int) error {
-	return cloudprovider.ErrNotImplemented
-}
-
-func (f *fakeMachineSet) Id() string {
-	return f.name
-}
-
-func (f *fakeMachineSet) Debug() string {
-	return f.name
-}
-
-func (f *fakeMachineSet) Nodes() ([]string, error) {
+func (f *fakeMachineSet) Nodes() ([]cloudprovider.Instance, error) {
 	return nil, cloudprovider.ErrNotImplemented
 }
 
-func (f *fakeMachineSet) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
-	return nil, cloudprovider.ErrNotImplemented
-}
-
-func (f *fakeMachineSet) Exist() bool {
-	return true
-}
-
-func (f *fakeMachineSet) Create() (cloudprovider.NodeGroup, error) {
-	return nil, cloudprovider.ErrNotImplemented
-}
-
-func (f *fakeMachineSet) Delete() error {
+func (f *fakeMachineSet) DeleteNodes([]string) error {
 	return cloudprovider.ErrNotImplemented
 }
 
-func (f *fakeMachineSet) Autoprovisioned() bool {
-	return false
+func (f *fakeMachineSet) GetAnnotations() map[string]string {
+	return nil
 }
 
 func testProvider(t *testing.T, name string, c *fakeCluster) cloudprovider.CloudProvider {
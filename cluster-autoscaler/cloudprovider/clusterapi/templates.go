@@ -0,0 +1,170 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterapi
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+)
+
+// Annotations of a MachineSet describing the nodes it creates. They are used to build
+// template nodes, so that MachineSets can be scaled up from zero.
+const (
+	// cpuAnnotation is the cpu capacity of a node, as a quantity.
+	cpuAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-cpu"
+	// memoryAnnotation is the memory capacity of a node, as a quantity.
+	memoryAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-memory"
+	// gpuAnnotation is the number of GPUs of a node.
+	gpuAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-gpu"
+	// maxPodsAnnotation is the number of pods a node can run.
+	maxPodsAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-max-pods"
+	// labelsAnnotation is a comma separated list of key=value labels of a node.
+	labelsAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-labels"
+	// taintsAnnotation is a comma separated list of key=value:Effect taints of a node.
+	// The value is optional.
+	taintsAnnotation = "sigs.k8s.io/cluster-api-autoscaler-node-template-taints"
+
+	defaultMaxPods = 110
+)
+
+// buildNodeFromTemplate builds a node as it would be created by the MachineSet, from the
+// annotations of the MachineSet. Returns ErrNotImplemented if the annotations don't specify
+// the cpu and memory capacity.
+func buildNodeFromTemplate(name string, annotations map[string]string) (*apiv1.Node, error) {
+	if annotations[cpuAnnotation] == "" || annotations[memoryAnnotation] == "" {
+		return nil, cloudprovider.ErrNotImplemented
+	}
+
+	node := apiv1.Node{}
+	nodeName := fmt.Sprintf("%s-%d", name, rand.Int63())
+
+	node.ObjectMeta = metav1.ObjectMeta{
+		Name:     nodeName,
+		SelfLink: fmt.Sprintf("/api/v1/nodes/%s", nodeName),
+		Labels:   map[string]string{},
+	}
+
+	node.Status = apiv1.NodeStatus{
+		Capacity: apiv1.ResourceList{},
+	}
+
+	for _, r := range []struct {
+		annotation   string
+		resourceName apiv1.ResourceName
+		defaultValue int64
+	}{
+		{cpuAnnotation, apiv1.ResourceCPU, 0},
+		{memoryAnnotation, apiv1.ResourceMemory, 0},
+		{gpuAnnotation, gpu.ResourceNvidiaGPU, 0},
+		{maxPodsAnnotation, apiv1.ResourcePods, defaultMaxPods},
+	} {
+		value, found := annotations[r.annotation]
+		if !found {
+			node.Status.Capacity[r.resourceName] = *resource.NewQuantity(r.defaultValue, resource.DecimalSI)
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of annotation %s: %v", value, r.annotation, err)
+		}
+		node.Status.Capacity[r.resourceName] = quantity
+	}
+
+	// TODO: use proper allocatable.
+	node.Status.Allocatable = node.Status.Capacity
+
+	labels, err := parseTemplateLabels(annotations[labelsAnnotation])
+	if err != nil {
+		return nil, err
+	}
+	node.Labels = cloudprovider.JoinStringMaps(node.Labels, labels)
+	node.Labels = cloudprovider.JoinStringMaps(node.Labels, buildGenericLabels(nodeName))
+
+	node.Spec.Taints, err = parseTemplateTaints(annotations[taintsAnnotation])
+	if err != nil {
+		return nil, err
+	}
+
+	node.Status.Conditions = cloudprovider.BuildReadyConditions()
+	return &node, nil
+}
+
+func buildGenericLabels(nodeName string) map[string]string {
+	result := make(map[string]string)
+	result[kubeletapis.LabelArch] = cloudprovider.DefaultArch
+	result[kubeletapis.LabelOS] = cloudprovider.DefaultOS
+	result[kubeletapis.LabelHostname] = nodeName
+	return result
+}
+
+// parseTemplateLabels parses labels in the format key1=value1,key2=value2.
+func parseTemplateLabels(value string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, item := range splitAnnotationList(value) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid label %q in annotation %s, expected key=value", item, labelsAnnotation)
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
+
+// parseTemplateTaints parses taints in the format key1=value1:Effect1,key2:Effect2.
+func parseTemplateTaints(value string) ([]apiv1.Taint, error) {
+	result := make([]apiv1.Taint, 0)
+	for _, item := range splitAnnotationList(value) {
+		colon := strings.LastIndex(item, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("invalid taint %q in annotation %s, expected key=value:Effect", item, taintsAnnotation)
+		}
+		taint := apiv1.Taint{Effect: apiv1.TaintEffect(item[colon+1:])}
+		switch taint.Effect {
+		case apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("invalid effect of taint %q in annotation %s", item, taintsAnnotation)
+		}
+		keyValue := strings.SplitN(item[:colon], "=", 2)
+		taint.Key = keyValue[0]
+		if len(keyValue) == 2 {
+			taint.Value = keyValue[1]
+		}
+		if taint.Key == "" {
+			return nil, fmt.Errorf("invalid taint %q in annotation %s, key can't be empty", item, taintsAnnotation)
+		}
+		result = append(result, taint)
+	}
+	return result, nil
+}
+
+func splitAnnotationList(value string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterapi
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildNodeFromTemplate(t *testing.T) {
+	node, err := buildNodeFromTemplate("ms1", map[string]string{
+		cpuAnnotation:     "4",
+		memoryAnnotation:  "16Gi",
+		gpuAnnotation:     "1",
+		maxPodsAnnotation: "50",
+		labelsAnnotation:  "foo=bar, node-role.kubernetes.io/worker=",
+		taintsAnnotation:  "dedicated=gpu:NoSchedule,spot:PreferNoSchedule",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, resource.MustParse("4"), node.Status.Capacity[apiv1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("16Gi"), node.Status.Capacity[apiv1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("1"), node.Status.Capacity[gpu.ResourceNvidiaGPU])
+	assert.Equal(t, resource.MustParse("50"), node.Status.Capacity[apiv1.ResourcePods])
+	assert.Equal(t, node.Status.Capacity, node.Status.Allocatable)
+
+	assert.Equal(t, "bar", node.Labels["foo"])
+	assert.Equal(t, "", node.Labels["node-role.kubernetes.io/worker"])
+	assert.Equal(t, cloudprovider.DefaultOS, node.Labels[kubeletapis.LabelOS])
+	assert.Equal(t, node.Name, node.Labels[kubeletapis.LabelHostname])
+
+	assert.Equal(t, []apiv1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: apiv1.TaintEffectNoSchedule},
+		{Key: "spot", Effect: apiv1.TaintEffectPreferNoSchedule},
+	}, node.Spec.Taints)
+}
+
+func TestBuildNodeFromTemplateDefaults(t *testing.T) {
+	node, err := buildNodeFromTemplate("ms1", map[string]string{
+		cpuAnnotation:    "2",
+		memoryAnnotation: "4G",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(defaultMaxPods), node.Status.Capacity.Pods().Value())
+	gpus := node.Status.Capacity[gpu.ResourceNvidiaGPU]
+	assert.Equal(t, int64(0), gpus.Value())
+	assert.Empty(t, node.Spec.Taints)
+}
+
+func TestBuildNodeFromTemplateErrors(t *testing.T) {
+	_, err := buildNodeFromTemplate("ms1", map[string]string{cpuAnnotation: "2"})
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+
+	_, err = buildNodeFromTemplate("ms1", nil)
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+
+	for _, annotations := range []map[string]string{
+		{cpuAnnotation: "two", memoryAnnotation: "4G"},
+		{cpuAnnotation: "2", memoryAnnotation: "4G", labelsAnnotation: "foo"},
+		{cpuAnnotation: "2", memoryAnnotation: "4G", taintsAnnotation: "foo=bar"},
+		{cpuAnnotation: "2", memoryAnnotation: "4G", taintsAnnotation: "foo=bar:NoWay"},
+		{cpuAnnotation: "2", memoryAnnotation: "4G", taintsAnnotation: "=bar:NoSchedule"},
+	} {
+		_, err = buildNodeFromTemplate("ms1", annotations)
+		assert.Error(t, err, "annotations: %v", annotations)
+		assert.NotEqual(t, cloudprovider.ErrNotImplemented, err)
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+
 type ClusterManager interface {
 	Refresh() error
 	Cleanup() error
@@ -7,13 +9,17 @@ type ClusterManager interface {
 	MachineSetForNode(name string) (MachineSet, error)
 }
 
+// MachineSet is a node group, backed either by a MachineSet or by a MachineDeployment.
 type MachineSet interface {
+	// Id returns the identifier of the node group, unique across namespaces and kinds.
+	Id() string
 	Name() string
 	Namespace() string
 	MinSize() int
 	MaxSize() int
 	Replicas() int
 	SetSize(n int) error
-	Nodes() ([]string, error)
+	Nodes() ([]cloudprovider.Instance, error)
 	DeleteNodes([]string) error
+	GetAnnotations() map[string]string
 }
@@ -0,0 +1,398 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalgrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+)
+
+const (
+	// ProviderName is the cloud provider name for the external gRPC cloud provider.
+	ProviderName = "externalgrpc"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// CloudConfig is the configuration of the external gRPC cloud provider, read from the cloud config file.
+type CloudConfig struct {
+	// Address of the gRPC server, e.g. "localhost:8086".
+	Address string `json:"address"`
+	// Key is the path to the client private key used for mutual TLS.
+	Key string `json:"key"`
+	// Cert is the path to the client certificate used for mutual TLS.
+	Cert string `json:"cert"`
+	// CACert is the path to the CA certificate used to verify the server. Connection is insecure if empty.
+	CACert string `json:"cacert"`
+	// Timeout of every call to the server, e.g. "10s". Defaults to 5s.
+	Timeout string `json:"timeout"`
+}
+
+// externalGrpcCloudProvider implements CloudProvider interface by calling a gRPC server.
+// Responses that don't change within a loop are cached until the next Refresh, so that
+// a slow server doesn't slow down the loop with every lookup. The lock guards the cache
+// only and is never held while calling the server.
+type externalGrpcCloudProvider struct {
+	client          protos.CloudProviderClient
+	conn            *grpc.ClientConn
+	timeout         time.Duration
+	resourceLimiter *cloudprovider.ResourceLimiter
+
+	sync.Mutex
+	// loop is increased by every Refresh, responses requested in a previous loop aren't cached.
+	loop int
+	// nodeGroups is the list returned by the server in this loop, nil if not requested yet.
+	nodeGroups []cloudprovider.NodeGroup
+	// nodeGroupsById holds all node groups seen in this loop so that their caches are shared.
+	nodeGroupsById map[string]*NodeGroup
+	// nodeGroupForNode is keyed by provider id of the node. Nil values mean the node isn't autoscaled.
+	nodeGroupForNode map[string]*NodeGroup
+	machineTypes     []string
+	// nodePrices and podPrices are keyed by priceKey of the node or pod and the priced duration.
+	nodePrices map[priceKey]float64
+	podPrices  map[priceKey]float64
+}
+
+// BuildExternalGrpcCloudProvider builds CloudProvider implementation calling the gRPC server configured
+// in the given cloud config.
+func BuildExternalGrpcCloudProvider(configReader io.Reader, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
+	config, err := readCloudConfig(configReader)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultTimeout
+	if config.Timeout != "" {
+		timeout, err = time.ParseDuration(config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %v", config.Timeout, err)
+		}
+	}
+	conn, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	return newExternalGrpcCloudProvider(protos.NewCloudProviderClient(conn), conn, timeout, resourceLimiter), nil
+}
+
+func newExternalGrpcCloudProvider(client protos.CloudProviderClient, conn *grpc.ClientConn, timeout time.Duration,
+	resourceLimiter *cloudprovider.ResourceLimiter) *externalGrpcCloudProvider {
+	provider := &externalGrpcCloudProvider{
+		client:          client,
+		conn:            conn,
+		timeout:         timeout,
+		resourceLimiter: resourceLimiter,
+	}
+	provider.resetCache()
+	return provider
+}
+
+func readCloudConfig(configReader io.Reader) (*CloudConfig, error) {
+	if configReader == nil {
+		return nil, fmt.Errorf("cloud config with the address of the gRPC server is required")
+	}
+	data, err := ioutil.ReadAll(configReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cloud config: %v", err)
+	}
+	config := &CloudConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud config: %v", err)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("address of the gRPC server is not set in cloud config")
+	}
+	return config, nil
+}
+
+// dial connects to the server lazily, so an unavailable server doesn't prevent CA from starting.
+func dial(config *CloudConfig) (*grpc.ClientConn, error) {
+	if config.CACert == "" {
+		glog.Warningf("No CA certificate given for externalgrpc cloud provider, connecting to %s without TLS", config.Address)
+		return grpc.Dial(config.Address, grpc.WithInsecure(), grpc.WithCodec(grpccodec.Codec{}))
+	}
+	caCert, err := ioutil.ReadFile(config.CACert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate %s: %v", config.CACert, err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate %s", config.CACert)
+	}
+	tlsConfig := &tls.Config{RootCAs: certPool}
+	if config.Cert != "" || config.Key != "" {
+		cert, err := tls.LoadX509KeyPair(config.Cert, config.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return grpc.Dial(config.Address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithCodec(grpccodec.Codec{}))
+}
+
+// resetCache drops all responses cached in the current loop. Must be called with the lock held,
+// or before the provider is shared.
+func (e *externalGrpcCloudProvider) resetCache() {
+	e.loop++
+	e.nodeGroups = nil
+	e.nodeGroupsById = make(map[string]*NodeGroup)
+	e.nodeGroupForNode = make(map[string]*NodeGroup)
+	e.machineTypes = nil
+	e.nodePrices = make(map[priceKey]float64)
+	e.podPrices = make(map[priceKey]float64)
+}
+
+// nodeGroupFor returns the node group with the id of the given message, reusing the one already seen
+// in the given loop if any. Node groups of a previous loop are neither reused nor shared. Must be
+// called with the lock held.
+func (e *externalGrpcCloudProvider) nodeGroupFor(pbNodeGroup *protos.NodeGroup, loop int) *NodeGroup {
+	if nodeGroup, found := e.nodeGroupsById[pbNodeGroup.Id]; found && loop == e.loop {
+		return nodeGroup
+	}
+	nodeGroup := &NodeGroup{
+		id:      pbNodeGroup.Id,
+		minSize: int(pbNodeGroup.MinSize),
+		maxSize: int(pbNodeGroup.MaxSize),
+		debug:   pbNodeGroup.Debug,
+		client:  e.client,
+		timeout: e.timeout,
+	}
+	if loop == e.loop {
+		e.nodeGroupsById[nodeGroup.id] = nodeGroup
+	}
+	return nodeGroup
+}
+
+// Name returns name of the cloud provider.
+func (e *externalGrpcCloudProvider) Name() string {
+	return ProviderName
+}
+
+// NodeGroups returns all node groups configured for this cloud provider.
+func (e *externalGrpcCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
+	e.Lock()
+	nodeGroups, loop := e.nodeGroups, e.loop
+	e.Unlock()
+	if nodeGroups != nil {
+		return nodeGroups
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	response, err := e.client.NodeGroups(ctx, &protos.NodeGroupsRequest{})
+	if err != nil {
+		glog.Errorf("Failed to get node groups from externalgrpc cloud provider: %v", err)
+		return []cloudprovider.NodeGroup{}
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	if e.nodeGroups != nil && loop == e.loop {
+		// Requested concurrently, the node groups of the first response are shared.
+		return e.nodeGroups
+	}
+	nodeGroups = make([]cloudprovider.NodeGroup, 0, len(response.NodeGroups))
+	for _, pbNodeGroup := range response.NodeGroups {
+		nodeGroups = append(nodeGroups, e.nodeGroupFor(pbNodeGroup, loop))
+	}
+	if loop == e.loop {
+		e.nodeGroups = nodeGroups
+	}
+	return nodeGroups
+}
+
+// NodeGroupForNode returns the node group for the given node, nil if the node should not be processed
+// by cluster autoscaler.
+func (e *externalGrpcCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	key := node.Spec.ProviderID
+	if key == "" {
+		key = node.Name
+	}
+	e.Lock()
+	nodeGroup, found := e.nodeGroupForNode[key]
+	loop := e.loop
+	e.Unlock()
+	if found {
+		if nodeGroup == nil {
+			return nil, nil
+		}
+		return nodeGroup, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	response, err := e.client.NodeGroupForNode(ctx, &protos.NodeGroupForNodeRequest{Node: externalGrpcNode(node)})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	nodeGroup = nil
+	if response.NodeGroup != nil && response.NodeGroup.Id != "" {
+		nodeGroup = e.nodeGroupFor(response.NodeGroup, loop)
+	}
+	if loop == e.loop {
+		e.nodeGroupForNode[key] = nodeGroup
+	}
+	if nodeGroup == nil {
+		return nil, nil
+	}
+	return nodeGroup, nil
+}
+
+// Pricing returns pricing model for this cloud provider. Prices are computed by the server, which
+// may answer with the Unimplemented status code if it doesn't support pricing, and cached until
+// the next Refresh.
+func (e *externalGrpcCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
+	return &pricingModel{provider: e}, nil
+}
+
+// GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
+func (e *externalGrpcCloudProvider) GetAvailableMachineTypes() ([]string, error) {
+	e.Lock()
+	machineTypes, loop := e.machineTypes, e.loop
+	e.Unlock()
+	if machineTypes != nil {
+		return machineTypes, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	response, err := e.client.GetAvailableMachineTypes(ctx, &protos.GetAvailableMachineTypesRequest{})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	machineTypes = response.MachineTypes
+	if machineTypes == nil {
+		machineTypes = []string{}
+	}
+	e.Lock()
+	defer e.Unlock()
+	if loop == e.loop {
+		e.machineTypes = machineTypes
+	}
+	return machineTypes, nil
+}
+
+// NewNodeGroup builds a theoretical node group based on the node definition provided. Node
+// autoprovisioning is not supported by the external gRPC protocol.
+func (e *externalGrpcCloudProvider) NewNodeGroup(machineType string, labels map[string]string, systemLabels map[string]string,
+	taints []apiv1.Taint, extraResources map[string]resource.Quantity) (cloudprovider.NodeGroup, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
+// GetResourceLimiter returns struct containing limits (max, min) for resources (cores, memory etc.).
+func (e *externalGrpcCloudProvider) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
+	return e.resourceLimiter, nil
+}
+
+// Cleanup tells the server to clean up its resources and closes the connection to it.
+func (e *externalGrpcCloudProvider) Cleanup() error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	_, err := e.client.Cleanup(ctx, &protos.CleanupRequest{})
+	if e.conn != nil {
+		if closeErr := e.conn.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return convertError(err)
+}
+
+// Refresh is called before every main loop. It drops the responses cached in the previous loop
+// and lets the server update its state.
+func (e *externalGrpcCloudProvider) Refresh() error {
+	e.Lock()
+	e.resetCache()
+	e.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	_, err := e.client.Refresh(ctx, &protos.RefreshRequest{})
+	return convertError(err)
+}
+
+// externalGrpcNode returns the fields of the node sent to the server.
+func externalGrpcNode(node *apiv1.Node) *protos.ExternalGrpcNode {
+	return &protos.ExternalGrpcNode{
+		ProviderID:  node.Spec.ProviderID,
+		Name:        node.Name,
+		Labels:      node.Labels,
+		Annotations: node.Annotations,
+	}
+}
+
+// convertError maps the Unimplemented status code to cloudprovider.ErrNotImplemented.
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+		return cloudprovider.ErrNotImplemented
+	}
+	return err
+}
+
+// instanceErrorClasses maps the error classes of InstanceCreationErrorDetails to classes of
+// instance creation errors.
+var instanceErrorClasses = map[protos.InstanceErrorClass]cloudprovider.InstanceErrorClass{
+	protos.InstanceErrorClass_OTHER:                 cloudprovider.OtherErrorClass,
+	protos.InstanceErrorClass_QUOTA_EXCEEDED:        cloudprovider.QuotaExceededErrorClass,
+	protos.InstanceErrorClass_STOCKOUT:              cloudprovider.StockoutErrorClass,
+	protos.InstanceErrorClass_INVALID_CONFIGURATION: cloudprovider.InvalidConfigurationErrorClass,
+}
+
+// convertIncreaseSizeError maps a failed NodeGroupIncreaseSize call carrying InstanceCreationErrorDetails
+// to a cloudprovider.InstanceCreationError. Status codes alone don't tell why instances couldn't be
+// created, e.g. Unavailable may just mean the server is down.
+func convertIncreaseSizeError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		details, ok := detail.(*protos.InstanceCreationErrorDetails)
+		if !ok {
+			continue
+		}
+		class, found := instanceErrorClasses[details.ErrorClass]
+		if !found {
+			class = cloudprovider.OtherErrorClass
+		}
+		return cloudprovider.NewInstanceCreationError(class, details.ErrorCode, "%s", st.Message())
+	}
+	return convertError(err)
+}
@@ -0,0 +1,416 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalgrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// fakeServer serves two node groups, ng1 with nodes n1 and n2 and ng2 without nodes,
+// and counts the calls of every method.
+type fakeServer struct {
+	sync.Mutex
+	calls       map[string]int
+	targetSizes map[string]int32
+	deleted     []string
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		calls:       make(map[string]int),
+		targetSizes: map[string]int32{"ng1": 2, "ng2": 0},
+	}
+}
+
+func (s *fakeServer) call(method string) {
+	s.Lock()
+	defer s.Unlock()
+	s.calls[method]++
+}
+
+func (s *fakeServer) callCount(method string) int {
+	s.Lock()
+	defer s.Unlock()
+	return s.calls[method]
+}
+
+func (s *fakeServer) NodeGroups(ctx context.Context, request *protos.NodeGroupsRequest) (*protos.NodeGroupsResponse, error) {
+	s.call("NodeGroups")
+	return &protos.NodeGroupsResponse{NodeGroups: []*protos.NodeGroup{
+		{Id: "ng1", MinSize: 1, MaxSize: 10, Debug: "ng1 debug"},
+		{Id: "ng2", MinSize: 0, MaxSize: 5},
+	}}, nil
+}
+
+func (s *fakeServer) NodeGroupForNode(ctx context.Context, request *protos.NodeGroupForNodeRequest) (*protos.NodeGroupForNodeResponse, error) {
+	s.call("NodeGroupForNode")
+	if strings.HasPrefix(request.Node.ProviderID, "fake://ng1/") {
+		return &protos.NodeGroupForNodeResponse{NodeGroup: &protos.NodeGroup{Id: "ng1", MinSize: 1, MaxSize: 10}}, nil
+	}
+	return &protos.NodeGroupForNodeResponse{NodeGroup: &protos.NodeGroup{}}, nil
+}
+
+func (s *fakeServer) PricingNodePrice(ctx context.Context, request *protos.PricingNodePriceRequest) (*protos.PricingNodePriceResponse, error) {
+	s.call("PricingNodePrice")
+	hours := request.EndTime.Sub(request.StartTime.Time).Hours()
+	return &protos.PricingNodePriceResponse{Price: hours * float64(request.Node.Status.Capacity.Cpu().Value())}, nil
+}
+
+func (s *fakeServer) PricingPodPrice(ctx context.Context, request *protos.PricingPodPriceRequest) (*protos.PricingPodPriceResponse, error) {
+	s.call("PricingPodPrice")
+	return nil, status.Error(codes.Unimplemented, "pod prices are not supported")
+}
+
+func (s *fakeServer) GetAvailableMachineTypes(ctx context.Context, request *protos.GetAvailableMachineTypesRequest) (*protos.GetAvailableMachineTypesResponse, error) {
+	s.call("GetAvailableMachineTypes")
+	return &protos.GetAvailableMachineTypesResponse{MachineTypes: []string{"small", "large"}}, nil
+}
+
+func (s *fakeServer) Cleanup(ctx context.Context, request *protos.CleanupRequest) (*protos.CleanupResponse, error) {
+	s.call("Cleanup")
+	return &protos.CleanupResponse{}, nil
+}
+
+func (s *fakeServer) Refresh(ctx context.Context, request *protos.RefreshRequest) (*protos.RefreshResponse, error) {
+	s.call("Refresh")
+	return &protos.RefreshResponse{}, nil
+}
+
+func (s *fakeServer) NodeGroupTargetSize(ctx context.Context, request *protos.NodeGroupTargetSizeRequest) (*protos.NodeGroupTargetSizeResponse, error) {
+	s.call("NodeGroupTargetSize")
+	s.Lock()
+	defer s.Unlock()
+	return &protos.NodeGroupTargetSizeResponse{TargetSize: s.targetSizes[request.Id]}, nil
+}
+
+func (s *fakeServer) NodeGroupIncreaseSize(ctx context.Context, request *protos.NodeGroupIncreaseSizeRequest) (*protos.NodeGroupIncreaseSizeResponse, error) {
+	s.call("NodeGroupIncreaseSize")
+	s.Lock()
+	defer s.Unlock()
+	if s.targetSizes[request.Id]+request.Delta > 5 {
+		return nil, instanceCreationError(protos.InstanceErrorClass_QUOTA_EXCEEDED, "CPUS", "CPU quota exceeded")
+	}
+	s.targetSizes[request.Id] += request.Delta
+	return &protos.NodeGroupIncreaseSizeResponse{}, nil
+}
+
+func (s *fakeServer) NodeGroupDeleteNodes(ctx context.Context, request *protos.NodeGroupDeleteNodesRequest) (*protos.NodeGroupDeleteNodesResponse, error) {
+	s.call("NodeGroupDeleteNodes")
+	s.Lock()
+	defer s.Unlock()
+	for _, node := range request.Nodes {
+		s.deleted = append(s.deleted, node.Name)
+	}
+	s.targetSizes[request.Id] -= int32(len(request.Nodes))
+	return &protos.NodeGroupDeleteNodesResponse{}, nil
+}
+
+func (s *fakeServer) NodeGroupDecreaseTargetSize(ctx context.Context, request *protos.NodeGroupDecreaseTargetSizeRequest) (*protos.NodeGroupDecreaseTargetSizeResponse, error) {
+	s.call("NodeGroupDecreaseTargetSize")
+	s.Lock()
+	defer s.Unlock()
+	s.targetSizes[request.Id] += request.Delta
+	return &protos.NodeGroupDecreaseTargetSizeResponse{}, nil
+}
+
+func (s *fakeServer) NodeGroupNodes(ctx context.Context, request *protos.NodeGroupNodesRequest) (*protos.NodeGroupNodesResponse, error) {
+	s.call("NodeGroupNodes")
+	if request.Id == "ng1" {
+		return &protos.NodeGroupNodesResponse{Nodes: []string{"fake://ng1/n1", "fake://ng1/n2"}}, nil
+	}
+	return &protos.NodeGroupNodesResponse{}, nil
+}
+
+func (s *fakeServer) NodeGroupTemplateNodeInfo(ctx context.Context, request *protos.NodeGroupTemplateNodeInfoRequest) (*protos.NodeGroupTemplateNodeInfoResponse, error) {
+	s.call("NodeGroupTemplateNodeInfo")
+	if request.Id != "ng1" {
+		return nil, status.Error(codes.Unimplemented, "no template")
+	}
+	return &protos.NodeGroupTemplateNodeInfoResponse{
+		NodeInfo: BuildTestNode("ng1-template", 2000, 4000),
+		Pods:     []*apiv1.Pod{BuildTestPod("kube-proxy", 100, 100)},
+	}, nil
+}
+
+func (s *fakeServer) NodeGroupGetOptions(ctx context.Context, request *protos.NodeGroupGetOptionsRequest) (*protos.NodeGroupGetOptionsResponse, error) {
+	s.call("NodeGroupGetOptions")
+	if request.Id != "ng1" {
+		return &protos.NodeGroupGetOptionsResponse{}, nil
+	}
+	options := *request.Defaults
+	options.ScaleDownUtilizationThreshold = 0.9
+	options.ScaleDownUnneededTime = ptypes.DurationProto(time.Hour)
+	options.MaxNodeProvisionTime = nil
+	options.HeadroomNodes = 2
+	options.HeadroomCores = 4
+	return &protos.NodeGroupGetOptionsResponse{NodeGroupAutoscalingOptions: &options}, nil
+}
+
+// instanceCreationError returns a status error with InstanceCreationErrorDetails.
+func instanceCreationError(class protos.InstanceErrorClass, code, msg string) error {
+	st, err := status.New(codes.Internal, msg).WithDetails(&protos.InstanceCreationErrorDetails{ErrorClass: class, ErrorCode: code})
+	if err != nil {
+		panic(err)
+	}
+	return st.Err()
+}
+
+func startFakeServer(t *testing.T) (*fakeServer, *externalGrpcCloudProvider, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := newFakeServer()
+	grpcServer := grpc.NewServer(grpc.CustomCodec(grpccodec.Codec{}))
+	protos.RegisterCloudProviderServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+
+	config := fmt.Sprintf("address: %s\ntimeout: 5s\n", listener.Addr().String())
+	provider, err := BuildExternalGrpcCloudProvider(strings.NewReader(config), nil)
+	assert.NoError(t, err)
+	return server, provider.(*externalGrpcCloudProvider), grpcServer.Stop
+}
+
+func TestNodeGroupsCachedUntilRefresh(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+
+	nodeGroups := provider.NodeGroups()
+	assert.Equal(t, 2, len(nodeGroups))
+	assert.Equal(t, "ng1", nodeGroups[0].Id())
+	assert.Equal(t, 1, nodeGroups[0].MinSize())
+	assert.Equal(t, 10, nodeGroups[0].MaxSize())
+	assert.Equal(t, "ng1 debug", nodeGroups[0].Debug())
+	provider.NodeGroups()
+	assert.Equal(t, 1, server.callCount("NodeGroups"))
+
+	for i := 0; i < 2; i++ {
+		size, err := nodeGroups[0].TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, size)
+		nodes, err := nodeGroups[0].Nodes()
+		assert.NoError(t, err)
+		assert.Equal(t, []cloudprovider.Instance{{Id: "fake://ng1/n1"}, {Id: "fake://ng1/n2"}}, nodes)
+	}
+	assert.Equal(t, 1, server.callCount("NodeGroupTargetSize"))
+	assert.Equal(t, 1, server.callCount("NodeGroupNodes"))
+
+	assert.NoError(t, provider.Refresh())
+	assert.Equal(t, 1, server.callCount("Refresh"))
+	provider.NodeGroups()
+	assert.Equal(t, 2, server.callCount("NodeGroups"))
+}
+
+func TestChangingSizeDropsCache(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+	nodeGroup := provider.NodeGroups()[0]
+
+	assert.Error(t, nodeGroup.IncreaseSize(0))
+	assert.NoError(t, nodeGroup.IncreaseSize(3))
+	size, err := nodeGroup.TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, size)
+
+	assert.NoError(t, nodeGroup.DecreaseTargetSize(-1))
+	size, err = nodeGroup.TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, size)
+
+	node := BuildTestNode("n1", 1000, 1000)
+	node.Spec.ProviderID = "fake://ng1/n1"
+	assert.NoError(t, nodeGroup.DeleteNodes([]*apiv1.Node{node}))
+	size, err = nodeGroup.TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, size)
+	assert.Equal(t, []string{"n1"}, server.deleted)
+	assert.Equal(t, 3, server.callCount("NodeGroupTargetSize"))
+}
+
+func TestIncreaseSizeErrors(t *testing.T) {
+	_, provider, stop := startFakeServer(t)
+	defer stop()
+	nodeGroup := provider.NodeGroups()[0]
+
+	err := nodeGroup.IncreaseSize(4)
+	assert.Error(t, err)
+	assert.Equal(t, cloudprovider.QuotaExceededErrorClass, cloudprovider.GetInstanceErrorClass(err))
+	assert.Equal(t, "CPUS", err.(cloudprovider.InstanceCreationError).Code)
+	assert.Equal(t, "CPU quota exceeded", err.Error())
+
+	assert.Equal(t, cloudprovider.StockoutErrorClass, cloudprovider.GetInstanceErrorClass(convertIncreaseSizeError(
+		instanceCreationError(protos.InstanceErrorClass_STOCKOUT, "", "zone out of capacity"))))
+	assert.Equal(t, cloudprovider.InvalidConfigurationErrorClass, cloudprovider.GetInstanceErrorClass(convertIncreaseSizeError(
+		instanceCreationError(protos.InstanceErrorClass_INVALID_CONFIGURATION, "", "image not found"))))
+	// Status codes alone don't classify errors, a server that is down isn't a stockout.
+	for _, code := range []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Internal} {
+		err = convertIncreaseSizeError(status.Error(code, "failure"))
+		assert.Error(t, err)
+		assert.Equal(t, cloudprovider.OtherErrorClass, cloudprovider.GetInstanceErrorClass(err))
+	}
+	assert.Equal(t, cloudprovider.ErrNotImplemented, convertIncreaseSizeError(status.Error(codes.Unimplemented, "")))
+	assert.NoError(t, convertIncreaseSizeError(nil))
+}
+
+func TestNodeGroupForNode(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+
+	node := BuildTestNode("n1", 1000, 1000)
+	node.Spec.ProviderID = "fake://ng1/n1"
+	nodeGroup, err := provider.NodeGroupForNode(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "ng1", nodeGroup.Id())
+	// Node groups seen in the same loop share their cache.
+	assert.True(t, nodeGroup == provider.NodeGroups()[0])
+
+	other := BuildTestNode("other", 1000, 1000)
+	other.Spec.ProviderID = "fake://other/other"
+	for i := 0; i < 2; i++ {
+		nodeGroup, err = provider.NodeGroupForNode(other)
+		assert.NoError(t, err)
+		assert.Nil(t, nodeGroup)
+		_, err = provider.NodeGroupForNode(node)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, server.callCount("NodeGroupForNode"))
+}
+
+func TestTemplateNodeInfo(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+	nodeGroups := provider.NodeGroups()
+
+	for i := 0; i < 2; i++ {
+		nodeInfo, err := nodeGroups[0].TemplateNodeInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, "ng1-template", nodeInfo.Node().Name)
+		assert.Equal(t, int64(2000), nodeInfo.Node().Status.Capacity.Cpu().MilliValue())
+		assert.Equal(t, 1, len(nodeInfo.Pods()))
+		assert.Equal(t, "kube-proxy", nodeInfo.Pods()[0].Name)
+	}
+	assert.Equal(t, 1, server.callCount("NodeGroupTemplateNodeInfo"))
+
+	_, err := nodeGroups[1].TemplateNodeInfo()
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+}
+
+func TestPricing(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+	pricing, pricingErr := provider.Pricing()
+	assert.NoError(t, pricingErr)
+
+	now := time.Now()
+	node := BuildTestNode("n1", 2000, 1000)
+	price, err := pricing.NodePrice(node, now, now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, price)
+
+	// Prices of the same period length are cached until the next refresh.
+	later := now.Add(time.Minute)
+	price, err = pricing.NodePrice(node, later, later.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, price)
+	assert.Equal(t, 1, server.callCount("PricingNodePrice"))
+	price, err = pricing.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, price)
+	assert.Equal(t, 2, server.callCount("PricingNodePrice"))
+	assert.NoError(t, provider.Refresh())
+	_, err = pricing.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, server.callCount("PricingNodePrice"))
+
+	_, err = pricing.PodPrice(BuildTestPod("p1", 100, 100), now, now.Add(time.Hour))
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+}
+
+func TestGetAvailableMachineTypesAndCleanup(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+
+	for i := 0; i < 2; i++ {
+		machineTypes, err := provider.GetAvailableMachineTypes()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"small", "large"}, machineTypes)
+	}
+	assert.Equal(t, 1, server.callCount("GetAvailableMachineTypes"))
+
+	assert.NoError(t, provider.Cleanup())
+	assert.Equal(t, 1, server.callCount("Cleanup"))
+}
+
+func TestGetOptions(t *testing.T) {
+	server, provider, stop := startFakeServer(t)
+	defer stop()
+	nodeGroups := provider.NodeGroups()
+	defaults := config.NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		ScaleDownUnneededTime:         10 * time.Minute,
+		ScaleDownUnreadyTime:          20 * time.Minute,
+		MaxNodeProvisionTime:          15 * time.Minute,
+		HeadroomMemory:                1024 * 1024 * 1024,
+	}
+
+	for i := 0; i < 2; i++ {
+		options, err := nodeGroups[0].GetOptions(defaults)
+		assert.NoError(t, err)
+		assert.Equal(t, &config.NodeGroupAutoscalingOptions{
+			ScaleDownUtilizationThreshold: 0.9,
+			ScaleDownUnneededTime:         time.Hour,
+			ScaleDownUnreadyTime:          20 * time.Minute,
+			MaxNodeProvisionTime:          15 * time.Minute,
+			HeadroomNodes:                 2,
+			HeadroomCores:                 4,
+			HeadroomMemory:                1024 * 1024 * 1024,
+		}, options)
+	}
+	assert.Equal(t, 1, server.callCount("NodeGroupGetOptions"))
+
+	options, err := nodeGroups[1].GetOptions(defaults)
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+}
+
+func TestBuildWithInvalidConfig(t *testing.T) {
+	_, err := BuildExternalGrpcCloudProvider(nil, nil)
+	assert.Error(t, err)
+	_, err = BuildExternalGrpcCloudProvider(strings.NewReader("timeout: 5s"), nil)
+	assert.Error(t, err)
+	_, err = BuildExternalGrpcCloudProvider(strings.NewReader("address: localhost:1\ntimeout: soon"), nil)
+	assert.Error(t, err)
+	_, err = BuildExternalGrpcCloudProvider(strings.NewReader("address: localhost:1\ncacert: /nonexistent/ca.pem"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalgrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+)
+
+// NodeGroup implements cloudprovider.NodeGroup by calling the gRPC server. Node groups are rebuilt
+// in every loop, responses are cached for the lifetime of the node group. Changing the size of the
+// node group drops the cached size and nodes.
+type NodeGroup struct {
+	id      string
+	minSize int
+	maxSize int
+	debug   string
+	client  protos.CloudProviderClient
+	timeout time.Duration
+
+	sync.Mutex
+	targetSize *int
+	nodes      []cloudprovider.Instance
+	nodeInfo   *schedulercache.NodeInfo
+	options    *config.NodeGroupAutoscalingOptions
+	// optionsFetched tells whether options were requested, nil options mean the defaults are used.
+	optionsFetched bool
+}
+
+var _ cloudprovider.NodeGroup = (*NodeGroup)(nil)
+
+// MaxSize returns maximum size of the node group.
+func (n *NodeGroup) MaxSize() int {
+	return n.maxSize
+}
+
+// MinSize returns minimum size of the node group.
+func (n *NodeGroup) MinSize() int {
+	return n.minSize
+}
+
+// TargetSize returns the current target size of the node group.
+func (n *NodeGroup) TargetSize() (int, error) {
+	n.Lock()
+	defer n.Unlock()
+	if n.targetSize != nil {
+		return *n.targetSize, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	response, err := n.client.NodeGroupTargetSize(ctx, &protos.NodeGroupTargetSizeRequest{Id: n.id})
+	if err != nil {
+		return 0, convertError(err)
+	}
+	size := int(response.TargetSize)
+	n.targetSize = &size
+	return size, nil
+}
+
+// IncreaseSize increases the size of the node group.
+func (n *NodeGroup) IncreaseSize(delta int) error {
+	if delta <= 0 {
+		return fmt.Errorf("size increase must be positive")
+	}
+	n.Lock()
+	defer n.Unlock()
+	n.targetSize = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	_, err := n.client.NodeGroupIncreaseSize(ctx, &protos.NodeGroupIncreaseSizeRequest{Id: n.id, Delta: int32(delta)})
+	return convertIncreaseSizeError(err)
+}
+
+// DeleteNodes deletes nodes from this node group and decreases its size accordingly.
+func (n *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
+	request := &protos.NodeGroupDeleteNodesRequest{
+		Id:    n.id,
+		Nodes: make([]*protos.ExternalGrpcNode, 0, len(nodes)),
+	}
+	for _, node := range nodes {
+		request.Nodes = append(request.Nodes, externalGrpcNode(node))
+	}
+	n.Lock()
+	defer n.Unlock()
+	n.targetSize = nil
+	n.nodes = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	_, err := n.client.NodeGroupDeleteNodes(ctx, request)
+	return convertError(err)
+}
+
+// DecreaseTargetSize decreases the target size of the node group without deleting any existing node.
+func (n *NodeGroup) DecreaseTargetSize(delta int) error {
+	if delta >= 0 {
+		return fmt.Errorf("size decrease must be negative")
+	}
+	n.Lock()
+	defer n.Unlock()
+	n.targetSize = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	_, err := n.client.NodeGroupDecreaseTargetSize(ctx, &protos.NodeGroupDecreaseTargetSizeRequest{Id: n.id, Delta: int32(delta)})
+	return convertError(err)
+}
+
+// Id returns an unique identifier of the node group.
+func (n *NodeGroup) Id() string {
+	return n.id
+}
+
+// Debug returns a string containing all information regarding this node group.
+func (n *NodeGroup) Debug() string {
+	return n.debug
+}
+
+// Nodes returns a list of all nodes that belong to this node group. The server doesn't
+// report the status of instances.
+func (n *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
+	n.Lock()
+	defer n.Unlock()
+	if n.nodes != nil {
+		return n.nodes, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	response, err := n.client.NodeGroupNodes(ctx, &protos.NodeGroupNodesRequest{Id: n.id})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	n.nodes = make([]cloudprovider.Instance, 0, len(response.Nodes))
+	for _, id := range response.Nodes {
+		n.nodes = append(n.nodes, cloudprovider.Instance{Id: id})
+	}
+	return n.nodes, nil
+}
+
+// TemplateNodeInfo returns a node template for this node group.
+func (n *NodeGroup) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
+	n.Lock()
+	defer n.Unlock()
+	if n.nodeInfo != nil {
+		return n.nodeInfo.Clone(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	response, err := n.client.NodeGroupTemplateNodeInfo(ctx, &protos.NodeGroupTemplateNodeInfoRequest{Id: n.id})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if response.NodeInfo == nil {
+		return nil, cloudprovider.ErrNotImplemented
+	}
+	nodeInfo := schedulercache.NewNodeInfo(response.Pods...)
+	if err := nodeInfo.SetNode(response.NodeInfo); err != nil {
+		return nil, err
+	}
+	n.nodeInfo = nodeInfo
+	return n.nodeInfo.Clone(), nil
+}
+
+// Exist checks if the node group really exists on the cloud provider side. Node groups
+// are only ever returned by the server, so they always exist.
+func (n *NodeGroup) Exist() bool {
+	return true
+}
+
+// Create creates the node group on the cloud provider side. Not supported by the external gRPC protocol.
+func (n *NodeGroup) Create() (cloudprovider.NodeGroup, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
+// Delete deletes the node group on the cloud provider side. Not supported by the external gRPC protocol.
+func (n *NodeGroup) Delete() error {
+	return cloudprovider.ErrNotImplemented
+}
+
+// Autoprovisioned returns true if the node group is autoprovisioned.
+func (n *NodeGroup) Autoprovisioned() bool {
+	return false
+}
+
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (n *NodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	n.Lock()
+	defer n.Unlock()
+	if n.optionsFetched {
+		return n.options, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+	response, err := n.client.NodeGroupGetOptions(ctx, &protos.NodeGroupGetOptionsRequest{
+		Id: n.id,
+		Defaults: &protos.NodeGroupAutoscalingOptions{
+			ScaleDownUtilizationThreshold: defaults.ScaleDownUtilizationThreshold,
+			ScaleDownUnneededTime:         ptypes.DurationProto(defaults.ScaleDownUnneededTime),
+			ScaleDownUnreadyTime:          ptypes.DurationProto(defaults.ScaleDownUnreadyTime),
+			MaxNodeProvisionTime:          ptypes.DurationProto(defaults.MaxNodeProvisionTime),
+			HeadroomNodes:                 int32(defaults.HeadroomNodes),
+			HeadroomCores:                 defaults.HeadroomCores,
+			HeadroomMemory:                defaults.HeadroomMemory,
+		},
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if pbOptions := response.NodeGroupAutoscalingOptions; pbOptions != nil {
+		options := defaults
+		options.ScaleDownUtilizationThreshold = pbOptions.ScaleDownUtilizationThreshold
+		options.HeadroomNodes = int(pbOptions.HeadroomNodes)
+		options.HeadroomCores = pbOptions.HeadroomCores
+		options.HeadroomMemory = pbOptions.HeadroomMemory
+		if options.ScaleDownUnneededTime, err = durationOrDefault(pbOptions.ScaleDownUnneededTime, defaults.ScaleDownUnneededTime); err != nil {
+			return nil, err
+		}
+		if options.ScaleDownUnreadyTime, err = durationOrDefault(pbOptions.ScaleDownUnreadyTime, defaults.ScaleDownUnreadyTime); err != nil {
+			return nil, err
+		}
+		if options.MaxNodeProvisionTime, err = durationOrDefault(pbOptions.MaxNodeProvisionTime, defaults.MaxNodeProvisionTime); err != nil {
+			return nil, err
+		}
+		n.options = &options
+	}
+	n.optionsFetched = true
+	return n.options, nil
+}
+
+func durationOrDefault(pbDuration *duration.Duration, defaultDuration time.Duration) (time.Duration, error) {
+	if pbDuration == nil {
+		return defaultDuration, nil
+	}
+	return ptypes.Duration(pbDuration)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalgrpc
+
+import (
+	"context"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
+)
+
+// pricingModel implements cloudprovider.PricingModel by calling the gRPC server. Prices are cached
+// by the provider per node and per pod until the next Refresh.
+type pricingModel struct {
+	provider *externalGrpcCloudProvider
+}
+
+// priceKey identifies a cached price. Prices of the same node or pod differ only by the length of
+// the priced period within a loop.
+type priceKey struct {
+	object   string
+	duration time.Duration
+}
+
+// NodePrice returns a price of running the given node for a given period of time.
+func (m *pricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	object := node.Spec.ProviderID
+	if object == "" {
+		object = node.Name
+	}
+	key := priceKey{object: object, duration: endTime.Sub(startTime)}
+	e := m.provider
+	e.Lock()
+	price, found := e.nodePrices[key]
+	loop := e.loop
+	e.Unlock()
+	if found {
+		return price, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	start, end := metav1.NewTime(startTime), metav1.NewTime(endTime)
+	response, err := e.client.PricingNodePrice(ctx, &protos.PricingNodePriceRequest{
+		Node:      node,
+		StartTime: &start,
+		EndTime:   &end,
+	})
+	if err != nil {
+		return 0, convertError(err)
+	}
+	e.Lock()
+	defer e.Unlock()
+	if loop == e.loop {
+		e.nodePrices[key] = response.Price
+	}
+	return response.Price, nil
+}
+
+// PodPrice returns a theoretical minimum price of running a pod for a given period of time on a
+// perfectly matching machine.
+func (m *pricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	object := string(pod.UID)
+	if object == "" {
+		object = pod.Namespace + "/" + pod.Name
+	}
+	key := priceKey{object: object, duration: endTime.Sub(startTime)}
+	e := m.provider
+	e.Lock()
+	price, found := e.podPrices[key]
+	loop := e.loop
+	e.Unlock()
+	if found {
+		return price, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	start, end := metav1.NewTime(startTime), metav1.NewTime(endTime)
+	response, err := e.client.PricingPodPrice(ctx, &protos.PricingPodPriceRequest{
+		Pod:       pod,
+		StartTime: &start,
+		EndTime:   &end,
+	})
+	if err != nil {
+		return 0, convertError(err)
+	}
+	e.Lock()
+	defer e.Unlock()
+	if loop == e.loop {
+		e.podPrices[key] = response.Price
+	}
+	return response.Price, nil
+}
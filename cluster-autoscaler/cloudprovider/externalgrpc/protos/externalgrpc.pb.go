@@ -0,0 +1,2115 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: cloudprovider/externalgrpc/protos/externalgrpc.proto
+
+package protos
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import duration "github.com/golang/protobuf/ptypes/duration"
+import v1 "k8s.io/api/core/v1"
+import v11 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// InstanceErrorClass tells why instances couldn't be created.
+type InstanceErrorClass int32
+
+const (
+	// Any error that doesn't belong to another class.
+	InstanceErrorClass_OTHER InstanceErrorClass = 0
+	// A quota of the account or project doesn't allow more instances.
+	InstanceErrorClass_QUOTA_EXCEEDED InstanceErrorClass = 1
+	// The cloud provider is out of capacity for the instance type in the
+	// location of the node group.
+	InstanceErrorClass_STOCKOUT InstanceErrorClass = 2
+	// Instances can't be created from the node group template.
+	InstanceErrorClass_INVALID_CONFIGURATION InstanceErrorClass = 3
+)
+
+var InstanceErrorClass_name = map[int32]string{
+	0: "OTHER",
+	1: "QUOTA_EXCEEDED",
+	2: "STOCKOUT",
+	3: "INVALID_CONFIGURATION",
+}
+var InstanceErrorClass_value = map[string]int32{
+	"OTHER":                 0,
+	"QUOTA_EXCEEDED":        1,
+	"STOCKOUT":              2,
+	"INVALID_CONFIGURATION": 3,
+}
+
+func (x InstanceErrorClass) String() string {
+	return proto.EnumName(InstanceErrorClass_name, int32(x))
+}
+func (InstanceErrorClass) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{0}
+}
+
+type NodeGroup struct {
+	// Id of the node group.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// MinSize of the node group.
+	MinSize int32 `protobuf:"varint,2,opt,name=minSize" json:"minSize,omitempty"`
+	// MaxSize of the node group.
+	MaxSize int32 `protobuf:"varint,3,opt,name=maxSize" json:"maxSize,omitempty"`
+	// Debug is a string containing all information regarding this node group.
+	Debug                string   `protobuf:"bytes,4,opt,name=debug" json:"debug,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroup) Reset()         { *m = NodeGroup{} }
+func (m *NodeGroup) String() string { return proto.CompactTextString(m) }
+func (*NodeGroup) ProtoMessage()    {}
+func (*NodeGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{0}
+}
+func (m *NodeGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroup.Unmarshal(m, b)
+}
+func (m *NodeGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroup.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroup.Merge(dst, src)
+}
+func (m *NodeGroup) XXX_Size() int {
+	return xxx_messageInfo_NodeGroup.Size(m)
+}
+func (m *NodeGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroup proto.InternalMessageInfo
+
+func (m *NodeGroup) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeGroup) GetMinSize() int32 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *NodeGroup) GetMaxSize() int32 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *NodeGroup) GetDebug() string {
+	if m != nil {
+		return m.Debug
+	}
+	return ""
+}
+
+// ExternalGrpcNode carries the fields of a Kubernetes node needed to find the
+// node group it belongs to.
+type ExternalGrpcNode struct {
+	// ProviderID of the node, as set by the cloud controller.
+	ProviderID string `protobuf:"bytes,1,opt,name=providerID" json:"providerID,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Labels of the node.
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Annotations of the node.
+	Annotations          map[string]string `protobuf:"bytes,4,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ExternalGrpcNode) Reset()         { *m = ExternalGrpcNode{} }
+func (m *ExternalGrpcNode) String() string { return proto.CompactTextString(m) }
+func (*ExternalGrpcNode) ProtoMessage()    {}
+func (*ExternalGrpcNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{1}
+}
+func (m *ExternalGrpcNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExternalGrpcNode.Unmarshal(m, b)
+}
+func (m *ExternalGrpcNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExternalGrpcNode.Marshal(b, m, deterministic)
+}
+func (dst *ExternalGrpcNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalGrpcNode.Merge(dst, src)
+}
+func (m *ExternalGrpcNode) XXX_Size() int {
+	return xxx_messageInfo_ExternalGrpcNode.Size(m)
+}
+func (m *ExternalGrpcNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalGrpcNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalGrpcNode proto.InternalMessageInfo
+
+func (m *ExternalGrpcNode) GetProviderID() string {
+	if m != nil {
+		return m.ProviderID
+	}
+	return ""
+}
+
+func (m *ExternalGrpcNode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExternalGrpcNode) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ExternalGrpcNode) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type NodeGroupsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupsRequest) Reset()         { *m = NodeGroupsRequest{} }
+func (m *NodeGroupsRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupsRequest) ProtoMessage()    {}
+func (*NodeGroupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{2}
+}
+func (m *NodeGroupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupsRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupsRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupsRequest.Merge(dst, src)
+}
+func (m *NodeGroupsRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupsRequest.Size(m)
+}
+func (m *NodeGroupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupsRequest proto.InternalMessageInfo
+
+type NodeGroupsResponse struct {
+	NodeGroups           []*NodeGroup `protobuf:"bytes,1,rep,name=nodeGroups" json:"nodeGroups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *NodeGroupsResponse) Reset()         { *m = NodeGroupsResponse{} }
+func (m *NodeGroupsResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupsResponse) ProtoMessage()    {}
+func (*NodeGroupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{3}
+}
+func (m *NodeGroupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupsResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupsResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupsResponse.Merge(dst, src)
+}
+func (m *NodeGroupsResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupsResponse.Size(m)
+}
+func (m *NodeGroupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupsResponse proto.InternalMessageInfo
+
+func (m *NodeGroupsResponse) GetNodeGroups() []*NodeGroup {
+	if m != nil {
+		return m.NodeGroups
+	}
+	return nil
+}
+
+type NodeGroupForNodeRequest struct {
+	Node                 *ExternalGrpcNode `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NodeGroupForNodeRequest) Reset()         { *m = NodeGroupForNodeRequest{} }
+func (m *NodeGroupForNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupForNodeRequest) ProtoMessage()    {}
+func (*NodeGroupForNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{4}
+}
+func (m *NodeGroupForNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupForNodeRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupForNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupForNodeRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupForNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupForNodeRequest.Merge(dst, src)
+}
+func (m *NodeGroupForNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupForNodeRequest.Size(m)
+}
+func (m *NodeGroupForNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupForNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupForNodeRequest proto.InternalMessageInfo
+
+func (m *NodeGroupForNodeRequest) GetNode() *ExternalGrpcNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type NodeGroupForNodeResponse struct {
+	// Node group of the node, with an empty id if the node is not autoscaled.
+	NodeGroup            *NodeGroup `protobuf:"bytes,1,opt,name=nodeGroup" json:"nodeGroup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *NodeGroupForNodeResponse) Reset()         { *m = NodeGroupForNodeResponse{} }
+func (m *NodeGroupForNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupForNodeResponse) ProtoMessage()    {}
+func (*NodeGroupForNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{5}
+}
+func (m *NodeGroupForNodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupForNodeResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupForNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupForNodeResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupForNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupForNodeResponse.Merge(dst, src)
+}
+func (m *NodeGroupForNodeResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupForNodeResponse.Size(m)
+}
+func (m *NodeGroupForNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupForNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupForNodeResponse proto.InternalMessageInfo
+
+func (m *NodeGroupForNodeResponse) GetNodeGroup() *NodeGroup {
+	if m != nil {
+		return m.NodeGroup
+	}
+	return nil
+}
+
+type PricingNodePriceRequest struct {
+	Node                 *v1.Node  `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	StartTime            *v11.Time `protobuf:"bytes,2,opt,name=startTime" json:"startTime,omitempty"`
+	EndTime              *v11.Time `protobuf:"bytes,3,opt,name=endTime" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PricingNodePriceRequest) Reset()         { *m = PricingNodePriceRequest{} }
+func (m *PricingNodePriceRequest) String() string { return proto.CompactTextString(m) }
+func (*PricingNodePriceRequest) ProtoMessage()    {}
+func (*PricingNodePriceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{6}
+}
+func (m *PricingNodePriceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PricingNodePriceRequest.Unmarshal(m, b)
+}
+func (m *PricingNodePriceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PricingNodePriceRequest.Marshal(b, m, deterministic)
+}
+func (dst *PricingNodePriceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PricingNodePriceRequest.Merge(dst, src)
+}
+func (m *PricingNodePriceRequest) XXX_Size() int {
+	return xxx_messageInfo_PricingNodePriceRequest.Size(m)
+}
+func (m *PricingNodePriceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PricingNodePriceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PricingNodePriceRequest proto.InternalMessageInfo
+
+func (m *PricingNodePriceRequest) GetNode() *v1.Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *PricingNodePriceRequest) GetStartTime() *v11.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *PricingNodePriceRequest) GetEndTime() *v11.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type PricingNodePriceResponse struct {
+	Price                float64  `protobuf:"fixed64,1,opt,name=price" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PricingNodePriceResponse) Reset()         { *m = PricingNodePriceResponse{} }
+func (m *PricingNodePriceResponse) String() string { return proto.CompactTextString(m) }
+func (*PricingNodePriceResponse) ProtoMessage()    {}
+func (*PricingNodePriceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{7}
+}
+func (m *PricingNodePriceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PricingNodePriceResponse.Unmarshal(m, b)
+}
+func (m *PricingNodePriceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PricingNodePriceResponse.Marshal(b, m, deterministic)
+}
+func (dst *PricingNodePriceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PricingNodePriceResponse.Merge(dst, src)
+}
+func (m *PricingNodePriceResponse) XXX_Size() int {
+	return xxx_messageInfo_PricingNodePriceResponse.Size(m)
+}
+func (m *PricingNodePriceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PricingNodePriceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PricingNodePriceResponse proto.InternalMessageInfo
+
+func (m *PricingNodePriceResponse) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+type PricingPodPriceRequest struct {
+	Pod                  *v1.Pod   `protobuf:"bytes,1,opt,name=pod" json:"pod,omitempty"`
+	StartTime            *v11.Time `protobuf:"bytes,2,opt,name=startTime" json:"startTime,omitempty"`
+	EndTime              *v11.Time `protobuf:"bytes,3,opt,name=endTime" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PricingPodPriceRequest) Reset()         { *m = PricingPodPriceRequest{} }
+func (m *PricingPodPriceRequest) String() string { return proto.CompactTextString(m) }
+func (*PricingPodPriceRequest) ProtoMessage()    {}
+func (*PricingPodPriceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{8}
+}
+func (m *PricingPodPriceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PricingPodPriceRequest.Unmarshal(m, b)
+}
+func (m *PricingPodPriceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PricingPodPriceRequest.Marshal(b, m, deterministic)
+}
+func (dst *PricingPodPriceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PricingPodPriceRequest.Merge(dst, src)
+}
+func (m *PricingPodPriceRequest) XXX_Size() int {
+	return xxx_messageInfo_PricingPodPriceRequest.Size(m)
+}
+func (m *PricingPodPriceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PricingPodPriceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PricingPodPriceRequest proto.InternalMessageInfo
+
+func (m *PricingPodPriceRequest) GetPod() *v1.Pod {
+	if m != nil {
+		return m.Pod
+	}
+	return nil
+}
+
+func (m *PricingPodPriceRequest) GetStartTime() *v11.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *PricingPodPriceRequest) GetEndTime() *v11.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type PricingPodPriceResponse struct {
+	Price                float64  `protobuf:"fixed64,1,opt,name=price" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PricingPodPriceResponse) Reset()         { *m = PricingPodPriceResponse{} }
+func (m *PricingPodPriceResponse) String() string { return proto.CompactTextString(m) }
+func (*PricingPodPriceResponse) ProtoMessage()    {}
+func (*PricingPodPriceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{9}
+}
+func (m *PricingPodPriceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PricingPodPriceResponse.Unmarshal(m, b)
+}
+func (m *PricingPodPriceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PricingPodPriceResponse.Marshal(b, m, deterministic)
+}
+func (dst *PricingPodPriceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PricingPodPriceResponse.Merge(dst, src)
+}
+func (m *PricingPodPriceResponse) XXX_Size() int {
+	return xxx_messageInfo_PricingPodPriceResponse.Size(m)
+}
+func (m *PricingPodPriceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PricingPodPriceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PricingPodPriceResponse proto.InternalMessageInfo
+
+func (m *PricingPodPriceResponse) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+type GetAvailableMachineTypesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAvailableMachineTypesRequest) Reset()         { *m = GetAvailableMachineTypesRequest{} }
+func (m *GetAvailableMachineTypesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAvailableMachineTypesRequest) ProtoMessage()    {}
+func (*GetAvailableMachineTypesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{10}
+}
+func (m *GetAvailableMachineTypesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAvailableMachineTypesRequest.Unmarshal(m, b)
+}
+func (m *GetAvailableMachineTypesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAvailableMachineTypesRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetAvailableMachineTypesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAvailableMachineTypesRequest.Merge(dst, src)
+}
+func (m *GetAvailableMachineTypesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAvailableMachineTypesRequest.Size(m)
+}
+func (m *GetAvailableMachineTypesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAvailableMachineTypesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAvailableMachineTypesRequest proto.InternalMessageInfo
+
+type GetAvailableMachineTypesResponse struct {
+	MachineTypes         []string `protobuf:"bytes,1,rep,name=machineTypes" json:"machineTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAvailableMachineTypesResponse) Reset()         { *m = GetAvailableMachineTypesResponse{} }
+func (m *GetAvailableMachineTypesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAvailableMachineTypesResponse) ProtoMessage()    {}
+func (*GetAvailableMachineTypesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{11}
+}
+func (m *GetAvailableMachineTypesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAvailableMachineTypesResponse.Unmarshal(m, b)
+}
+func (m *GetAvailableMachineTypesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAvailableMachineTypesResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetAvailableMachineTypesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAvailableMachineTypesResponse.Merge(dst, src)
+}
+func (m *GetAvailableMachineTypesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAvailableMachineTypesResponse.Size(m)
+}
+func (m *GetAvailableMachineTypesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAvailableMachineTypesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAvailableMachineTypesResponse proto.InternalMessageInfo
+
+func (m *GetAvailableMachineTypesResponse) GetMachineTypes() []string {
+	if m != nil {
+		return m.MachineTypes
+	}
+	return nil
+}
+
+type CleanupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanupRequest) Reset()         { *m = CleanupRequest{} }
+func (m *CleanupRequest) String() string { return proto.CompactTextString(m) }
+func (*CleanupRequest) ProtoMessage()    {}
+func (*CleanupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{12}
+}
+func (m *CleanupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CleanupRequest.Unmarshal(m, b)
+}
+func (m *CleanupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CleanupRequest.Marshal(b, m, deterministic)
+}
+func (dst *CleanupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanupRequest.Merge(dst, src)
+}
+func (m *CleanupRequest) XXX_Size() int {
+	return xxx_messageInfo_CleanupRequest.Size(m)
+}
+func (m *CleanupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanupRequest proto.InternalMessageInfo
+
+type CleanupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanupResponse) Reset()         { *m = CleanupResponse{} }
+func (m *CleanupResponse) String() string { return proto.CompactTextString(m) }
+func (*CleanupResponse) ProtoMessage()    {}
+func (*CleanupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{13}
+}
+func (m *CleanupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CleanupResponse.Unmarshal(m, b)
+}
+func (m *CleanupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CleanupResponse.Marshal(b, m, deterministic)
+}
+func (dst *CleanupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanupResponse.Merge(dst, src)
+}
+func (m *CleanupResponse) XXX_Size() int {
+	return xxx_messageInfo_CleanupResponse.Size(m)
+}
+func (m *CleanupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanupResponse proto.InternalMessageInfo
+
+type RefreshRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefreshRequest) Reset()         { *m = RefreshRequest{} }
+func (m *RefreshRequest) String() string { return proto.CompactTextString(m) }
+func (*RefreshRequest) ProtoMessage()    {}
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{14}
+}
+func (m *RefreshRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshRequest.Unmarshal(m, b)
+}
+func (m *RefreshRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshRequest.Marshal(b, m, deterministic)
+}
+func (dst *RefreshRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshRequest.Merge(dst, src)
+}
+func (m *RefreshRequest) XXX_Size() int {
+	return xxx_messageInfo_RefreshRequest.Size(m)
+}
+func (m *RefreshRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshRequest proto.InternalMessageInfo
+
+type RefreshResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefreshResponse) Reset()         { *m = RefreshResponse{} }
+func (m *RefreshResponse) String() string { return proto.CompactTextString(m) }
+func (*RefreshResponse) ProtoMessage()    {}
+func (*RefreshResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{15}
+}
+func (m *RefreshResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshResponse.Unmarshal(m, b)
+}
+func (m *RefreshResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshResponse.Marshal(b, m, deterministic)
+}
+func (dst *RefreshResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshResponse.Merge(dst, src)
+}
+func (m *RefreshResponse) XXX_Size() int {
+	return xxx_messageInfo_RefreshResponse.Size(m)
+}
+func (m *RefreshResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshResponse proto.InternalMessageInfo
+
+type NodeGroupTargetSizeRequest struct {
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupTargetSizeRequest) Reset()         { *m = NodeGroupTargetSizeRequest{} }
+func (m *NodeGroupTargetSizeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupTargetSizeRequest) ProtoMessage()    {}
+func (*NodeGroupTargetSizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{16}
+}
+func (m *NodeGroupTargetSizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupTargetSizeRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupTargetSizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupTargetSizeRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupTargetSizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupTargetSizeRequest.Merge(dst, src)
+}
+func (m *NodeGroupTargetSizeRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupTargetSizeRequest.Size(m)
+}
+func (m *NodeGroupTargetSizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupTargetSizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupTargetSizeRequest proto.InternalMessageInfo
+
+func (m *NodeGroupTargetSizeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupTargetSizeResponse struct {
+	TargetSize           int32    `protobuf:"varint,1,opt,name=targetSize" json:"targetSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupTargetSizeResponse) Reset()         { *m = NodeGroupTargetSizeResponse{} }
+func (m *NodeGroupTargetSizeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupTargetSizeResponse) ProtoMessage()    {}
+func (*NodeGroupTargetSizeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{17}
+}
+func (m *NodeGroupTargetSizeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupTargetSizeResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupTargetSizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupTargetSizeResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupTargetSizeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupTargetSizeResponse.Merge(dst, src)
+}
+func (m *NodeGroupTargetSizeResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupTargetSizeResponse.Size(m)
+}
+func (m *NodeGroupTargetSizeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupTargetSizeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupTargetSizeResponse proto.InternalMessageInfo
+
+func (m *NodeGroupTargetSizeResponse) GetTargetSize() int32 {
+	if m != nil {
+		return m.TargetSize
+	}
+	return 0
+}
+
+type NodeGroupIncreaseSizeRequest struct {
+	// Number of nodes to add, always positive.
+	Delta int32 `protobuf:"varint,1,opt,name=delta" json:"delta,omitempty"`
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupIncreaseSizeRequest) Reset()         { *m = NodeGroupIncreaseSizeRequest{} }
+func (m *NodeGroupIncreaseSizeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupIncreaseSizeRequest) ProtoMessage()    {}
+func (*NodeGroupIncreaseSizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{18}
+}
+func (m *NodeGroupIncreaseSizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupIncreaseSizeRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupIncreaseSizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupIncreaseSizeRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupIncreaseSizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupIncreaseSizeRequest.Merge(dst, src)
+}
+func (m *NodeGroupIncreaseSizeRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupIncreaseSizeRequest.Size(m)
+}
+func (m *NodeGroupIncreaseSizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupIncreaseSizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupIncreaseSizeRequest proto.InternalMessageInfo
+
+func (m *NodeGroupIncreaseSizeRequest) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *NodeGroupIncreaseSizeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupIncreaseSizeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupIncreaseSizeResponse) Reset()         { *m = NodeGroupIncreaseSizeResponse{} }
+func (m *NodeGroupIncreaseSizeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupIncreaseSizeResponse) ProtoMessage()    {}
+func (*NodeGroupIncreaseSizeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{19}
+}
+func (m *NodeGroupIncreaseSizeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupIncreaseSizeResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupIncreaseSizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupIncreaseSizeResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupIncreaseSizeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupIncreaseSizeResponse.Merge(dst, src)
+}
+func (m *NodeGroupIncreaseSizeResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupIncreaseSizeResponse.Size(m)
+}
+func (m *NodeGroupIncreaseSizeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupIncreaseSizeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupIncreaseSizeResponse proto.InternalMessageInfo
+
+// InstanceCreationErrorDetails are attached to the status details of a failed
+// NodeGroupIncreaseSize call when the server knows why instances couldn't be
+// created.
+type InstanceCreationErrorDetails struct {
+	ErrorClass InstanceErrorClass `protobuf:"varint,1,opt,name=errorClass,enum=clusterautoscaler.cloudprovider.v1.externalgrpc.InstanceErrorClass" json:"errorClass,omitempty"`
+	// Cloud provider specific error code, if any.
+	ErrorCode            string   `protobuf:"bytes,2,opt,name=errorCode" json:"errorCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstanceCreationErrorDetails) Reset()         { *m = InstanceCreationErrorDetails{} }
+func (m *InstanceCreationErrorDetails) String() string { return proto.CompactTextString(m) }
+func (*InstanceCreationErrorDetails) ProtoMessage()    {}
+func (*InstanceCreationErrorDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{20}
+}
+func (m *InstanceCreationErrorDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceCreationErrorDetails.Unmarshal(m, b)
+}
+func (m *InstanceCreationErrorDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceCreationErrorDetails.Marshal(b, m, deterministic)
+}
+func (dst *InstanceCreationErrorDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceCreationErrorDetails.Merge(dst, src)
+}
+func (m *InstanceCreationErrorDetails) XXX_Size() int {
+	return xxx_messageInfo_InstanceCreationErrorDetails.Size(m)
+}
+func (m *InstanceCreationErrorDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceCreationErrorDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceCreationErrorDetails proto.InternalMessageInfo
+
+func (m *InstanceCreationErrorDetails) GetErrorClass() InstanceErrorClass {
+	if m != nil {
+		return m.ErrorClass
+	}
+	return InstanceErrorClass_OTHER
+}
+
+func (m *InstanceCreationErrorDetails) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+type NodeGroupDeleteNodesRequest struct {
+	Nodes []*ExternalGrpcNode `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupDeleteNodesRequest) Reset()         { *m = NodeGroupDeleteNodesRequest{} }
+func (m *NodeGroupDeleteNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupDeleteNodesRequest) ProtoMessage()    {}
+func (*NodeGroupDeleteNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{21}
+}
+func (m *NodeGroupDeleteNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupDeleteNodesRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupDeleteNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupDeleteNodesRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupDeleteNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupDeleteNodesRequest.Merge(dst, src)
+}
+func (m *NodeGroupDeleteNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupDeleteNodesRequest.Size(m)
+}
+func (m *NodeGroupDeleteNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupDeleteNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupDeleteNodesRequest proto.InternalMessageInfo
+
+func (m *NodeGroupDeleteNodesRequest) GetNodes() []*ExternalGrpcNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *NodeGroupDeleteNodesRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupDeleteNodesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupDeleteNodesResponse) Reset()         { *m = NodeGroupDeleteNodesResponse{} }
+func (m *NodeGroupDeleteNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupDeleteNodesResponse) ProtoMessage()    {}
+func (*NodeGroupDeleteNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{22}
+}
+func (m *NodeGroupDeleteNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupDeleteNodesResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupDeleteNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupDeleteNodesResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupDeleteNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupDeleteNodesResponse.Merge(dst, src)
+}
+func (m *NodeGroupDeleteNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupDeleteNodesResponse.Size(m)
+}
+func (m *NodeGroupDeleteNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupDeleteNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupDeleteNodesResponse proto.InternalMessageInfo
+
+type NodeGroupDecreaseTargetSizeRequest struct {
+	// Number of nodes to remove from the target size, always negative.
+	Delta int32 `protobuf:"varint,1,opt,name=delta" json:"delta,omitempty"`
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupDecreaseTargetSizeRequest) Reset()         { *m = NodeGroupDecreaseTargetSizeRequest{} }
+func (m *NodeGroupDecreaseTargetSizeRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupDecreaseTargetSizeRequest) ProtoMessage()    {}
+func (*NodeGroupDecreaseTargetSizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{23}
+}
+func (m *NodeGroupDecreaseTargetSizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupDecreaseTargetSizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupDecreaseTargetSizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest.Merge(dst, src)
+}
+func (m *NodeGroupDecreaseTargetSizeRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest.Size(m)
+}
+func (m *NodeGroupDecreaseTargetSizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupDecreaseTargetSizeRequest proto.InternalMessageInfo
+
+func (m *NodeGroupDecreaseTargetSizeRequest) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *NodeGroupDecreaseTargetSizeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupDecreaseTargetSizeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupDecreaseTargetSizeResponse) Reset()         { *m = NodeGroupDecreaseTargetSizeResponse{} }
+func (m *NodeGroupDecreaseTargetSizeResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupDecreaseTargetSizeResponse) ProtoMessage()    {}
+func (*NodeGroupDecreaseTargetSizeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{24}
+}
+func (m *NodeGroupDecreaseTargetSizeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupDecreaseTargetSizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupDecreaseTargetSizeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse.Merge(dst, src)
+}
+func (m *NodeGroupDecreaseTargetSizeResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse.Size(m)
+}
+func (m *NodeGroupDecreaseTargetSizeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupDecreaseTargetSizeResponse proto.InternalMessageInfo
+
+type NodeGroupNodesRequest struct {
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupNodesRequest) Reset()         { *m = NodeGroupNodesRequest{} }
+func (m *NodeGroupNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupNodesRequest) ProtoMessage()    {}
+func (*NodeGroupNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{25}
+}
+func (m *NodeGroupNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupNodesRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupNodesRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupNodesRequest.Merge(dst, src)
+}
+func (m *NodeGroupNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupNodesRequest.Size(m)
+}
+func (m *NodeGroupNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupNodesRequest proto.InternalMessageInfo
+
+func (m *NodeGroupNodesRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupNodesResponse struct {
+	// Ids of the nodes, matching the providerID of their Kubernetes nodes.
+	Nodes                []string `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupNodesResponse) Reset()         { *m = NodeGroupNodesResponse{} }
+func (m *NodeGroupNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupNodesResponse) ProtoMessage()    {}
+func (*NodeGroupNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{26}
+}
+func (m *NodeGroupNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupNodesResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupNodesResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupNodesResponse.Merge(dst, src)
+}
+func (m *NodeGroupNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupNodesResponse.Size(m)
+}
+func (m *NodeGroupNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupNodesResponse proto.InternalMessageInfo
+
+func (m *NodeGroupNodesResponse) GetNodes() []string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NodeGroupTemplateNodeInfoRequest struct {
+	// Id of the node group.
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupTemplateNodeInfoRequest) Reset()         { *m = NodeGroupTemplateNodeInfoRequest{} }
+func (m *NodeGroupTemplateNodeInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupTemplateNodeInfoRequest) ProtoMessage()    {}
+func (*NodeGroupTemplateNodeInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{27}
+}
+func (m *NodeGroupTemplateNodeInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupTemplateNodeInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupTemplateNodeInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupTemplateNodeInfoRequest.Merge(dst, src)
+}
+func (m *NodeGroupTemplateNodeInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoRequest.Size(m)
+}
+func (m *NodeGroupTemplateNodeInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupTemplateNodeInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupTemplateNodeInfoRequest proto.InternalMessageInfo
+
+func (m *NodeGroupTemplateNodeInfoRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NodeGroupTemplateNodeInfoResponse struct {
+	// Template node of the node group.
+	NodeInfo *v1.Node `protobuf:"bytes,1,opt,name=nodeInfo" json:"nodeInfo,omitempty"`
+	// Pods expected to run on every new node, e.g. DaemonSet pods.
+	Pods                 []*v1.Pod `protobuf:"bytes,2,rep,name=pods" json:"pods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *NodeGroupTemplateNodeInfoResponse) Reset()         { *m = NodeGroupTemplateNodeInfoResponse{} }
+func (m *NodeGroupTemplateNodeInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupTemplateNodeInfoResponse) ProtoMessage()    {}
+func (*NodeGroupTemplateNodeInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{28}
+}
+func (m *NodeGroupTemplateNodeInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupTemplateNodeInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupTemplateNodeInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupTemplateNodeInfoResponse.Merge(dst, src)
+}
+func (m *NodeGroupTemplateNodeInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupTemplateNodeInfoResponse.Size(m)
+}
+func (m *NodeGroupTemplateNodeInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupTemplateNodeInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupTemplateNodeInfoResponse proto.InternalMessageInfo
+
+func (m *NodeGroupTemplateNodeInfoResponse) GetNodeInfo() *v1.Node {
+	if m != nil {
+		return m.NodeInfo
+	}
+	return nil
+}
+
+func (m *NodeGroupTemplateNodeInfoResponse) GetPods() []*v1.Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type NodeGroupAutoscalingOptions struct {
+	// Utilization below which a node of the node group can be considered for scale down.
+	ScaleDownUtilizationThreshold float64 `protobuf:"fixed64,1,opt,name=scaleDownUtilizationThreshold" json:"scaleDownUtilizationThreshold,omitempty"`
+	// How long a node of the node group should be unneeded before it is eligible for scale down.
+	ScaleDownUnneededTime *duration.Duration `protobuf:"bytes,2,opt,name=scaleDownUnneededTime" json:"scaleDownUnneededTime,omitempty"`
+	// How long an unready node of the node group should be unneeded before it is eligible for scale down.
+	ScaleDownUnreadyTime *duration.Duration `protobuf:"bytes,3,opt,name=scaleDownUnreadyTime" json:"scaleDownUnreadyTime,omitempty"`
+	// Maximum time to wait for a node of the node group to be provisioned.
+	MaxNodeProvisionTime *duration.Duration `protobuf:"bytes,4,opt,name=maxNodeProvisionTime" json:"maxNodeProvisionTime,omitempty"`
+	// Number of empty nodes worth of free capacity kept in the node group.
+	HeadroomNodes int32 `protobuf:"varint,5,opt,name=headroomNodes" json:"headroomNodes,omitempty"`
+	// Number of free cores kept in the node group on top of headroomNodes.
+	HeadroomCores int64 `protobuf:"varint,6,opt,name=headroomCores" json:"headroomCores,omitempty"`
+	// Free memory (in bytes) kept in the node group on top of headroomNodes.
+	HeadroomMemory       int64    `protobuf:"varint,7,opt,name=headroomMemory" json:"headroomMemory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeGroupAutoscalingOptions) Reset()         { *m = NodeGroupAutoscalingOptions{} }
+func (m *NodeGroupAutoscalingOptions) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupAutoscalingOptions) ProtoMessage()    {}
+func (*NodeGroupAutoscalingOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{29}
+}
+func (m *NodeGroupAutoscalingOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupAutoscalingOptions.Unmarshal(m, b)
+}
+func (m *NodeGroupAutoscalingOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupAutoscalingOptions.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupAutoscalingOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupAutoscalingOptions.Merge(dst, src)
+}
+func (m *NodeGroupAutoscalingOptions) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupAutoscalingOptions.Size(m)
+}
+func (m *NodeGroupAutoscalingOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupAutoscalingOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupAutoscalingOptions proto.InternalMessageInfo
+
+func (m *NodeGroupAutoscalingOptions) GetScaleDownUtilizationThreshold() float64 {
+	if m != nil {
+		return m.ScaleDownUtilizationThreshold
+	}
+	return 0
+}
+
+func (m *NodeGroupAutoscalingOptions) GetScaleDownUnneededTime() *duration.Duration {
+	if m != nil {
+		return m.ScaleDownUnneededTime
+	}
+	return nil
+}
+
+func (m *NodeGroupAutoscalingOptions) GetScaleDownUnreadyTime() *duration.Duration {
+	if m != nil {
+		return m.ScaleDownUnreadyTime
+	}
+	return nil
+}
+
+func (m *NodeGroupAutoscalingOptions) GetMaxNodeProvisionTime() *duration.Duration {
+	if m != nil {
+		return m.MaxNodeProvisionTime
+	}
+	return nil
+}
+
+func (m *NodeGroupAutoscalingOptions) GetHeadroomNodes() int32 {
+	if m != nil {
+		return m.HeadroomNodes
+	}
+	return 0
+}
+
+func (m *NodeGroupAutoscalingOptions) GetHeadroomCores() int64 {
+	if m != nil {
+		return m.HeadroomCores
+	}
+	return 0
+}
+
+func (m *NodeGroupAutoscalingOptions) GetHeadroomMemory() int64 {
+	if m != nil {
+		return m.HeadroomMemory
+	}
+	return 0
+}
+
+type NodeGroupGetOptionsRequest struct {
+	// Id of the node group.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Options used for node groups that don't override them.
+	Defaults             *NodeGroupAutoscalingOptions `protobuf:"bytes,2,opt,name=defaults" json:"defaults,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *NodeGroupGetOptionsRequest) Reset()         { *m = NodeGroupGetOptionsRequest{} }
+func (m *NodeGroupGetOptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupGetOptionsRequest) ProtoMessage()    {}
+func (*NodeGroupGetOptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{30}
+}
+func (m *NodeGroupGetOptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupGetOptionsRequest.Unmarshal(m, b)
+}
+func (m *NodeGroupGetOptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupGetOptionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupGetOptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupGetOptionsRequest.Merge(dst, src)
+}
+func (m *NodeGroupGetOptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupGetOptionsRequest.Size(m)
+}
+func (m *NodeGroupGetOptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupGetOptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupGetOptionsRequest proto.InternalMessageInfo
+
+func (m *NodeGroupGetOptionsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeGroupGetOptionsRequest) GetDefaults() *NodeGroupAutoscalingOptions {
+	if m != nil {
+		return m.Defaults
+	}
+	return nil
+}
+
+type NodeGroupGetOptionsResponse struct {
+	// Options of the node group, unset to use the defaults.
+	NodeGroupAutoscalingOptions *NodeGroupAutoscalingOptions `protobuf:"bytes,1,opt,name=nodeGroupAutoscalingOptions" json:"nodeGroupAutoscalingOptions,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}                     `json:"-"`
+	XXX_unrecognized            []byte                       `json:"-"`
+	XXX_sizecache               int32                        `json:"-"`
+}
+
+func (m *NodeGroupGetOptionsResponse) Reset()         { *m = NodeGroupGetOptionsResponse{} }
+func (m *NodeGroupGetOptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeGroupGetOptionsResponse) ProtoMessage()    {}
+func (*NodeGroupGetOptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_externalgrpc_02695e4bec27b1ce, []int{31}
+}
+func (m *NodeGroupGetOptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeGroupGetOptionsResponse.Unmarshal(m, b)
+}
+func (m *NodeGroupGetOptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeGroupGetOptionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeGroupGetOptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeGroupGetOptionsResponse.Merge(dst, src)
+}
+func (m *NodeGroupGetOptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeGroupGetOptionsResponse.Size(m)
+}
+func (m *NodeGroupGetOptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeGroupGetOptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeGroupGetOptionsResponse proto.InternalMessageInfo
+
+func (m *NodeGroupGetOptionsResponse) GetNodeGroupAutoscalingOptions() *NodeGroupAutoscalingOptions {
+	if m != nil {
+		return m.NodeGroupAutoscalingOptions
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*NodeGroup)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroup")
+	proto.RegisterType((*ExternalGrpcNode)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.ExternalGrpcNode")
+	proto.RegisterMapType((map[string]string)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.ExternalGrpcNode.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.ExternalGrpcNode.LabelsEntry")
+	proto.RegisterType((*NodeGroupsRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupsRequest")
+	proto.RegisterType((*NodeGroupsResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupsResponse")
+	proto.RegisterType((*NodeGroupForNodeRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupForNodeRequest")
+	proto.RegisterType((*NodeGroupForNodeResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupForNodeResponse")
+	proto.RegisterType((*PricingNodePriceRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.PricingNodePriceRequest")
+	proto.RegisterType((*PricingNodePriceResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.PricingNodePriceResponse")
+	proto.RegisterType((*PricingPodPriceRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.PricingPodPriceRequest")
+	proto.RegisterType((*PricingPodPriceResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.PricingPodPriceResponse")
+	proto.RegisterType((*GetAvailableMachineTypesRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.GetAvailableMachineTypesRequest")
+	proto.RegisterType((*GetAvailableMachineTypesResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.GetAvailableMachineTypesResponse")
+	proto.RegisterType((*CleanupRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.CleanupRequest")
+	proto.RegisterType((*CleanupResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.CleanupResponse")
+	proto.RegisterType((*RefreshRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.RefreshRequest")
+	proto.RegisterType((*RefreshResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.RefreshResponse")
+	proto.RegisterType((*NodeGroupTargetSizeRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupTargetSizeRequest")
+	proto.RegisterType((*NodeGroupTargetSizeResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupTargetSizeResponse")
+	proto.RegisterType((*NodeGroupIncreaseSizeRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupIncreaseSizeRequest")
+	proto.RegisterType((*NodeGroupIncreaseSizeResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupIncreaseSizeResponse")
+	proto.RegisterType((*InstanceCreationErrorDetails)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.InstanceCreationErrorDetails")
+	proto.RegisterType((*NodeGroupDeleteNodesRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupDeleteNodesRequest")
+	proto.RegisterType((*NodeGroupDeleteNodesResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupDeleteNodesResponse")
+	proto.RegisterType((*NodeGroupDecreaseTargetSizeRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupDecreaseTargetSizeRequest")
+	proto.RegisterType((*NodeGroupDecreaseTargetSizeResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupDecreaseTargetSizeResponse")
+	proto.RegisterType((*NodeGroupNodesRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupNodesRequest")
+	proto.RegisterType((*NodeGroupNodesResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupNodesResponse")
+	proto.RegisterType((*NodeGroupTemplateNodeInfoRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupTemplateNodeInfoRequest")
+	proto.RegisterType((*NodeGroupTemplateNodeInfoResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupTemplateNodeInfoResponse")
+	proto.RegisterType((*NodeGroupAutoscalingOptions)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupAutoscalingOptions")
+	proto.RegisterType((*NodeGroupGetOptionsRequest)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupGetOptionsRequest")
+	proto.RegisterType((*NodeGroupGetOptionsResponse)(nil), "clusterautoscaler.cloudprovider.v1.externalgrpc.NodeGroupGetOptionsResponse")
+	proto.RegisterEnum("clusterautoscaler.cloudprovider.v1.externalgrpc.InstanceErrorClass", InstanceErrorClass_name, InstanceErrorClass_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for CloudProvider service
+
+type CloudProviderClient interface {
+	// NodeGroups returns all node groups configured for this cloud provider.
+	NodeGroups(ctx context.Context, in *NodeGroupsRequest, opts ...grpc.CallOption) (*NodeGroupsResponse, error)
+	// NodeGroupForNode returns the node group for the given node. The node group
+	// id is an empty string if the node should not be processed by Cluster
+	// Autoscaler.
+	NodeGroupForNode(ctx context.Context, in *NodeGroupForNodeRequest, opts ...grpc.CallOption) (*NodeGroupForNodeResponse, error)
+	// PricingNodePrice returns a theoretical minimum price of running a node for
+	// a given period of time on a perfectly matching machine.
+	PricingNodePrice(ctx context.Context, in *PricingNodePriceRequest, opts ...grpc.CallOption) (*PricingNodePriceResponse, error)
+	// PricingPodPrice returns a theoretical minimum price of running a pod for a
+	// given period of time on a perfectly matching machine.
+	PricingPodPrice(ctx context.Context, in *PricingPodPriceRequest, opts ...grpc.CallOption) (*PricingPodPriceResponse, error)
+	// GetAvailableMachineTypes returns all machine types that can be requested
+	// from the cloud provider.
+	GetAvailableMachineTypes(ctx context.Context, in *GetAvailableMachineTypesRequest, opts ...grpc.CallOption) (*GetAvailableMachineTypesResponse, error)
+	// Cleanup cleans up open resources before the cloud provider is destroyed,
+	// i.e. go routines etc.
+	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+	// Refresh is called before every main loop and can be used to dynamically
+	// update cloud provider state.
+	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
+	// NodeGroupTargetSize returns the current target size of the node group.
+	NodeGroupTargetSize(ctx context.Context, in *NodeGroupTargetSizeRequest, opts ...grpc.CallOption) (*NodeGroupTargetSizeResponse, error)
+	// NodeGroupIncreaseSize increases the size of the node group. The delta is
+	// always positive. Errors may carry InstanceCreationErrorDetails in their
+	// status details to tell why instances couldn't be created.
+	NodeGroupIncreaseSize(ctx context.Context, in *NodeGroupIncreaseSizeRequest, opts ...grpc.CallOption) (*NodeGroupIncreaseSizeResponse, error)
+	// NodeGroupDeleteNodes deletes nodes from the node group and decreases its
+	// size accordingly.
+	NodeGroupDeleteNodes(ctx context.Context, in *NodeGroupDeleteNodesRequest, opts ...grpc.CallOption) (*NodeGroupDeleteNodesResponse, error)
+	// NodeGroupDecreaseTargetSize decreases the target size of the node group
+	// without deleting any existing node. The delta is always negative.
+	NodeGroupDecreaseTargetSize(ctx context.Context, in *NodeGroupDecreaseTargetSizeRequest, opts ...grpc.CallOption) (*NodeGroupDecreaseTargetSizeResponse, error)
+	// NodeGroupNodes returns the ids of all nodes belonging to the node group.
+	NodeGroupNodes(ctx context.Context, in *NodeGroupNodesRequest, opts ...grpc.CallOption) (*NodeGroupNodesResponse, error)
+	// NodeGroupTemplateNodeInfo returns a node and the pods running on it, built
+	// as if a new node was just added to the node group.
+	NodeGroupTemplateNodeInfo(ctx context.Context, in *NodeGroupTemplateNodeInfoRequest, opts ...grpc.CallOption) (*NodeGroupTemplateNodeInfoResponse, error)
+	// NodeGroupGetOptions returns the autoscaling options of the node group,
+	// starting from the given defaults. Returning no options means the defaults
+	// are used.
+	NodeGroupGetOptions(ctx context.Context, in *NodeGroupGetOptionsRequest, opts ...grpc.CallOption) (*NodeGroupGetOptionsResponse, error)
+}
+
+type cloudProviderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCloudProviderClient(cc *grpc.ClientConn) CloudProviderClient {
+	return &cloudProviderClient{cc}
+}
+
+func (c *cloudProviderClient) NodeGroups(ctx context.Context, in *NodeGroupsRequest, opts ...grpc.CallOption) (*NodeGroupsResponse, error) {
+	out := new(NodeGroupsResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupForNode(ctx context.Context, in *NodeGroupForNodeRequest, opts ...grpc.CallOption) (*NodeGroupForNodeResponse, error) {
+	out := new(NodeGroupForNodeResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupForNode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) PricingNodePrice(ctx context.Context, in *PricingNodePriceRequest, opts ...grpc.CallOption) (*PricingNodePriceResponse, error) {
+	out := new(PricingNodePriceResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/PricingNodePrice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) PricingPodPrice(ctx context.Context, in *PricingPodPriceRequest, opts ...grpc.CallOption) (*PricingPodPriceResponse, error) {
+	out := new(PricingPodPriceResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/PricingPodPrice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) GetAvailableMachineTypes(ctx context.Context, in *GetAvailableMachineTypesRequest, opts ...grpc.CallOption) (*GetAvailableMachineTypesResponse, error) {
+	out := new(GetAvailableMachineTypesResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/GetAvailableMachineTypes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error) {
+	out := new(CleanupResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Cleanup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error) {
+	out := new(RefreshResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Refresh", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupTargetSize(ctx context.Context, in *NodeGroupTargetSizeRequest, opts ...grpc.CallOption) (*NodeGroupTargetSizeResponse, error) {
+	out := new(NodeGroupTargetSizeResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupTargetSize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupIncreaseSize(ctx context.Context, in *NodeGroupIncreaseSizeRequest, opts ...grpc.CallOption) (*NodeGroupIncreaseSizeResponse, error) {
+	out := new(NodeGroupIncreaseSizeResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupIncreaseSize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupDeleteNodes(ctx context.Context, in *NodeGroupDeleteNodesRequest, opts ...grpc.CallOption) (*NodeGroupDeleteNodesResponse, error) {
+	out := new(NodeGroupDeleteNodesResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupDeleteNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupDecreaseTargetSize(ctx context.Context, in *NodeGroupDecreaseTargetSizeRequest, opts ...grpc.CallOption) (*NodeGroupDecreaseTargetSizeResponse, error) {
+	out := new(NodeGroupDecreaseTargetSizeResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupDecreaseTargetSize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupNodes(ctx context.Context, in *NodeGroupNodesRequest, opts ...grpc.CallOption) (*NodeGroupNodesResponse, error) {
+	out := new(NodeGroupNodesResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupTemplateNodeInfo(ctx context.Context, in *NodeGroupTemplateNodeInfoRequest, opts ...grpc.CallOption) (*NodeGroupTemplateNodeInfoResponse, error) {
+	out := new(NodeGroupTemplateNodeInfoResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupTemplateNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderClient) NodeGroupGetOptions(ctx context.Context, in *NodeGroupGetOptionsRequest, opts ...grpc.CallOption) (*NodeGroupGetOptionsResponse, error) {
+	out := new(NodeGroupGetOptionsResponse)
+	err := grpc.Invoke(ctx, "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupGetOptions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for CloudProvider service
+
+type CloudProviderServer interface {
+	// NodeGroups returns all node groups configured for this cloud provider.
+	NodeGroups(context.Context, *NodeGroupsRequest) (*NodeGroupsResponse, error)
+	// NodeGroupForNode returns the node group for the given node. The node group
+	// id is an empty string if the node should not be processed by Cluster
+	// Autoscaler.
+	NodeGroupForNode(context.Context, *NodeGroupForNodeRequest) (*NodeGroupForNodeResponse, error)
+	// PricingNodePrice returns a theoretical minimum price of running a node for
+	// a given period of time on a perfectly matching machine.
+	PricingNodePrice(context.Context, *PricingNodePriceRequest) (*PricingNodePriceResponse, error)
+	// PricingPodPrice returns a theoretical minimum price of running a pod for a
+	// given period of time on a perfectly matching machine.
+	PricingPodPrice(context.Context, *PricingPodPriceRequest) (*PricingPodPriceResponse, error)
+	// GetAvailableMachineTypes returns all machine types that can be requested
+	// from the cloud provider.
+	GetAvailableMachineTypes(context.Context, *GetAvailableMachineTypesRequest) (*GetAvailableMachineTypesResponse, error)
+	// Cleanup cleans up open resources before the cloud provider is destroyed,
+	// i.e. go routines etc.
+	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
+	// Refresh is called before every main loop and can be used to dynamically
+	// update cloud provider state.
+	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
+	// NodeGroupTargetSize returns the current target size of the node group.
+	NodeGroupTargetSize(context.Context, *NodeGroupTargetSizeRequest) (*NodeGroupTargetSizeResponse, error)
+	// NodeGroupIncreaseSize increases the size of the node group. The delta is
+	// always positive. Errors may carry InstanceCreationErrorDetails in their
+	// status details to tell why instances couldn't be created.
+	NodeGroupIncreaseSize(context.Context, *NodeGroupIncreaseSizeRequest) (*NodeGroupIncreaseSizeResponse, error)
+	// NodeGroupDeleteNodes deletes nodes from the node group and decreases its
+	// size accordingly.
+	NodeGroupDeleteNodes(context.Context, *NodeGroupDeleteNodesRequest) (*NodeGroupDeleteNodesResponse, error)
+	// NodeGroupDecreaseTargetSize decreases the target size of the node group
+	// without deleting any existing node. The delta is always negative.
+	NodeGroupDecreaseTargetSize(context.Context, *NodeGroupDecreaseTargetSizeRequest) (*NodeGroupDecreaseTargetSizeResponse, error)
+	// NodeGroupNodes returns the ids of all nodes belonging to the node group.
+	NodeGroupNodes(context.Context, *NodeGroupNodesRequest) (*NodeGroupNodesResponse, error)
+	// NodeGroupTemplateNodeInfo returns a node and the pods running on it, built
+	// as if a new node was just added to the node group.
+	NodeGroupTemplateNodeInfo(context.Context, *NodeGroupTemplateNodeInfoRequest) (*NodeGroupTemplateNodeInfoResponse, error)
+	// NodeGroupGetOptions returns the autoscaling options of the node group,
+	// starting from the given defaults. Returning no options means the defaults
+	// are used.
+	NodeGroupGetOptions(context.Context, *NodeGroupGetOptionsRequest) (*NodeGroupGetOptionsResponse, error)
+}
+
+func RegisterCloudProviderServer(s *grpc.Server, srv CloudProviderServer) {
+	s.RegisterService(&_CloudProvider_serviceDesc, srv)
+}
+
+func _CloudProvider_NodeGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroups(ctx, req.(*NodeGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupForNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupForNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupForNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupForNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupForNode(ctx, req.(*NodeGroupForNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_PricingNodePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PricingNodePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).PricingNodePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/PricingNodePrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).PricingNodePrice(ctx, req.(*PricingNodePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_PricingPodPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PricingPodPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).PricingPodPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/PricingPodPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).PricingPodPrice(ctx, req.(*PricingPodPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_GetAvailableMachineTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailableMachineTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).GetAvailableMachineTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/GetAvailableMachineTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).GetAvailableMachineTypes(ctx, req.(*GetAvailableMachineTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Cleanup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).Cleanup(ctx, req.(*CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).Refresh(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupTargetSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupTargetSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupTargetSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupTargetSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupTargetSize(ctx, req.(*NodeGroupTargetSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupIncreaseSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupIncreaseSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupIncreaseSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupIncreaseSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupIncreaseSize(ctx, req.(*NodeGroupIncreaseSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupDeleteNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupDeleteNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupDeleteNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupDeleteNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupDeleteNodes(ctx, req.(*NodeGroupDeleteNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupDecreaseTargetSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupDecreaseTargetSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupDecreaseTargetSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupDecreaseTargetSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupDecreaseTargetSize(ctx, req.(*NodeGroupDecreaseTargetSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupNodes(ctx, req.(*NodeGroupNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupTemplateNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupTemplateNodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupTemplateNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupTemplateNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupTemplateNodeInfo(ctx, req.(*NodeGroupTemplateNodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProvider_NodeGroupGetOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeGroupGetOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServer).NodeGroupGetOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupGetOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServer).NodeGroupGetOptions(ctx, req.(*NodeGroupGetOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CloudProvider_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider",
+	HandlerType: (*CloudProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NodeGroups",
+			Handler:    _CloudProvider_NodeGroups_Handler,
+		},
+		{
+			MethodName: "NodeGroupForNode",
+			Handler:    _CloudProvider_NodeGroupForNode_Handler,
+		},
+		{
+			MethodName: "PricingNodePrice",
+			Handler:    _CloudProvider_PricingNodePrice_Handler,
+		},
+		{
+			MethodName: "PricingPodPrice",
+			Handler:    _CloudProvider_PricingPodPrice_Handler,
+		},
+		{
+			MethodName: "GetAvailableMachineTypes",
+			Handler:    _CloudProvider_GetAvailableMachineTypes_Handler,
+		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _CloudProvider_Cleanup_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _CloudProvider_Refresh_Handler,
+		},
+		{
+			MethodName: "NodeGroupTargetSize",
+			Handler:    _CloudProvider_NodeGroupTargetSize_Handler,
+		},
+		{
+			MethodName: "NodeGroupIncreaseSize",
+			Handler:    _CloudProvider_NodeGroupIncreaseSize_Handler,
+		},
+		{
+			MethodName: "NodeGroupDeleteNodes",
+			Handler:    _CloudProvider_NodeGroupDeleteNodes_Handler,
+		},
+		{
+			MethodName: "NodeGroupDecreaseTargetSize",
+			Handler:    _CloudProvider_NodeGroupDecreaseTargetSize_Handler,
+		},
+		{
+			MethodName: "NodeGroupNodes",
+			Handler:    _CloudProvider_NodeGroupNodes_Handler,
+		},
+		{
+			MethodName: "NodeGroupTemplateNodeInfo",
+			Handler:    _CloudProvider_NodeGroupTemplateNodeInfo_Handler,
+		},
+		{
+			MethodName: "NodeGroupGetOptions",
+			Handler:    _CloudProvider_NodeGroupGetOptions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cloudprovider/externalgrpc/protos/externalgrpc.proto",
+}
+
+func init() {
+	proto.RegisterFile("cloudprovider/externalgrpc/protos/externalgrpc.proto", fileDescriptor_externalgrpc_02695e4bec27b1ce)
+}
+
+var fileDescriptor_externalgrpc_02695e4bec27b1ce = []byte{
+	// 1495 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0xdb, 0xc6,
+	0x13, 0x37, 0x25, 0x2b, 0xb6, 0xc6, 0x89, 0xa3, 0x6c, 0x9c, 0x84, 0x61, 0x12, 0xc7, 0xe1, 0xff,
+	0xdf, 0xd6, 0x4d, 0x03, 0xaa, 0x71, 0x73, 0x48, 0x03, 0xf4, 0xa1, 0x48, 0xb2, 0xac, 0xc6, 0xb6,
+	0x1c, 0x46, 0x6e, 0x83, 0xa0, 0x40, 0xb0, 0x16, 0xd7, 0x32, 0x61, 0x8a, 0xcb, 0x2e, 0x29, 0x37,
+	0xce, 0x07, 0xc8, 0x31, 0xc7, 0xf6, 0x56, 0xa0, 0x28, 0xd0, 0xa2, 0x97, 0x1e, 0x0a, 0x14, 0xe8,
+	0xb5, 0x40, 0xd1, 0x43, 0xaf, 0x3d, 0xf4, 0xeb, 0x14, 0x4b, 0xae, 0x56, 0xa4, 0x1e, 0x76, 0xf5,
+	0x38, 0xf4, 0x24, 0xee, 0xec, 0xcc, 0x6f, 0x1e, 0x3b, 0x3b, 0xfc, 0x51, 0x70, 0xaf, 0xe1, 0xd0,
+	0xb6, 0xe5, 0x31, 0x7a, 0x64, 0x5b, 0x84, 0xe5, 0xc9, 0x8b, 0x80, 0x30, 0x17, 0x3b, 0x4d, 0xe6,
+	0x35, 0xf2, 0x1e, 0xa3, 0x01, 0xf5, 0x13, 0x32, 0x23, 0x94, 0xa1, 0x7c, 0xc3, 0x69, 0xfb, 0x01,
+	0x61, 0xb8, 0x1d, 0x50, 0xbf, 0x81, 0x1d, 0xc2, 0x8c, 0x04, 0x8e, 0x71, 0x74, 0xd7, 0x88, 0x9b,
+	0x69, 0xcb, 0x4d, 0x4a, 0x9b, 0x0e, 0x89, 0x20, 0xf7, 0xda, 0xfb, 0x79, 0xab, 0xcd, 0x70, 0x60,
+	0x53, 0x37, 0x02, 0xd4, 0xee, 0x1d, 0xde, 0xf7, 0x0d, 0x9b, 0xe6, 0xb1, 0x67, 0xb7, 0x70, 0xe3,
+	0xc0, 0x76, 0x09, 0x3b, 0xce, 0x7b, 0x87, 0x4d, 0x2e, 0xf0, 0xf3, 0x2d, 0x12, 0xe0, 0xfc, 0xd1,
+	0xdd, 0x7c, 0x93, 0xb8, 0x84, 0xe1, 0x80, 0x58, 0xc2, 0x4a, 0xef, 0x5a, 0xe5, 0x1b, 0x94, 0x91,
+	0x01, 0x3a, 0x3a, 0x81, 0xec, 0x36, 0xb5, 0x48, 0x85, 0xd1, 0xb6, 0x87, 0x16, 0x21, 0x65, 0x5b,
+	0xaa, 0xb2, 0xa2, 0xac, 0x66, 0xcd, 0x94, 0x6d, 0x21, 0x15, 0xe6, 0x5a, 0xb6, 0xfb, 0xc4, 0x7e,
+	0x49, 0xd4, 0xd4, 0x8a, 0xb2, 0x9a, 0x31, 0x3b, 0xcb, 0x70, 0x07, 0xbf, 0x08, 0x77, 0xd2, 0x62,
+	0x27, 0x5a, 0xa2, 0x25, 0xc8, 0x58, 0x64, 0xaf, 0xdd, 0x54, 0x67, 0x43, 0x98, 0x68, 0xa1, 0x7f,
+	0x93, 0x86, 0x5c, 0x59, 0x64, 0x5c, 0x61, 0x5e, 0x83, 0xfb, 0x44, 0xcb, 0x00, 0x9d, 0x8a, 0x54,
+	0x4b, 0xc2, 0x6d, 0x4c, 0x82, 0x10, 0xcc, 0xba, 0xb8, 0x15, 0xf9, 0xce, 0x9a, 0xe1, 0x33, 0x22,
+	0x70, 0xc6, 0xc1, 0x7b, 0xc4, 0xf1, 0xd5, 0xf4, 0x4a, 0x7a, 0x75, 0x61, 0x6d, 0xcb, 0x18, 0xb1,
+	0xd6, 0x46, 0x6f, 0x18, 0xc6, 0x66, 0x88, 0x57, 0x76, 0x03, 0x76, 0x6c, 0x0a, 0x70, 0x14, 0xc0,
+	0x02, 0x76, 0x5d, 0x1a, 0x84, 0x87, 0xe0, 0xab, 0xb3, 0xa1, 0x2f, 0x73, 0x72, 0x5f, 0x85, 0x2e,
+	0x68, 0xe4, 0x30, 0xee, 0x46, 0x7b, 0x1f, 0x16, 0x62, 0xc1, 0xa0, 0x1c, 0xa4, 0x0f, 0xc9, 0xb1,
+	0x28, 0x0c, 0x7f, 0xe4, 0xc5, 0x3d, 0xc2, 0x4e, 0xbb, 0x53, 0x92, 0x68, 0xf1, 0x20, 0x75, 0x5f,
+	0xd1, 0x3e, 0x84, 0x5c, 0x2f, 0xf6, 0x28, 0xf6, 0xfa, 0x45, 0xb8, 0x20, 0xfb, 0xc0, 0x37, 0xc9,
+	0x17, 0x6d, 0xe2, 0x07, 0xba, 0x07, 0x28, 0x2e, 0xf4, 0x3d, 0xea, 0xfa, 0x04, 0x3d, 0x03, 0x70,
+	0xa5, 0x54, 0x55, 0xc2, 0xd2, 0x3c, 0x18, 0xb9, 0x34, 0x12, 0xd8, 0x8c, 0xa1, 0xe9, 0x1e, 0x5c,
+	0x91, 0x1b, 0xeb, 0x94, 0xf1, 0x67, 0x11, 0x0c, 0xda, 0x85, 0x59, 0xae, 0x18, 0xa6, 0xb3, 0xb0,
+	0x56, 0x98, 0xf8, 0x2c, 0xcc, 0x10, 0x4e, 0x0f, 0x40, 0xed, 0xf7, 0x28, 0x32, 0x7d, 0x0a, 0x59,
+	0x19, 0x9b, 0xf0, 0x3b, 0x49, 0xa2, 0x5d, 0x30, 0xfd, 0x6f, 0x05, 0xae, 0xec, 0x30, 0xbb, 0x61,
+	0xbb, 0x4d, 0xbe, 0xcf, 0x1f, 0x65, 0xa2, 0x77, 0x12, 0x89, 0xaa, 0x46, 0x74, 0x8b, 0x0d, 0xec,
+	0xd9, 0x06, 0xbf, 0xc5, 0xdc, 0x41, 0x37, 0x7e, 0xb4, 0x01, 0x59, 0x3f, 0xc0, 0x2c, 0xa8, 0xdb,
+	0xe2, 0xa6, 0x2c, 0xac, 0xdd, 0x8e, 0x99, 0xc8, 0x71, 0x61, 0x78, 0x87, 0x4d, 0x2e, 0xf0, 0x0d,
+	0x3e, 0x2e, 0x38, 0x08, 0xb7, 0x30, 0xbb, 0xc6, 0xa8, 0x04, 0x73, 0xc4, 0xb5, 0x42, 0x9c, 0xf4,
+	0xc8, 0x38, 0x1d, 0x53, 0xfd, 0x5d, 0x50, 0xfb, 0x13, 0x13, 0xf5, 0x5c, 0x82, 0x8c, 0xc7, 0x05,
+	0x61, 0x6a, 0x8a, 0x19, 0x2d, 0xf4, 0xbf, 0x14, 0xb8, 0x2c, 0x4c, 0x76, 0xa8, 0x95, 0x28, 0xc5,
+	0xdb, 0x90, 0xf6, 0xa8, 0x25, 0x2a, 0x71, 0x65, 0x50, 0x25, 0x76, 0xa8, 0x65, 0x72, 0x9d, 0xff,
+	0x5c, 0x1d, 0xf2, 0xf2, 0x80, 0xbb, 0x49, 0x9d, 0x58, 0x86, 0x5b, 0x70, 0xb3, 0x42, 0x82, 0xc2,
+	0x11, 0xb6, 0x1d, 0xbc, 0xe7, 0x90, 0xad, 0xc8, 0x51, 0xfd, 0xd8, 0x23, 0xf2, 0x3e, 0xae, 0xc3,
+	0xca, 0x70, 0x15, 0x01, 0xae, 0xc3, 0xd9, 0x56, 0x4c, 0x1e, 0xde, 0xcf, 0xac, 0x99, 0x90, 0xe9,
+	0x39, 0x58, 0x2c, 0x3a, 0x04, 0xbb, 0x6d, 0xaf, 0x83, 0x7c, 0x01, 0xce, 0x4b, 0x49, 0x04, 0xc4,
+	0x95, 0x4c, 0xb2, 0xcf, 0x88, 0x7f, 0x10, 0x53, 0x92, 0x12, 0xa1, 0x74, 0x07, 0x34, 0xd9, 0xdf,
+	0x75, 0xcc, 0x9a, 0x24, 0xe0, 0x2f, 0x81, 0xce, 0xf1, 0xf5, 0xbc, 0x4f, 0xf4, 0x0f, 0xe0, 0xda,
+	0x40, 0x6d, 0x11, 0xfa, 0x32, 0x40, 0x20, 0xa5, 0xa1, 0x59, 0xc6, 0x8c, 0x49, 0xf4, 0x12, 0x5c,
+	0x97, 0xe6, 0x55, 0xb7, 0xc1, 0x08, 0xf6, 0x49, 0xdc, 0x5d, 0xf8, 0xea, 0x71, 0x02, 0x2c, 0x4c,
+	0xa3, 0x85, 0x08, 0x22, 0x25, 0x83, 0xb8, 0x09, 0x37, 0x86, 0xa0, 0x88, 0x9c, 0xbe, 0x55, 0xe0,
+	0x7a, 0xd5, 0xf5, 0x03, 0xec, 0x36, 0x48, 0x91, 0x91, 0x70, 0xa2, 0x96, 0x19, 0xa3, 0xac, 0x44,
+	0x02, 0x6c, 0x3b, 0x3e, 0x6a, 0x00, 0x10, 0xbe, 0x2e, 0x3a, 0xd8, 0xf7, 0x43, 0x67, 0x8b, 0x6b,
+	0xc5, 0x91, 0xe7, 0x42, 0xc7, 0x45, 0x59, 0x42, 0x99, 0x31, 0x58, 0x74, 0x1d, 0xb2, 0xd1, 0x8a,
+	0x8f, 0x82, 0x28, 0xfa, 0xae, 0x40, 0x7f, 0xa5, 0xc4, 0x4a, 0x59, 0x22, 0x0e, 0x09, 0x08, 0x5f,
+	0x76, 0x3a, 0x05, 0x7d, 0x06, 0x19, 0x3e, 0x1d, 0x3a, 0xe3, 0x79, 0x0a, 0xd3, 0x32, 0xc2, 0xeb,
+	0xab, 0xe6, 0x72, 0xec, 0x4c, 0x12, 0x71, 0x88, 0x62, 0x7e, 0x02, 0x7a, 0x6c, 0x3f, 0xaa, 0x76,
+	0x7f, 0xa3, 0xfc, 0xbb, 0x93, 0x7b, 0x03, 0xfe, 0x77, 0x22, 0x96, 0x70, 0xf9, 0x16, 0x5c, 0x92,
+	0x6a, 0x89, 0xa2, 0xf4, 0xb6, 0xa3, 0x01, 0x97, 0x7b, 0x15, 0xbb, 0x37, 0xb4, 0x5b, 0xbe, 0xac,
+	0xc8, 0x5d, 0x5f, 0x83, 0x95, 0x6e, 0xfb, 0x92, 0x96, 0xe7, 0xe0, 0x28, 0xdb, 0xaa, 0xbb, 0x4f,
+	0x87, 0xf9, 0x78, 0xa5, 0xc0, 0xad, 0x13, 0x8c, 0x84, 0xbf, 0x7b, 0x30, 0xef, 0x0a, 0xd9, 0xa9,
+	0x63, 0x5f, 0x6a, 0xa2, 0x77, 0x60, 0xd6, 0xa3, 0x96, 0xaf, 0xa6, 0xc2, 0x33, 0x1e, 0x3a, 0x1e,
+	0x43, 0x25, 0xfd, 0x8f, 0x74, 0xac, 0x63, 0x0a, 0xa2, 0x0d, 0x6c, 0xb7, 0x59, 0xf3, 0x42, 0xc6,
+	0x80, 0x4a, 0x70, 0x23, 0x6c, 0x8c, 0x12, 0xfd, 0xd2, 0xdd, 0x0d, 0x6c, 0xc7, 0x7e, 0x19, 0x36,
+	0x7e, 0xfd, 0x80, 0xdf, 0x77, 0xea, 0x58, 0x62, 0x58, 0x9d, 0xac, 0x84, 0x6a, 0x70, 0xa9, 0xab,
+	0xe0, 0xba, 0x84, 0x58, 0xc4, 0x8a, 0x4d, 0xe4, 0xab, 0x46, 0x44, 0x74, 0x8d, 0x0e, 0xd1, 0x35,
+	0x4a, 0x82, 0xe8, 0x9a, 0x83, 0xed, 0xd0, 0x16, 0x2c, 0xc5, 0x36, 0x18, 0xc1, 0xd6, 0x71, 0x6c,
+	0x32, 0x9f, 0x80, 0x37, 0xd0, 0x8c, 0xc3, 0xb5, 0xf0, 0x8b, 0xe8, 0xcd, 0x44, 0x8f, 0x6c, 0x9f,
+	0x07, 0xcf, 0xe1, 0x66, 0x4f, 0x85, 0x1b, 0x64, 0x86, 0xfe, 0x0f, 0xe7, 0x0e, 0x08, 0xb6, 0x18,
+	0xa5, 0xad, 0xb0, 0x81, 0xd4, 0x4c, 0xd8, 0xbf, 0x49, 0x61, 0x5c, 0xab, 0x48, 0x19, 0xf1, 0xd5,
+	0x33, 0x2b, 0xca, 0x6a, 0xda, 0x4c, 0x0a, 0xd1, 0x9b, 0xb0, 0xd8, 0x11, 0x6c, 0x91, 0x16, 0x65,
+	0xc7, 0xea, 0x5c, 0xa8, 0xd6, 0x23, 0xd5, 0xbf, 0x52, 0x62, 0x33, 0xb7, 0x42, 0x02, 0x71, 0x80,
+	0x43, 0x1a, 0x10, 0x1d, 0xc0, 0xbc, 0x45, 0xf6, 0x71, 0xdb, 0x09, 0x7c, 0x71, 0x08, 0x9b, 0xe3,
+	0x53, 0x98, 0xfe, 0xbe, 0x31, 0x25, 0xba, 0xfe, 0x53, 0x7c, 0x26, 0xc5, 0x03, 0x13, 0x4d, 0xfe,
+	0x5a, 0x81, 0x6b, 0xee, 0x70, 0x24, 0xd1, 0xf8, 0xd3, 0x8d, 0xee, 0x24, 0x87, 0xb7, 0x3f, 0x07,
+	0xd4, 0x3f, 0x84, 0x51, 0x16, 0x32, 0xb5, 0xfa, 0x46, 0xd9, 0xcc, 0xcd, 0x20, 0x04, 0x8b, 0x8f,
+	0x77, 0x6b, 0xf5, 0xc2, 0xf3, 0xf2, 0xd3, 0x62, 0xb9, 0x5c, 0x2a, 0x97, 0x72, 0x0a, 0x3a, 0x0b,
+	0xf3, 0x4f, 0xea, 0xb5, 0xe2, 0xa3, 0xda, 0x6e, 0x3d, 0x97, 0x42, 0x57, 0xe1, 0x52, 0x75, 0xfb,
+	0xd3, 0xc2, 0x66, 0xb5, 0xf4, 0xbc, 0x58, 0xdb, 0x5e, 0xaf, 0x56, 0x76, 0xcd, 0x42, 0xbd, 0x5a,
+	0xdb, 0xce, 0xa5, 0xd7, 0x7e, 0xb8, 0x08, 0xe7, 0x8a, 0x3c, 0xf0, 0x1d, 0x11, 0x38, 0xfa, 0x5a,
+	0x01, 0xe8, 0xf2, 0x69, 0xf4, 0x70, 0xfc, 0x4c, 0x3b, 0xa7, 0xad, 0x15, 0x27, 0xc2, 0x10, 0x03,
+	0x73, 0x06, 0xfd, 0xa8, 0x40, 0xae, 0x97, 0x05, 0xa3, 0x8d, 0xf1, 0xb1, 0x93, 0xd4, 0x5d, 0xab,
+	0x4e, 0x01, 0x29, 0x11, 0x6b, 0x2f, 0xc3, 0x1c, 0x23, 0xd6, 0x21, 0xec, 0x7b, 0x8c, 0x58, 0x87,
+	0xd1, 0x5d, 0x7d, 0x06, 0x7d, 0xaf, 0xc0, 0xf9, 0x1e, 0x16, 0x88, 0x2a, 0xe3, 0x3a, 0xe8, 0x21,
+	0xc7, 0xda, 0xc6, 0xe4, 0x40, 0x32, 0xd0, 0xdf, 0x14, 0x50, 0x87, 0x51, 0x4b, 0xb4, 0x33, 0xb2,
+	0xa3, 0x53, 0x88, 0xac, 0xf6, 0x78, 0x8a, 0x88, 0x32, 0x87, 0xd7, 0x0a, 0xcc, 0x09, 0x12, 0x8b,
+	0x3e, 0x1a, 0xd9, 0x41, 0x92, 0x10, 0x6b, 0x1f, 0x8f, 0x0f, 0x90, 0x08, 0x48, 0x10, 0xe6, 0x31,
+	0x02, 0x4a, 0x92, 0xef, 0x31, 0x02, 0xea, 0xe5, 0xea, 0x33, 0xe8, 0x67, 0x05, 0x2e, 0x0e, 0x20,
+	0xe0, 0xe8, 0xd1, 0xf8, 0xf7, 0xb3, 0x8f, 0xcb, 0x69, 0x9b, 0xd3, 0x01, 0x93, 0x41, 0xff, 0xaa,
+	0xc4, 0xf8, 0x5c, 0x9c, 0xb0, 0xa3, 0xad, 0xf1, 0x3d, 0x0d, 0xf8, 0x7c, 0xd0, 0xb6, 0xa7, 0x05,
+	0x27, 0x43, 0xff, 0x45, 0x81, 0xa5, 0x41, 0xec, 0x18, 0x4d, 0x50, 0xa3, 0x7e, 0xb2, 0xaf, 0x6d,
+	0x4d, 0x09, 0x4d, 0xc6, 0xfd, 0x67, 0xf2, 0xeb, 0xa2, 0x97, 0x69, 0xa3, 0x27, 0x93, 0x38, 0x1c,
+	0xf2, 0x0d, 0xa0, 0xd5, 0xa7, 0x0b, 0x2a, 0x93, 0xf9, 0x4e, 0x81, 0xc5, 0x24, 0xcd, 0x47, 0xeb,
+	0xe3, 0xbb, 0x4a, 0x14, 0xbe, 0x32, 0x31, 0x8e, 0x8c, 0xf2, 0x77, 0x05, 0xae, 0x0e, 0xfd, 0x4e,
+	0x40, 0x8f, 0x27, 0xb8, 0x53, 0x83, 0x3f, 0x54, 0x34, 0x73, 0x9a, 0x90, 0x83, 0x27, 0x4c, 0x97,
+	0x03, 0x4e, 0x32, 0x61, 0xfa, 0x28, 0xee, 0x24, 0x13, 0xa6, 0x9f, 0x96, 0xea, 0x33, 0x0f, 0xe7,
+	0x9f, 0x9d, 0x89, 0xfe, 0xcb, 0xdf, 0x8b, 0x7e, 0xdf, 0xfb, 0x27, 0x00, 0x00, 0xff, 0xff, 0xf6,
+	0xb0, 0x77, 0xa9, 0xf7, 0x17, 0x00, 0x00,
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protos
+
+// Messages embedding Kubernetes objects are encoded by hand, see the grpccodec package.
+
+import (
+	"reflect"
+
+	v11 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+)
+
+// Marshal encodes the request in the protobuf wire format.
+func (m *PricingNodePriceRequest) Marshal() ([]byte, error) {
+	return marshalMessages([]marshaler{m.Node, m.StartTime, m.EndTime})
+}
+
+// Unmarshal decodes the request from the protobuf wire format.
+func (m *PricingNodePriceRequest) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		switch field {
+		case 1:
+			m.Node = &v11.Node{}
+			return m.Node.Unmarshal(value)
+		case 2:
+			m.StartTime = &v1.Time{}
+			return m.StartTime.Unmarshal(value)
+		case 3:
+			m.EndTime = &v1.Time{}
+			return m.EndTime.Unmarshal(value)
+		}
+		return nil
+	}, nil)
+}
+
+// Marshal encodes the request in the protobuf wire format.
+func (m *PricingPodPriceRequest) Marshal() ([]byte, error) {
+	return marshalMessages([]marshaler{m.Pod, m.StartTime, m.EndTime})
+}
+
+// Unmarshal decodes the request from the protobuf wire format.
+func (m *PricingPodPriceRequest) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		switch field {
+		case 1:
+			m.Pod = &v11.Pod{}
+			return m.Pod.Unmarshal(value)
+		case 2:
+			m.StartTime = &v1.Time{}
+			return m.StartTime.Unmarshal(value)
+		case 3:
+			m.EndTime = &v1.Time{}
+			return m.EndTime.Unmarshal(value)
+		}
+		return nil
+	}, nil)
+}
+
+// Marshal encodes the response in the protobuf wire format.
+func (m *NodeGroupTemplateNodeInfoResponse) Marshal() ([]byte, error) {
+	data, err := marshalMessages([]marshaler{m.NodeInfo})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range m.Pods {
+		encoded, err := pod.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		data = grpccodec.AppendBytesField(data, 2, encoded)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the response from the protobuf wire format.
+func (m *NodeGroupTemplateNodeInfoResponse) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		switch field {
+		case 1:
+			m.NodeInfo = &v11.Node{}
+			return m.NodeInfo.Unmarshal(value)
+		case 2:
+			pod := &v11.Pod{}
+			if err := pod.Unmarshal(value); err != nil {
+				return err
+			}
+			m.Pods = append(m.Pods, pod)
+		}
+		return nil
+	}, nil)
+}
+
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// marshalMessages encodes the given messages as fields numbered from 1 in the given order. Nil messages are skipped.
+func marshalMessages(messages []marshaler) ([]byte, error) {
+	var data []byte
+	for i, message := range messages {
+		if message == nil || reflect.ValueOf(message).IsNil() {
+			continue
+		}
+		encoded, err := message.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		data = grpccodec.AppendBytesField(data, uint64(i+1), encoded)
+	}
+	return data, nil
+}
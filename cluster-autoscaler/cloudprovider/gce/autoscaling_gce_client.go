@@ -27,6 +27,7 @@ import (
 
 	"github.com/golang/glog"
 	gce "google.golang.org/api/compute/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 )
 
 const (
@@ -34,6 +35,15 @@ const (
 	defaultOperationPollInterval = 100 * time.Millisecond
 )
 
+// instanceErrorClasses maps the codes of errors reported for instances that failed to be created,
+// or for resize operations that failed, to error classes.
+var instanceErrorClasses = map[string]cloudprovider.InstanceErrorClass{
+	"QUOTA_EXCEEDED":                            cloudprovider.QuotaExceededErrorClass,
+	"RESOURCE_POOL_EXHAUSTED":                   cloudprovider.StockoutErrorClass,
+	"ZONE_RESOURCE_POOL_EXHAUSTED":              cloudprovider.StockoutErrorClass,
+	"ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS": cloudprovider.StockoutErrorClass,
+}
+
 // AutoscalingGceClient is used for communicating with GCE API.
 type AutoscalingGceClient interface {
 	// reading resources
@@ -41,7 +51,7 @@ type AutoscalingGceClient interface {
 	FetchMachineTypes(zone string) ([]*gce.MachineType, error)
 	FetchMigTargetSize(GceRef) (int64, error)
 	FetchMigBasename(GceRef) (string, error)
-	FetchMigInstances(GceRef) ([]GceRef, error)
+	FetchMigInstances(GceRef) ([]cloudprovider.Instance, error)
 	FetchMigTemplate(GceRef) (*gce.InstanceTemplate, error)
 	FetchMigsWithName(zone string, filter *regexp.Regexp) ([]string, error)
 	FetchZones(region string) ([]string, error)
@@ -127,22 +137,45 @@ func (client *autoscalingGceClientV1) ResizeMig(migRef GceRef, size int64) error
 	if err != nil {
 		return err
 	}
-	return client.waitForOp(op, migRef.Project, migRef.Zone)
+	done, err := client.waitForDoneOp(op, migRef.Project, migRef.Zone)
+	if err != nil {
+		return err
+	}
+	return resizeError(done)
+}
+
+// resizeError returns the errors of a done resize operation, as an InstanceCreationError if the
+// class of the first one is known.
+func resizeError(op *gce.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+	first := op.Error.Errors[0]
+	if errorClass, found := instanceErrorClasses[first.Code]; found {
+		return cloudprovider.NewInstanceCreationError(errorClass, first.Code, "%s", first.Message)
+	}
+	return fmt.Errorf("resize operation %s failed: %s %s", op.Name, first.Code, first.Message)
 }
 
 func (client *autoscalingGceClientV1) waitForOp(operation *gce.Operation, project, zone string) error {
+	_, err := client.waitForDoneOp(operation, project, zone)
+	return err
+}
+
+// waitForDoneOp waits for the operation to be done and returns its final state.
+func (client *autoscalingGceClientV1) waitForDoneOp(operation *gce.Operation, project, zone string) (*gce.Operation, error) {
 	for start := time.Now(); time.Since(start) < client.operationWaitTimeout; time.Sleep(client.operationPollInterval) {
 		glog.V(4).Infof("Waiting for operation %s %s %s", project, zone, operation.Name)
 		if op, err := client.gceService.ZoneOperations.Get(project, zone, operation.Name).Do(); err == nil {
 			glog.V(4).Infof("Operation %s %s %s status: %s", project, zone, operation.Name, op.Status)
 			if op.Status == "DONE" {
-				return nil
+				return op, nil
 			}
 		} else {
 			glog.Warningf("Error while getting operation %s on %s: %v", operation.Name, operation.TargetLink, err)
 		}
 	}
-	return fmt.Errorf("Timeout while waiting for operation %s on %s to complete.", operation.Name, operation.TargetLink)
+	return nil, fmt.Errorf("Timeout while waiting for operation %s on %s to complete.", operation.Name, operation.TargetLink)
 }
 
 func (client *autoscalingGceClientV1) DeleteInstances(migRef GceRef, instances []*GceRef) error {
@@ -159,21 +192,52 @@ func (client *autoscalingGceClientV1) DeleteInstances(migRef GceRef, instances [
 	return client.waitForOp(op, migRef.Project, migRef.Zone)
 }
 
-func (client *autoscalingGceClientV1) FetchMigInstances(migRef GceRef) ([]GceRef, error) {
+func (client *autoscalingGceClientV1) FetchMigInstances(migRef GceRef) ([]cloudprovider.Instance, error) {
 	instances, err := client.gceService.InstanceGroupManagers.ListManagedInstances(migRef.Project, migRef.Zone, migRef.Name).Do()
 	if err != nil {
 		glog.V(4).Infof("Failed MIG info request for %s %s %s: %v", migRef.Project, migRef.Zone, migRef.Name, err)
 		return nil, err
 	}
-	refs := []GceRef{}
+	infos := []cloudprovider.Instance{}
 	for _, i := range instances.ManagedInstances {
 		ref, err := ParseInstanceUrlRef(i.Instance)
 		if err != nil {
 			return nil, err
 		}
-		refs = append(refs, ref)
+		infos = append(infos, cloudprovider.Instance{
+			Id:     fmt.Sprintf("gce://%s/%s/%s", ref.Project, ref.Zone, ref.Name),
+			Status: instanceStatusFromManagedInstance(i),
+		})
+	}
+	return infos, nil
+}
+
+// instanceStatusFromManagedInstance derives the status of an instance from the action the MIG
+// performs on it and from the errors of the last attempt to create it.
+func instanceStatusFromManagedInstance(instance *gce.ManagedInstance) *cloudprovider.InstanceStatus {
+	status := &cloudprovider.InstanceStatus{}
+	switch instance.CurrentAction {
+	case "CREATING", "CREATING_WITHOUT_RETRIES", "RECREATING":
+		status.State = cloudprovider.InstanceCreating
+	case "ABANDONING", "DELETING":
+		status.State = cloudprovider.InstanceDeleting
+	default:
+		status.State = cloudprovider.InstanceRunning
+	}
+	if status.State == cloudprovider.InstanceCreating && instance.LastAttempt != nil &&
+		instance.LastAttempt.Errors != nil && len(instance.LastAttempt.Errors.Errors) > 0 {
+		lastError := instance.LastAttempt.Errors.Errors[0]
+		errorClass, found := instanceErrorClasses[lastError.Code]
+		if !found {
+			errorClass = cloudprovider.OtherErrorClass
+		}
+		status.ErrorInfo = &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   errorClass,
+			ErrorCode:    lastError.Code,
+			ErrorMessage: lastError.Message,
+		}
 	}
-	return refs, nil
+	return status
 }
 
 func (client *autoscalingGceClientV1) FetchZones(region string) ([]string, error) {
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	test_util "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 
 	"github.com/stretchr/testify/assert"
@@ -97,3 +98,63 @@ func TestWaitForOpTimeout(t *testing.T) {
 	err := g.waitForOp(operation, projectId, zoneB)
 	assert.Error(t, err)
 }
+
+func TestResizeError(t *testing.T) {
+	assert.NoError(t, resizeError(&gce_api.Operation{Status: "DONE"}))
+
+	err := resizeError(&gce_api.Operation{Error: &gce_api.OperationError{Errors: []*gce_api.OperationErrorErrors{
+		{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "no capacity"},
+	}}})
+	assert.Equal(t, cloudprovider.StockoutErrorClass, cloudprovider.GetInstanceErrorClass(err))
+
+	err = resizeError(&gce_api.Operation{Error: &gce_api.OperationError{Errors: []*gce_api.OperationErrorErrors{
+		{Code: "QUOTA_EXCEEDED", Message: "CPUS quota exceeded"},
+	}}})
+	assert.Equal(t, cloudprovider.QuotaExceededErrorClass, cloudprovider.GetInstanceErrorClass(err))
+
+	err = resizeError(&gce_api.Operation{Error: &gce_api.OperationError{Errors: []*gce_api.OperationErrorErrors{
+		{Code: "INTERNAL_ERROR"},
+	}}})
+	assert.Error(t, err)
+	assert.Equal(t, cloudprovider.OtherErrorClass, cloudprovider.GetInstanceErrorClass(err))
+}
+
+func TestInstanceStatusFromManagedInstance(t *testing.T) {
+	status := instanceStatusFromManagedInstance(&gce_api.ManagedInstance{CurrentAction: "NONE"})
+	assert.Equal(t, &cloudprovider.InstanceStatus{State: cloudprovider.InstanceRunning}, status)
+
+	status = instanceStatusFromManagedInstance(&gce_api.ManagedInstance{CurrentAction: "DELETING"})
+	assert.Equal(t, &cloudprovider.InstanceStatus{State: cloudprovider.InstanceDeleting}, status)
+
+	status = instanceStatusFromManagedInstance(&gce_api.ManagedInstance{CurrentAction: "CREATING"})
+	assert.Equal(t, &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating}, status)
+
+	status = instanceStatusFromManagedInstance(&gce_api.ManagedInstance{
+		CurrentAction: "CREATING",
+		LastAttempt: &gce_api.ManagedInstanceLastAttempt{
+			Errors: &gce_api.ManagedInstanceLastAttemptErrors{
+				Errors: []*gce_api.ManagedInstanceLastAttemptErrorsErrors{
+					{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "The zone does not have enough resources"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, &cloudprovider.InstanceStatus{
+		State: cloudprovider.InstanceCreating,
+		ErrorInfo: &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.StockoutErrorClass,
+			ErrorCode:    "ZONE_RESOURCE_POOL_EXHAUSTED",
+			ErrorMessage: "The zone does not have enough resources",
+		},
+	}, status)
+
+	status = instanceStatusFromManagedInstance(&gce_api.ManagedInstance{
+		CurrentAction: "CREATING_WITHOUT_RETRIES",
+		LastAttempt: &gce_api.ManagedInstanceLastAttempt{
+			Errors: &gce_api.ManagedInstanceLastAttemptErrors{
+				Errors: []*gce_api.ManagedInstanceLastAttemptErrorsErrors{{Code: "UNKNOWN"}},
+			},
+		},
+	})
+	assert.Equal(t, cloudprovider.OtherErrorClass, status.ErrorInfo.ErrorClass)
+}
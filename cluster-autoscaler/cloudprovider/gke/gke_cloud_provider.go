@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
@@ -355,7 +356,7 @@ func (mig *GkeMig) Debug() string {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (mig *GkeMig) Nodes() ([]string, error) {
+func (mig *GkeMig) Nodes() ([]cloudprovider.Instance, error) {
 	return mig.gkeManager.GetMigNodes(mig)
 }
 
@@ -387,6 +388,12 @@ func (mig *GkeMig) Autoprovisioned() bool {
 	return mig.autoprovisioned
 }
 
+// GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
+// NodeGroup. Returning a nil will result in using default options.
+func (mig *GkeMig) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
 // TemplateNodeInfo returns a node template for this node group.
 func (mig *GkeMig) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
 	node, err := mig.gkeManager.GetMigTemplateNode(mig)
@@ -61,9 +61,9 @@ func (m *gkeManagerMock) GetMigForInstance(instance *gce.GceRef) (gce.Mig, error
 	return args.Get(0).(*GkeMig), args.Error(1)
 }
 
-func (m *gkeManagerMock) GetMigNodes(mig gce.Mig) ([]string, error) {
+func (m *gkeManagerMock) GetMigNodes(mig gce.Mig) ([]cloudprovider.Instance, error) {
 	args := m.Called(mig)
-	return args.Get(0).([]string), args.Error(1)
+	return args.Get(0).([]cloudprovider.Instance), args.Error(1)
 }
 
 func (m *gkeManagerMock) Refresh() error {
@@ -333,8 +333,8 @@ func TestMig(t *testing.T) {
 	// Test DecreaseTargetSize.
 	gkeManagerMock.On("GetMigSize", mock.AnythingOfType("*gke.GkeMig")).Return(int64(3), nil).Once()
 	gkeManagerMock.On("GetMigNodes", mock.AnythingOfType("*gke.GkeMig")).Return(
-		[]string{"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g",
-			"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}, nil).Once()
+		[]cloudprovider.Instance{{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g"},
+			{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}}, nil).Once()
 	gkeManagerMock.On("SetMigSize", mock.AnythingOfType("*gke.GkeMig"), int64(2)).Return(nil).Once()
 	err = mig1.DecreaseTargetSize(-1)
 	assert.NoError(t, err)
@@ -348,8 +348,8 @@ func TestMig(t *testing.T) {
 	// Test DecreaseTargetSize - fail on deleting existing nodes.
 	gkeManagerMock.On("GetMigSize", mock.AnythingOfType("*gke.GkeMig")).Return(int64(3), nil).Once()
 	gkeManagerMock.On("GetMigNodes", mock.AnythingOfType("*gke.GkeMig")).Return(
-		[]string{"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g",
-			"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}, nil).Once()
+		[]cloudprovider.Instance{{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g"},
+			{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}}, nil).Once()
 
 	err = mig1.DecreaseTargetSize(-2)
 	assert.Error(t, err)
@@ -411,12 +411,12 @@ func TestMig(t *testing.T) {
 
 	// Test Nodes.
 	gkeManagerMock.On("GetMigNodes", mock.AnythingOfType("*gke.GkeMig")).Return(
-		[]string{"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g",
-			"gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}, nil).Once()
+		[]cloudprovider.Instance{{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g"},
+			{Id: "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1"}}, nil).Once()
 	nodes, err := mig1.Nodes()
 	assert.NoError(t, err)
-	assert.Equal(t, "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g", nodes[0])
-	assert.Equal(t, "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1", nodes[1])
+	assert.Equal(t, "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-9j4g", nodes[0].Id)
+	assert.Equal(t, "gce://project1/us-central1-b/gke-cluster-1-default-pool-f7607aac-dck1", nodes[1].Id)
 	mock.AssertExpectationsForObjects(t, gkeManagerMock)
 
 	// Test Create.
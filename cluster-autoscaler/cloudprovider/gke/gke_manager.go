@@ -92,7 +92,7 @@ type GkeManager interface {
 	// GetMigForInstance returns MigConfig of the given Instance
 	GetMigForInstance(instance *gce.GceRef) (gce.Mig, error)
 	// GetMigNodes returns mig nodes.
-	GetMigNodes(mig gce.Mig) ([]string, error)
+	GetMigNodes(mig gce.Mig) ([]cloudprovider.Instance, error)
 	// Refresh updates config by calling GKE API (in GKE mode only).
 	Refresh() error
 	// GetResourceLimiter returns resource limiter.
@@ -419,16 +419,8 @@ func (m *gkeManagerImpl) GetMigForInstance(instance *gce.GceRef) (gce.Mig, error
 }
 
 // GetMigNodes returns mig nodes.
-func (m *gkeManagerImpl) GetMigNodes(mig gce.Mig) ([]string, error) {
-	instances, err := m.GceService.FetchMigInstances(mig.GceRef())
-	if err != nil {
-		return []string{}, err
-	}
-	result := make([]string, 0)
-	for _, ref := range instances {
-		result = append(result, fmt.Sprintf("gce://%s/%s/%s", ref.Project, ref.Zone, ref.Name))
-	}
-	return result, nil
+func (m *gkeManagerImpl) GetMigNodes(mig gce.Mig) ([]cloudprovider.Instance, error) {
+	return m.GceService.FetchMigInstances(mig.GceRef())
 }
 
 func (m *gkeManagerImpl) GetLocation() string {
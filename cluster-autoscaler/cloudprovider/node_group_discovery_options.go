@@ -29,11 +29,15 @@ const (
 	autoDiscovererTypeASG   = "asg"
 	autoDiscovererTypeLabel = "label"
 
+	autoDiscovererTypeClusterAPI = "clusterapi"
+
 	migAutoDiscovererKeyPrefix   = "namePrefix"
 	migAutoDiscovererKeyMinNodes = "min"
 	migAutoDiscovererKeyMaxNodes = "max"
 
 	asgAutoDiscovererKeyTag = "tag"
+
+	clusterAPIAutoDiscovererKeyNamespace = "namespace"
 )
 
 var validMIGAutoDiscovererKeys = strings.Join([]string{
@@ -108,6 +112,20 @@ func (o NodeGroupDiscoveryOptions) ParseLabelAutoDiscoverySpecs() ([]LabelAutoDi
 	return cfgs, nil
 }
 
+// ParseClusterAPIAutoDiscoverySpecs returns any provided NodeGroupAutoDiscoverySpecs
+// parsed into configuration appropriate for cluster-api autodiscovery.
+func (o NodeGroupDiscoveryOptions) ParseClusterAPIAutoDiscoverySpecs() ([]ClusterAPIAutoDiscoveryConfig, error) {
+	cfgs := make([]ClusterAPIAutoDiscoveryConfig, len(o.NodeGroupAutoDiscoverySpecs))
+	var err error
+	for i, spec := range o.NodeGroupAutoDiscoverySpecs {
+		cfgs[i], err = parseClusterAPIAutoDiscoverySpec(spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfgs, nil
+}
+
 // A MIGAutoDiscoveryConfig specifies how to autodiscover GCE MIGs.
 type MIGAutoDiscoveryConfig struct {
 	// Re is a regexp passed using the eq filter to the GCE list API.
@@ -245,3 +263,38 @@ func parseLabelAutoDiscoverySpec(spec string) (LabelAutoDiscoveryConfig, error)
 
 	return cfg, nil
 }
+
+// A ClusterAPIAutoDiscoveryConfig specifies how to autodiscover cluster-api MachineSets
+// and MachineDeployments.
+type ClusterAPIAutoDiscoveryConfig struct {
+	// Namespace to watch for node groups.
+	Namespace string
+}
+
+func parseClusterAPIAutoDiscoverySpec(spec string) (ClusterAPIAutoDiscoveryConfig, error) {
+	cfg := ClusterAPIAutoDiscoveryConfig{}
+
+	tokens := strings.Split(spec, ":")
+	if len(tokens) != 2 {
+		return cfg, fmt.Errorf("spec \"%s\" should be discoverer:key=value", spec)
+	}
+	discoverer := tokens[0]
+	if discoverer != autoDiscovererTypeClusterAPI {
+		return cfg, fmt.Errorf("unsupported discoverer specified: %s", discoverer)
+	}
+
+	kv := strings.Split(tokens[1], "=")
+	if len(kv) != 2 {
+		return cfg, fmt.Errorf("invalid key=value pair %s", kv)
+	}
+	k, v := kv[0], kv[1]
+	if k != clusterAPIAutoDiscovererKeyNamespace {
+		return cfg, fmt.Errorf("unsupported key \"%s\" is specified for discoverer \"%s\". The only supported key is \"%s\"", k, discoverer, clusterAPIAutoDiscovererKeyNamespace)
+	}
+	if v == "" {
+		return cfg, errors.New("namespace not supplied")
+	}
+	cfg.Namespace = v
+
+	return cfg, nil
+}
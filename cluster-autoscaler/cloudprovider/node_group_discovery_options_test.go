@@ -187,3 +187,54 @@ func TestParseASGAutoDiscoverySpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseClusterAPIAutoDiscoverySpecs(t *testing.T) {
+	cases := []struct {
+		name    string
+		specs   []string
+		want    []ClusterAPIAutoDiscoveryConfig
+		wantErr bool
+	}{
+		{
+			name:  "GoodSpecs",
+			specs: []string{"clusterapi:namespace=foo", "clusterapi:namespace=bar"},
+			want: []ClusterAPIAutoDiscoveryConfig{
+				{Namespace: "foo"},
+				{Namespace: "bar"},
+			},
+		},
+		{
+			name:    "WrongType",
+			specs:   []string{"asg:namespace=foo"},
+			wantErr: true,
+		},
+		{
+			name:    "UnsupportedKey",
+			specs:   []string{"clusterapi:tag=foo"},
+			wantErr: true,
+		},
+		{
+			name:    "KeyMissingValue",
+			specs:   []string{"clusterapi:namespace="},
+			wantErr: true,
+		},
+		{
+			name:    "KeyMissingSeparator",
+			specs:   []string{"clusterapi:namespace"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			do := NodeGroupDiscoveryOptions{NodeGroupAutoDiscoverySpecs: tc.specs}
+			got, err := do.ParseClusterAPIAutoDiscoverySpecs()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
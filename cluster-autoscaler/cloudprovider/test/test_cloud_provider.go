@@ -23,6 +23,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
 )
@@ -44,6 +45,7 @@ type OnNodeGroupDeleteFunc func(string) error
 type TestCloudProvider struct {
 	sync.Mutex
 	nodes             map[string]string
+	instanceStatuses  map[string]*cloudprovider.InstanceStatus
 	groups            map[string]cloudprovider.NodeGroup
 	onScaleUp         func(string, int) error
 	onScaleDown       func(string, string) error
@@ -57,11 +59,12 @@ type TestCloudProvider struct {
 // NewTestCloudProvider builds new TestCloudProvider
 func NewTestCloudProvider(onScaleUp OnScaleUpFunc, onScaleDown OnScaleDownFunc) *TestCloudProvider {
 	return &TestCloudProvider{
-		nodes:           make(map[string]string),
-		groups:          make(map[string]cloudprovider.NodeGroup),
-		onScaleUp:       onScaleUp,
-		onScaleDown:     onScaleDown,
-		resourceLimiter: cloudprovider.NewResourceLimiter(make(map[string]int64), make(map[string]int64)),
+		nodes:            make(map[string]string),
+		instanceStatuses: make(map[string]*cloudprovider.InstanceStatus),
+		groups:           make(map[string]cloudprovider.NodeGroup),
+		onScaleUp:        onScaleUp,
+		onScaleDown:      onScaleDown,
+		resourceLimiter:  cloudprovider.NewResourceLimiter(make(map[string]int64), make(map[string]int64)),
 	}
 }
 
@@ -71,6 +74,7 @@ func NewTestAutoprovisioningCloudProvider(onScaleUp OnScaleUpFunc, onScaleDown O
 	machineTypes []string, machineTemplates map[string]*schedulercache.NodeInfo) *TestCloudProvider {
 	return &TestCloudProvider{
 		nodes:             make(map[string]string),
+		instanceStatuses:  make(map[string]*cloudprovider.InstanceStatus),
 		groups:            make(map[string]cloudprovider.NodeGroup),
 		onScaleUp:         onScaleUp,
 		onScaleDown:       onScaleDown,
@@ -168,6 +172,23 @@ func (tcp *TestCloudProvider) AddNodeGroup(id string, min int, max int, size int
 	}
 }
 
+// AddNodeGroupWithCustomOptions adds node group with the given autoscaling options to test cloud provider.
+func (tcp *TestCloudProvider) AddNodeGroupWithCustomOptions(id string, min int, max int, size int, opts *config.NodeGroupAutoscalingOptions) {
+	tcp.Lock()
+	defer tcp.Unlock()
+
+	tcp.groups[id] = &TestNodeGroup{
+		cloudProvider:   tcp,
+		id:              id,
+		minSize:         min,
+		maxSize:         max,
+		targetSize:      size,
+		exist:           true,
+		autoprovisioned: false,
+		opts:            opts,
+	}
+}
+
 // AddAutoprovisionedNodeGroup adds node group to test cloud provider.
 func (tcp *TestCloudProvider) AddAutoprovisionedNodeGroup(id string, min int, max int, size int, machineType string) *TestNodeGroup {
 	tcp.Lock()
@@ -193,6 +214,13 @@ func (tcp *TestCloudProvider) AddNode(nodeGroupId string, node *apiv1.Node) {
 	tcp.nodes[node.Name] = nodeGroupId
 }
 
+// SetInstanceStatus sets the status of the instance of the given node, as returned by NodeGroup.Nodes().
+func (tcp *TestCloudProvider) SetInstanceStatus(nodeName string, status *cloudprovider.InstanceStatus) {
+	tcp.Lock()
+	defer tcp.Unlock()
+	tcp.instanceStatuses[nodeName] = status
+}
+
 // GetResourceLimiter returns struct containing limits (max, min) for resources (cores, memory etc.).
 func (tcp *TestCloudProvider) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
 	return tcp.resourceLimiter, nil
@@ -227,6 +255,7 @@ type TestNodeGroup struct {
 	machineType     string
 	labels          map[string]string
 	taints          []apiv1.Taint
+	opts            *config.NodeGroupAutoscalingOptions
 }
 
 // MaxSize returns maximum size of the node group.
@@ -342,14 +371,14 @@ func (tng *TestNodeGroup) Debug() string {
 }
 
 // Nodes returns a list of all nodes that belong to this node group.
-func (tng *TestNodeGroup) Nodes() ([]string, error) {
+func (tng *TestNodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 	tng.Lock()
 	defer tng.Unlock()
 
-	result := make([]string, 0)
+	result := make([]cloudprovider.Instance, 0)
 	for node, nodegroup := range tng.cloudProvider.nodes {
 		if nodegroup == tng.id {
-			result = append(result, node)
+			result = append(result, cloudprovider.Instance{Id: node, Status: tng.cloudProvider.instanceStatuses[node]})
 		}
 	}
 	return result, nil
@@ -379,6 +408,17 @@ func (tng *TestNodeGroup) TemplateNodeInfo() (*schedulercache.NodeInfo, error) {
 	return template, nil
 }
 
+// GetOptions returns the autoscaling options passed to the test node group when it was created.
+func (tng *TestNodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
+	tng.Lock()
+	defer tng.Unlock()
+
+	if tng.opts == nil {
+		return nil, cloudprovider.ErrNotImplemented
+	}
+	return tng.opts, nil
+}
+
 // Labels returns labels passed to the test node group when it was created.
 func (tng *TestNodeGroup) Labels() map[string]string {
 	return tng.labels
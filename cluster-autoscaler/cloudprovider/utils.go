@@ -19,12 +19,16 @@ package cloudprovider
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
+
+	"github.com/golang/glog"
 )
 
 const (
@@ -123,3 +127,20 @@ func JoinStringMaps(items ...map[string]string) map[string]string {
 	}
 	return result
 }
+
+// GetNodeGroupOptions returns the autoscaling options of the given node group, or the defaults if the node
+// group is nil, doesn't override them or fails to return them.
+func GetNodeGroupOptions(nodeGroup NodeGroup, defaults config.NodeGroupAutoscalingOptions) config.NodeGroupAutoscalingOptions {
+	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return defaults
+	}
+	options, err := nodeGroup.GetOptions(defaults)
+	if err != nil && err != ErrNotImplemented {
+		glog.Warningf("Failed to get autoscaling options of node group %s, using defaults: %v", nodeGroup.Id(), err)
+		return defaults
+	}
+	if options == nil {
+		return defaults
+	}
+	return *options
+}
@@ -54,6 +54,8 @@ const (
 	ClusterAutoscalerCandidatesPresent ClusterAutoscalerConditionStatus = "CandidatesPresent"
 	//ClusterAutoscalerNoCandidates status means that there are no candidates for scale down.
 	ClusterAutoscalerNoCandidates ClusterAutoscalerConditionStatus = "NoCandidates"
+	// ClusterAutoscalerBlockedBySchedule status means that scale down is blocked by a blackout window.
+	ClusterAutoscalerBlockedBySchedule ClusterAutoscalerConditionStatus = "BlockedBySchedule"
 
 	// Statuses for ScaleUp condition type.
 
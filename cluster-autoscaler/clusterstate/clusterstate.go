@@ -19,12 +19,14 @@ package clusterstate
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/deletetaint"
@@ -53,8 +55,47 @@ const (
 
 	// NodeGroupBackoffResetTimeout is the time after last failed scale-up when the backoff duration is reset.
 	NodeGroupBackoffResetTimeout = 3 * time.Hour
+
+	// InitialQuotaExceededBackoffDuration is the duration of first backoff after a scale-up failed on a quota.
+	// Quotas are rarely raised within minutes, so there is no point in retrying early.
+	InitialQuotaExceededBackoffDuration = 30 * time.Minute
+
+	// MaxQuotaExceededBackoffDuration is the maximum backoff duration after scale-ups failed on a quota.
+	MaxQuotaExceededBackoffDuration = 2 * time.Hour
+
+	// InitialInvalidConfigurationBackoffDuration is the duration of first backoff after instances couldn't be
+	// created from the node group template. The template has to be fixed by hand.
+	InitialInvalidConfigurationBackoffDuration = time.Hour
+
+	// MaxInvalidConfigurationBackoffDuration is the maximum backoff duration after instances couldn't be
+	// created from the node group template.
+	MaxInvalidConfigurationBackoffDuration = 2 * time.Hour
 )
 
+// backoffDurations are the initial and maximum backoff durations of a node group.
+type backoffDurations struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+// nodeGroupBackoffDurations holds the backoff durations for the classes of instance creation errors
+// that don't use the default ones. Stockouts use the default ones, as capacity often comes back
+// quickly and scale-up falls back to other node groups in the meantime.
+var nodeGroupBackoffDurations = map[cloudprovider.InstanceErrorClass]backoffDurations{
+	cloudprovider.QuotaExceededErrorClass:        {InitialQuotaExceededBackoffDuration, MaxQuotaExceededBackoffDuration},
+	cloudprovider.InvalidConfigurationErrorClass: {InitialInvalidConfigurationBackoffDuration, MaxInvalidConfigurationBackoffDuration},
+}
+
+// failedScaleUpReasons maps the classes of instance creation errors to the reasons of failed scale-ups
+// reported in metrics.
+var failedScaleUpReasons = map[cloudprovider.InstanceErrorClass]metrics.FailedScaleUpReason{
+	cloudprovider.QuotaExceededErrorClass:        metrics.QuotaExceeded,
+	cloudprovider.StockoutErrorClass:             metrics.Stockout,
+	cloudprovider.InvalidConfigurationErrorClass: metrics.InvalidConfiguration,
+	cloudprovider.TimeoutErrorClass:              metrics.Timeout,
+	cloudprovider.OtherErrorClass:                metrics.APIError,
+}
+
 // ScaleUpRequest contains information about the requested node group scale up.
 type ScaleUpRequest struct {
 	// NodeGroupName is the node group to be scaled up.
@@ -86,7 +127,7 @@ type ClusterStateRegistryConfig struct {
 	// Minimum number of nodes that must be unready for MaxTotalUnreadyPercentage to apply.
 	// This is to ensure that in very small clusters (e.g. 2 nodes) a single node's failure doesn't disable autoscaling.
 	OkTotalUnreadyCount int
-	//  Maximum time CA waits for node to be provisioned
+	//  Maximum time CA waits for node to be provisioned, unless overridden by the node group
 	MaxNodeProvisionTime time.Duration
 }
 
@@ -109,6 +150,14 @@ type UnregisteredNode struct {
 	Node *apiv1.Node
 	// UnregisteredSince is the time when the node was first spotted.
 	UnregisteredSince time.Time
+	// Status is the status of the instance reported by the cloud provider, nil if unknown.
+	Status *cloudprovider.InstanceStatus
+}
+
+// CreationFailed returns true if the cloud provider reported that the instance of the node couldn't
+// be created, so the node will never register.
+func (n UnregisteredNode) CreationFailed() bool {
+	return n.Status != nil && n.Status.State == cloudprovider.InstanceCreating && n.Status.ErrorInfo != nil
 }
 
 // ClusterStateRegistry is a structure to keep track the current state of the cluster.
@@ -124,7 +173,9 @@ type ClusterStateRegistry struct {
 	acceptableRanges        map[string]AcceptableRange
 	incorrectNodeGroupSizes map[string]IncorrectNodeGroupSize
 	unregisteredNodes       map[string]UnregisteredNode
+	reportedCreationErrors  map[string]bool
 	candidatesForScaleDown  map[string][]string
+	scaleDownBlackouts      ScaleDownBlackouts
 	nodeGroupBackoffInfo    *backoff.Backoff
 	lastStatus              *api.ClusterAutoscalerStatus
 	lastScaleDownUpdateTime time.Time
@@ -147,6 +198,7 @@ func NewClusterStateRegistry(cloudProvider cloudprovider.CloudProvider, config C
 		acceptableRanges:        make(map[string]AcceptableRange),
 		incorrectNodeGroupSizes: make(map[string]IncorrectNodeGroupSize),
 		unregisteredNodes:       make(map[string]UnregisteredNode),
+		reportedCreationErrors:  make(map[string]bool),
 		candidatesForScaleDown:  make(map[string][]string),
 		nodeGroupBackoffInfo:    backoff.NewBackoff(InitialNodeGroupBackoffDuration, MaxNodeGroupBackoffDuration, NodeGroupBackoffResetTimeout),
 		lastStatus:              emptyStatus,
@@ -203,8 +255,7 @@ func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time) {
 			csr.logRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpTimedOut",
 				"Nodes added to group %s failed to register within %v",
 				sur.NodeGroupName, currentTime.Sub(sur.Time))
-			metrics.RegisterFailedScaleUp(metrics.Timeout)
-			csr.backoffNodeGroup(sur.NodeGroupName, currentTime)
+			csr.registerFailedScaleUpNoLock(sur.NodeGroupName, cloudprovider.TimeoutErrorClass, currentTime)
 		}
 	}
 
@@ -218,20 +269,33 @@ func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time) {
 }
 
 // To be executed under a lock.
-func (csr *ClusterStateRegistry) backoffNodeGroup(nodeGroupName string, currentTime time.Time) {
-	backoffUntil := csr.nodeGroupBackoffInfo.Backoff(nodeGroupName, currentTime)
-	glog.Warningf("Disabling scale-up for node group %v until %v", nodeGroupName, backoffUntil)
+func (csr *ClusterStateRegistry) backoffNodeGroup(nodeGroupName string, errorClass cloudprovider.InstanceErrorClass, currentTime time.Time) {
+	durations, found := nodeGroupBackoffDurations[errorClass]
+	if !found {
+		durations = backoffDurations{InitialNodeGroupBackoffDuration, MaxNodeGroupBackoffDuration}
+	}
+	backoffUntil := csr.nodeGroupBackoffInfo.BackoffWithDurations(nodeGroupName, durations.initial, durations.max, currentTime)
+	glog.Warningf("Disabling scale-up for node group %v until %v after %s error", nodeGroupName, backoffUntil, errorClass)
+}
+
+// To be executed under a lock.
+func (csr *ClusterStateRegistry) registerFailedScaleUpNoLock(nodeGroupName string, errorClass cloudprovider.InstanceErrorClass, currentTime time.Time) {
+	reason, found := failedScaleUpReasons[errorClass]
+	if !found {
+		reason = metrics.APIError
+	}
+	metrics.RegisterFailedScaleUp(reason)
+	csr.backoffNodeGroup(nodeGroupName, errorClass, currentTime)
 }
 
 // RegisterFailedScaleUp should be called after getting error from cloudprovider
 // when trying to scale-up node group. It will mark this group as not safe to autoscale
-// for some time.
-func (csr *ClusterStateRegistry) RegisterFailedScaleUp(nodeGroupName string, reason metrics.FailedScaleUpReason) {
+// for some time, depending on the class of the error.
+func (csr *ClusterStateRegistry) RegisterFailedScaleUp(nodeGroupName string, errorClass cloudprovider.InstanceErrorClass) {
 	csr.Lock()
 	defer csr.Unlock()
 
-	metrics.RegisterFailedScaleUp(reason)
-	csr.backoffNodeGroup(nodeGroupName, time.Now())
+	csr.registerFailedScaleUpNoLock(nodeGroupName, errorClass, time.Now())
 }
 
 // UpdateNodes updates the state of the nodes in the ClusterStateRegistry and recalculates the stats
@@ -252,6 +316,7 @@ func (csr *ClusterStateRegistry) UpdateNodes(nodes []*apiv1.Node, currentTime ti
 	csr.nodes = nodes
 
 	csr.updateUnregisteredNodes(notRegistered)
+	csr.handleInstanceCreationErrors(currentTime)
 	csr.updateReadinessStats(currentTime)
 
 	// update acceptable ranges based on requests from last loop and targetSizes
@@ -505,7 +570,7 @@ func (csr *ClusterStateRegistry) updateReadinessStats(currentTime time.Time) {
 			continue
 		}
 		perNgCopy := perNodeGroup[nodeGroup.Id()]
-		if unregistered.UnregisteredSince.Add(csr.config.MaxNodeProvisionTime).Before(currentTime) {
+		if unregistered.CreationFailed() || unregistered.UnregisteredSince.Add(csr.maxNodeProvisionTime(nodeGroup)).Before(currentTime) {
 			perNgCopy.LongUnregistered += 1
 			total.LongUnregistered += 1
 		} else {
@@ -523,6 +588,13 @@ func (csr *ClusterStateRegistry) updateReadinessStats(currentTime time.Time) {
 	csr.totalReadiness = total
 }
 
+// maxNodeProvisionTime returns the maximum time to wait for a node of the given node group to be provisioned.
+// Only MaxNodeProvisionTime is known to the registry, other options of the node group are ignored.
+func (csr *ClusterStateRegistry) maxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) time.Duration {
+	defaults := config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: csr.config.MaxNodeProvisionTime}
+	return cloudprovider.GetNodeGroupOptions(nodeGroup, defaults).MaxNodeProvisionTime
+}
+
 // Calculates which node groups have incorrect size.
 func (csr *ClusterStateRegistry) updateIncorrectNodeGroupSizes(currentTime time.Time) {
 	result := make(map[string]IncorrectNodeGroupSize)
@@ -564,6 +636,7 @@ func (csr *ClusterStateRegistry) updateUnregisteredNodes(unregisteredNodes []Unr
 	result := make(map[string]UnregisteredNode)
 	for _, unregistered := range unregisteredNodes {
 		if prev, found := csr.unregisteredNodes[unregistered.Node.Name]; found {
+			prev.Status = unregistered.Status
 			result[unregistered.Node.Name] = prev
 		} else {
 			result[unregistered.Node.Name] = unregistered
@@ -572,6 +645,62 @@ func (csr *ClusterStateRegistry) updateUnregisteredNodes(unregisteredNodes []Unr
 	csr.unregisteredNodes = result
 }
 
+// handleInstanceCreationErrors registers a failed scale-up, with the class of the error, for node groups
+// with instances that couldn't be created. Scale-up requests are reduced by the failed instances, so they
+// don't wait for nodes that will never register. To be executed under a lock.
+func (csr *ClusterStateRegistry) handleInstanceCreationErrors(currentTime time.Time) {
+	reported := make(map[string]bool)
+	failedPerNodeGroup := make(map[string][]UnregisteredNode)
+	for name, unregistered := range csr.unregisteredNodes {
+		if !unregistered.CreationFailed() {
+			continue
+		}
+		reported[name] = true
+		if csr.reportedCreationErrors[name] {
+			continue
+		}
+		nodeGroup, err := csr.cloudProvider.NodeGroupForNode(unregistered.Node)
+		if err != nil {
+			glog.Warningf("Failed to get nodegroup for %s: %v", name, err)
+			continue
+		}
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		failedPerNodeGroup[nodeGroup.Id()] = append(failedPerNodeGroup[nodeGroup.Id()], unregistered)
+	}
+	csr.reportedCreationErrors = reported
+
+	for nodeGroupName, failed := range failedPerNodeGroup {
+		sort.Slice(failed, func(i, j int) bool { return failed[i].Node.Name < failed[j].Node.Name })
+		errorInfo := failed[0].Status.ErrorInfo
+		glog.Warningf("Failed to create %d instances of node group %s: %s %s", len(failed), nodeGroupName,
+			errorInfo.ErrorCode, errorInfo.ErrorMessage)
+		csr.logRecorder.Eventf(apiv1.EventTypeWarning, "InstanceCreationFailed",
+			"Failed to create %d instances of node group %s: %s %s", len(failed), nodeGroupName,
+			errorInfo.ErrorCode, errorInfo.ErrorMessage)
+
+		remaining := len(failed)
+		newSur := make([]*ScaleUpRequest, 0, len(csr.scaleUpRequests))
+		for _, sur := range csr.scaleUpRequests {
+			if sur.NodeGroupName == nodeGroupName && remaining > 0 {
+				decrease := remaining
+				if decrease > sur.Increase {
+					decrease = sur.Increase
+				}
+				sur.Increase -= decrease
+				remaining -= decrease
+				if sur.Increase <= 0 {
+					continue
+				}
+			}
+			newSur = append(newSur, sur)
+		}
+		csr.scaleUpRequests = newSur
+		csr.registerFailedScaleUpNoLock(nodeGroupName, errorInfo.ErrorClass, currentTime)
+	}
+}
+
 //GetUnregisteredNodes returns a list of all unregistered nodes.
 func (csr *ClusterStateRegistry) GetUnregisteredNodes() []UnregisteredNode {
 	csr.Lock()
@@ -602,6 +731,20 @@ func (csr *ClusterStateRegistry) UpdateScaleDownCandidates(nodes []*apiv1.Node,
 	csr.lastScaleDownUpdateTime = now
 }
 
+// ScaleDownBlackouts describes where scale-down is blocked by schedule.
+type ScaleDownBlackouts struct {
+	// Cluster is true if scale-down of the whole cluster is blocked.
+	Cluster bool
+	// NodeGroups contains ids of node groups whose scale-down is blocked, including those blocked by
+	// a blackout of the whole cluster.
+	NodeGroups map[string]bool
+}
+
+// UpdateScaleDownBlackouts updates the node groups whose scale-down is blocked by schedule.
+func (csr *ClusterStateRegistry) UpdateScaleDownBlackouts(blackouts ScaleDownBlackouts) {
+	csr.scaleDownBlackouts = blackouts
+}
+
 // GetStatus returns ClusterAutoscalerStatus with the current cluster autoscaler status.
 func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscalerStatus {
 	result := &api.ClusterAutoscalerStatus{
@@ -629,7 +772,7 @@ func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscaler
 
 		// Scale down.
 		nodeGroupStatus.Conditions = append(nodeGroupStatus.Conditions, buildScaleDownStatusNodeGroup(
-			csr.candidatesForScaleDown[nodeGroup.Id()], csr.lastScaleDownUpdateTime))
+			csr.candidatesForScaleDown[nodeGroup.Id()], csr.scaleDownBlackouts.NodeGroups[nodeGroup.Id()], csr.lastScaleDownUpdateTime))
 
 		result.NodeGroupStatuses = append(result.NodeGroupStatuses, nodeGroupStatus)
 	}
@@ -638,7 +781,7 @@ func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscaler
 	result.ClusterwideConditions = append(result.ClusterwideConditions,
 		buildScaleUpStatusClusterwide(result.NodeGroupStatuses, csr.totalReadiness))
 	result.ClusterwideConditions = append(result.ClusterwideConditions,
-		buildScaleDownStatusClusterwide(csr.candidatesForScaleDown, csr.lastScaleDownUpdateTime))
+		buildScaleDownStatusClusterwide(csr.candidatesForScaleDown, csr.scaleDownBlackouts.Cluster, csr.lastScaleDownUpdateTime))
 
 	updateLastTransition(csr.lastStatus, result)
 	csr.lastStatus = result
@@ -691,13 +834,15 @@ func buildScaleUpStatusNodeGroup(isScaleUpInProgress bool, isSafeToScaleUp bool,
 	return condition
 }
 
-func buildScaleDownStatusNodeGroup(candidates []string, lastProbed time.Time) api.ClusterAutoscalerCondition {
+func buildScaleDownStatusNodeGroup(candidates []string, blockedBySchedule bool, lastProbed time.Time) api.ClusterAutoscalerCondition {
 	condition := api.ClusterAutoscalerCondition{
 		Type:          api.ClusterAutoscalerScaleDown,
 		Message:       fmt.Sprintf("candidates=%d", len(candidates)),
 		LastProbeTime: metav1.Time{Time: lastProbed},
 	}
-	if len(candidates) > 0 {
+	if blockedBySchedule {
+		condition.Status = api.ClusterAutoscalerBlockedBySchedule
+	} else if len(candidates) > 0 {
 		condition.Status = api.ClusterAutoscalerCandidatesPresent
 	} else {
 		condition.Status = api.ClusterAutoscalerNoCandidates
@@ -752,7 +897,7 @@ func buildScaleUpStatusClusterwide(nodeGroupStatuses []api.NodeGroupStatus, read
 	return condition
 }
 
-func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed time.Time) api.ClusterAutoscalerCondition {
+func buildScaleDownStatusClusterwide(candidates map[string][]string, blockedBySchedule bool, lastProbed time.Time) api.ClusterAutoscalerCondition {
 	totalCandidates := 0
 	for _, val := range candidates {
 		totalCandidates += len(val)
@@ -762,7 +907,9 @@ func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed
 		Message:       fmt.Sprintf("candidates=%d", totalCandidates),
 		LastProbeTime: metav1.Time{Time: lastProbed},
 	}
-	if totalCandidates > 0 {
+	if blockedBySchedule {
+		condition.Status = api.ClusterAutoscalerBlockedBySchedule
+	} else if totalCandidates > 0 {
 		condition.Status = api.ClusterAutoscalerCandidatesPresent
 	} else {
 		condition.Status = api.ClusterAutoscalerNoCandidates
@@ -878,22 +1025,27 @@ func getNotRegisteredNodes(allNodes []*apiv1.Node, cloudProvider cloudprovider.C
 	}
 	notRegistered := make([]UnregisteredNode, 0)
 	for _, nodeGroup := range cloudProvider.NodeGroups() {
-		nodes, err := nodeGroup.Nodes()
+		instances, err := nodeGroup.Nodes()
 		if err != nil {
 			return []UnregisteredNode{}, err
 		}
-		for _, node := range nodes {
-			if !registered.Has(node) {
+		for _, instance := range instances {
+			// Instances being deleted won't register anymore, there is no need to wait for them.
+			if instance.Status != nil && instance.Status.State == cloudprovider.InstanceDeleting {
+				continue
+			}
+			if !registered.Has(instance.Id) {
 				notRegistered = append(notRegistered, UnregisteredNode{
 					Node: &apiv1.Node{
 						ObjectMeta: metav1.ObjectMeta{
-							Name: node,
+							Name: instance.Id,
 						},
 						Spec: apiv1.NodeSpec{
-							ProviderID: node,
+							ProviderID: instance.Id,
 						},
 					},
 					UnregisteredSince: time,
+					Status:            instance.Status,
 				})
 			}
 		}
@@ -22,9 +22,11 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
 	kube_record "k8s.io/client-go/tools/record"
@@ -490,6 +492,101 @@ func TestUnregisteredNodes(t *testing.T) {
 	assert.Equal(t, 0, len(clusterstate.GetUnregisteredNodes()))
 }
 
+func TestUnregisteredNodesWithNodeGroupMaxNodeProvisionTime(t *testing.T) {
+	ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+	ng1_1.Spec.ProviderID = "ng1-1"
+	ng2_1 := BuildTestNode("ng2-1", 1000, 1000)
+	ng2_1.Spec.ProviderID = "ng2-1"
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroupWithCustomOptions("ng2", 1, 10, 1, &config.NodeGroupAutoscalingOptions{
+		MaxNodeProvisionTime: time.Hour,
+	})
+	provider.AddNode("ng1", ng1_1)
+	provider.AddNode("ng2", ng2_1)
+
+	fakeClient := &fake.Clientset{}
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false)
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{
+		MaxTotalUnreadyPercentage: 10,
+		OkTotalUnreadyCount:       1,
+		MaxNodeProvisionTime:      10 * time.Second,
+	}, fakeLogRecorder)
+	err := clusterstate.UpdateNodes([]*apiv1.Node{}, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(clusterstate.GetUnregisteredNodes()))
+
+	// Only the node of ng1 exceeded its MaxNodeProvisionTime.
+	err = clusterstate.UpdateNodes([]*apiv1.Node{}, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	upcomingNodes := clusterstate.GetUpcomingNodes()
+	assert.Equal(t, 0, upcomingNodes["ng1"])
+	assert.Equal(t, 1, upcomingNodes["ng2"])
+}
+
+func TestInstanceCreationErrors(t *testing.T) {
+	now := time.Now()
+	ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+	ng1_1.Spec.ProviderID = "ng1-1"
+	SetNodeReadyState(ng1_1, true, now.Add(-time.Minute))
+	ng1_2 := BuildTestNode("ng1-2", 1000, 1000)
+	ng1_2.Spec.ProviderID = "ng1-2"
+	ng1_3 := BuildTestNode("ng1-3", 1000, 1000)
+	ng1_3.Spec.ProviderID = "ng1-3"
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 3)
+	provider.AddNode("ng1", ng1_1)
+	provider.AddNode("ng1", ng1_2)
+	provider.AddNode("ng1", ng1_3)
+	provider.SetInstanceStatus("ng1-2", &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating})
+	provider.SetInstanceStatus("ng1-3", &cloudprovider.InstanceStatus{
+		State: cloudprovider.InstanceCreating,
+		ErrorInfo: &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.QuotaExceededErrorClass,
+			ErrorCode:    "QUOTA_EXCEEDED",
+			ErrorMessage: "Quota CPUS exceeded",
+		},
+	})
+
+	fakeClient := &fake.Clientset{}
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false)
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{
+		MaxTotalUnreadyPercentage: 10,
+		OkTotalUnreadyCount:       1,
+	}, fakeLogRecorder)
+	clusterstate.RegisterScaleUp(&ScaleUpRequest{
+		NodeGroupName:   "ng1",
+		Increase:        2,
+		Time:            now.Add(-time.Minute),
+		ExpectedAddTime: now.Add(time.Minute),
+	})
+	err := clusterstate.UpdateNodes([]*apiv1.Node{ng1_1}, now)
+	assert.NoError(t, err)
+
+	unregistered := clusterstate.GetUnregisteredNodes()
+	assert.Equal(t, 2, len(unregistered))
+	for _, node := range unregistered {
+		assert.Equal(t, node.Node.Name == "ng1-3", node.CreationFailed())
+	}
+	// The failed instance is no longer expected to come up.
+	assert.Equal(t, 1, clusterstate.GetUpcomingNodes()["ng1"])
+	assert.Equal(t, 1, len(clusterstate.scaleUpRequests))
+	assert.Equal(t, 1, clusterstate.scaleUpRequests[0].Increase)
+	assert.False(t, clusterstate.IsNodeGroupSafeToScaleUp("ng1", now))
+	assert.True(t, clusterstate.nodeGroupBackoffInfo.IsBackedOff("ng1", now.Add(InitialNodeGroupBackoffDuration+time.Minute)))
+
+	// The same failure is reported only once.
+	clusterstate.RegisterScaleUp(&ScaleUpRequest{
+		NodeGroupName:   "ng1",
+		Increase:        1,
+		Time:            now,
+		ExpectedAddTime: now.Add(2 * time.Minute),
+	})
+	err = clusterstate.UpdateNodes([]*apiv1.Node{ng1_1}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(clusterstate.scaleUpRequests))
+}
+
 func TestUpdateLastTransitionTimes(t *testing.T) {
 	now := metav1.Time{Time: time.Now()}
 	later := metav1.Time{Time: now.Time.Add(10 * time.Second)}
@@ -672,6 +769,37 @@ func TestScaleUpBackoff(t *testing.T) {
 	assert.False(t, clusterstate.nodeGroupBackoffInfo.IsBackedOff("ng1", now))
 }
 
+func TestScaleUpBackoffByErrorClass(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	for _, id := range []string{"quota", "stockout", "config", "other"} {
+		provider.AddNodeGroup(id, 1, 10, 1)
+	}
+	fakeClient := &fake.Clientset{}
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false)
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{}, fakeLogRecorder)
+
+	now := time.Now()
+	clusterstate.RegisterFailedScaleUp("quota", cloudprovider.QuotaExceededErrorClass)
+	clusterstate.RegisterFailedScaleUp("stockout", cloudprovider.StockoutErrorClass)
+	clusterstate.RegisterFailedScaleUp("config", cloudprovider.InvalidConfigurationErrorClass)
+	clusterstate.RegisterFailedScaleUp("other", cloudprovider.OtherErrorClass)
+
+	backedOff := func(at time.Duration) map[string]bool {
+		result := make(map[string]bool)
+		for _, id := range []string{"quota", "stockout", "config", "other"} {
+			result[id] = clusterstate.nodeGroupBackoffInfo.IsBackedOff(id, now.Add(at))
+		}
+		return result
+	}
+	assert.Equal(t, map[string]bool{"quota": true, "stockout": true, "config": true, "other": true}, backedOff(time.Minute))
+	assert.Equal(t, map[string]bool{"quota": true, "stockout": false, "config": true, "other": false},
+		backedOff(InitialNodeGroupBackoffDuration+time.Minute))
+	assert.Equal(t, map[string]bool{"quota": false, "stockout": false, "config": true, "other": false},
+		backedOff(InitialQuotaExceededBackoffDuration+time.Minute))
+	assert.Equal(t, map[string]bool{"quota": false, "stockout": false, "config": false, "other": false},
+		backedOff(InitialInvalidConfigurationBackoffDuration+time.Minute))
+}
+
 func TestGetClusterSize(t *testing.T) {
 	now := time.Now()
 
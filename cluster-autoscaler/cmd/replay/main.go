@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command replay runs the autoscaler loops recorded with --loop-record-file offline
+// and prints the decisions taken in each of them. It doesn't need access to a cluster
+// or a cloud provider, so it can be used to check how a change to the configuration
+// or the code would have affected the recorded scale-ups and scale-downs.
+//
+// The replay accepts the same autoscaling flags as cluster autoscaler. Flags related to the
+// cluster or the cloud provider, e.g. --cloud-provider or --nodes, are ignored since node
+// groups come from the recording. Behaviors configured in ConfigMaps of the cluster can't be
+// replayed since ConfigMaps are not recorded: the priority expander keeps all node groups,
+// --min-size-schedules and --scale-down-blackouts have no effect.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config/flags"
+	"k8s.io/autoscaler/cluster-autoscaler/core"
+	"k8s.io/autoscaler/cluster-autoscaler/replay"
+
+	"github.com/golang/glog"
+)
+
+var (
+	recordFile = flag.String("record-file", "", "Path to the file with loop records written by cluster autoscaler run with --loop-record-file.")
+)
+
+func main() {
+	flag.Parse()
+	if *recordFile == "" {
+		glog.Fatalf("--record-file is required")
+	}
+
+	records, err := replay.ReadRecordsFromFile(*recordFile)
+	if err != nil {
+		glog.Fatalf("Failed to read loop records: %v", err)
+	}
+	replayer, err := core.NewReplayer(flags.CreateAutoscalingOptions())
+	if err != nil {
+		glog.Fatalf("Failed to create replayer: %v", err)
+	}
+	defer replayer.Close()
+
+	decisionsCount := 0
+	for _, record := range records {
+		decisions, err := replayer.ReplayLoop(record)
+		if err != nil {
+			glog.Warningf("Loop %s failed: %v", record.Timestamp.Format(time.RFC3339), err)
+		}
+		for _, decision := range decisions {
+			fmt.Fprintln(os.Stdout, decision.String())
+		}
+		decisionsCount += len(decisions)
+	}
+	fmt.Fprintf(os.Stdout, "Replayed %d loops, %d decisions taken\n", len(records), decisionsCount)
+}
@@ -18,6 +18,8 @@ package config
 
 import (
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // GpuLimits define lower and upper bound on GPU instances of given type in cluster
@@ -30,6 +32,37 @@ type GpuLimits struct {
 	Max int64
 }
 
+// GRPCExpanderOptions define how to reach an external gRPC expander and what to do when it doesn't answer
+type GRPCExpanderOptions struct {
+	// URL of the gRPC expander service.
+	URL string
+	// Cert is the path to the CA certificate used to verify the service. Connection is insecure if empty.
+	Cert string
+	// Timeout is how long to wait for the service to answer.
+	Timeout time.Duration
+	// FallbackExpanderName is the built-in expander used when the service fails to answer.
+	FallbackExpanderName string
+}
+
+// NodeGroupAutoscalingOptions contain the autoscaling options that can be overridden for a single node group
+type NodeGroupAutoscalingOptions struct {
+	// ScaleDownUtilizationThreshold sets threshold for nodes to be considered for scale down.
+	ScaleDownUtilizationThreshold float64
+	// ScaleDownUnneededTime sets the duration CA expects a node to be unneeded/eligible for removal
+	// before scaling down the node.
+	ScaleDownUnneededTime time.Duration
+	// ScaleDownUnreadyTime represents how long an unready node should be unneeded before it is eligible for scale down
+	ScaleDownUnreadyTime time.Duration
+	// MaxNodeProvisionTime is the maximum time CA waits for a node of the node group to be provisioned
+	MaxNodeProvisionTime time.Duration
+	// HeadroomNodes is the number of empty nodes worth of free capacity kept in the node group
+	HeadroomNodes int
+	// HeadroomCores is the number of free cores kept in the node group on top of HeadroomNodes
+	HeadroomCores int64
+	// HeadroomMemory is the free memory (in bytes) kept in the node group on top of HeadroomNodes
+	HeadroomMemory int64
+}
+
 // AutoscalingOptions contain various options to customize how autoscaling works
 type AutoscalingOptions struct {
 	// MaxEmptyBulkDelete is a number of empty nodes that can be removed at the same time.
@@ -58,8 +91,11 @@ type AutoscalingOptions struct {
 	NodeGroupAutoDiscovery []string
 	// EstimatorName is the estimator used to estimate the number of needed nodes in scale up.
 	EstimatorName string
-	// ExpanderName sets the type of node group expander to be used in scale up
-	ExpanderName string
+	// ExpanderNames sets the types of node group expanders to be used in scale up. The expanders are
+	// applied in order, each one choosing among the node groups left by the previous one.
+	ExpanderNames []string
+	// GRPCExpander configures the external gRPC expander.
+	GRPCExpander GRPCExpanderOptions
 	// MaxGracefulTerminationSec is maximum number of seconds scale down waits for pods to terminate before
 	// removing the node from cloud provider.
 	MaxGracefulTerminationSec int
@@ -75,6 +111,8 @@ type AutoscalingOptions struct {
 	CloudProviderName string
 	// NodeGroups is the list of node groups a.k.a autoscaling targets
 	NodeGroups []string
+	// ScanInterval is how often the cluster is reevaluated for scale up or down
+	ScanInterval time.Duration
 	// ScaleDownEnabled is used to allow CA to scale down the cluster
 	ScaleDownEnabled bool
 	// ScaleDownDelayAfterAdd sets the duration from the last scale up to the time when CA starts to check scale down options
@@ -115,4 +153,77 @@ type AutoscalingOptions struct {
 	ExpendablePodsPriorityCutoff int
 	// Regional tells whether the cluster is regional.
 	Regional bool
+	// DryRun tells whether CA should only simulate scale-up and scale-down and report the decisions
+	// it would have taken instead of changing node groups, tainting nodes or evicting pods.
+	DryRun bool
+	// LoopRecordFile is the path to a file the inputs of every autoscaler loop are appended to,
+	// so that the loops can be replayed offline. Recording is disabled if empty.
+	LoopRecordFile string
+	// LoopRecordFileMaxSize is the size in bytes past which the loop record file is rotated.
+	// The file grows without limit if 0.
+	LoopRecordFileMaxSize int64
+	// HeadroomNodes is the number of empty nodes worth of free capacity kept in every node group,
+	// unless overridden by the node group.
+	HeadroomNodes int
+	// NodeGroupHeadroomCores is the number of free cores kept in every node group, unless overridden
+	// by the node group.
+	NodeGroupHeadroomCores int64
+	// NodeGroupHeadroomMemory is the free memory (in bytes) kept in every node group, unless
+	// overridden by the node group.
+	NodeGroupHeadroomMemory int64
+	// ClusterHeadroomNodes is the number of nodes worth of free capacity kept in the whole cluster,
+	// measured in the largest node of the cluster.
+	ClusterHeadroomNodes int
+	// HeadroomCores is the number of free cores kept in the whole cluster.
+	HeadroomCores int64
+	// HeadroomMemory is the free memory (in bytes) kept in the whole cluster.
+	HeadroomMemory int64
+	// MinSizeSchedulesEnabled tells whether min sizes of node groups are changed by the schedules
+	// read from a ConfigMap.
+	MinSizeSchedulesEnabled bool
+	// ScaleDownBlackoutsEnabled tells whether scale-down is blocked during the blackout windows read
+	// from a ConfigMap.
+	ScaleDownBlackoutsEnabled bool
+	// MaxDrainParallelism is a number of non-empty nodes that can be drained and removed at the same time.
+	MaxDrainParallelism int
+	// AWSPricingOverrideFile is the path to a JSON file with prices of AWS instance types, by region,
+	// overriding the on-demand prices. Not used if empty.
+	AWSPricingOverrideFile string
+	// AzurePricingOverrideFile is the path to a JSON file with prices of Azure VM sizes, by region,
+	// overriding the pay-as-you-go prices. Not used if empty.
+	AzurePricingOverrideFile string
+	// ScaleDownOrderPolicies sets the policies used to order the scale-down candidates. Each policy only
+	// breaks the ties left by the previous ones. The candidates are not reordered if empty.
+	ScaleDownOrderPolicies []string
+	// NodeConsolidationEnabled tells whether CA replaces several underutilized nodes with fewer, cheaper
+	// nodes of another node group.
+	NodeConsolidationEnabled bool
+	// MaxConsolidatedNodes is the maximum number of nodes replaced by a single consolidation.
+	MaxConsolidatedNodes int
+	// ConsolidationMinSavingsRatio is the minimum part of the hourly price of the replaced nodes a
+	// consolidation has to save.
+	ConsolidationMinSavingsRatio float64
+	// MaxNodeAge is the age after which nodes are replaced with new ones. Nodes are not recycled if 0.
+	MaxNodeAge time.Duration
+	// MaxConcurrentRecycledNodes is the maximum number of nodes being replaced at the same time.
+	MaxConcurrentRecycledNodes int
+	// ScratchPodNamespaces are the namespaces whose pods keep only scratch data in local storage.
+	// Such pods don't prevent scale-down even if nodes with local storage are skipped.
+	ScratchPodNamespaces []string
+	// ScratchPodSelector selects pods in any namespace keeping only scratch data in local storage,
+	// nil if none.
+	ScratchPodSelector labels.Selector
+}
+
+// NodeGroupDefaults returns the options used for node groups that don't override them.
+func (o AutoscalingOptions) NodeGroupDefaults() NodeGroupAutoscalingOptions {
+	return NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: o.ScaleDownUtilizationThreshold,
+		ScaleDownUnneededTime:         o.ScaleDownUnneededTime,
+		ScaleDownUnreadyTime:          o.ScaleDownUnreadyTime,
+		MaxNodeProvisionTime:          o.MaxNodeProvisionTime,
+		HeadroomNodes:                 o.HeadroomNodes,
+		HeadroomCores:                 o.NodeGroupHeadroomCores,
+		HeadroomMemory:                o.NodeGroupHeadroomMemory,
+	}
 }
@@ -0,0 +1,361 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flags registers the command line flags configuring the autoscaling options, shared
+// by cluster autoscaler and the commands running its loops offline.
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+
+	"github.com/golang/glog"
+)
+
+// MultiStringFlag is a flag for passing multiple parameters using same flag
+type MultiStringFlag []string
+
+// String returns string representation of the node groups.
+func (flag *MultiStringFlag) String() string {
+	return "[" + strings.Join(*flag, " ") + "]"
+}
+
+// Set adds a new configuration.
+func (flag *MultiStringFlag) Set(value string) error {
+	*flag = append(*flag, value)
+	return nil
+}
+
+func multiStringFlag(name string, usage string) *MultiStringFlag {
+	value := new(MultiStringFlag)
+	flag.Var(value, name, usage)
+	return value
+}
+
+var (
+	clusterName            = flag.String("cluster-name", "", "Autoscaled cluster name, if available")
+	cloudConfig            = flag.String("cloud-config", "", "The path to the cloud provider configuration file.  Empty string for no configuration file.")
+	namespace              = flag.String("namespace", "kube-system", "Namespace in which cluster-autoscaler run.")
+	scaleDownEnabled       = flag.Bool("scale-down-enabled", true, "Should CA scale down the cluster")
+	scaleDownDelayAfterAdd = flag.Duration("scale-down-delay-after-add", 10*time.Minute,
+		"How long after scale up that scale down evaluation resumes")
+	scaleDownDelayAfterDelete = flag.Duration("scale-down-delay-after-delete", *scanInterval,
+		"How long after node deletion that scale down evaluation resumes, defaults to scanInterval")
+	scaleDownDelayAfterFailure = flag.Duration("scale-down-delay-after-failure", 3*time.Minute,
+		"How long after scale down failure that scale down evaluation resumes")
+	scaleDownUnneededTime = flag.Duration("scale-down-unneeded-time", 10*time.Minute,
+		"How long a node should be unneeded before it is eligible for scale down")
+	scaleDownUnreadyTime = flag.Duration("scale-down-unready-time", 20*time.Minute,
+		"How long an unready node should be unneeded before it is eligible for scale down")
+	scaleDownUtilizationThreshold = flag.Float64("scale-down-utilization-threshold", 0.5,
+		"Node utilization level, defined as sum of requested resources divided by capacity, below which a node can be considered for scale down")
+	scaleDownNonEmptyCandidatesCount = flag.Int("scale-down-non-empty-candidates-count", 30,
+		"Maximum number of non empty nodes considered in one iteration as candidates for scale down with drain."+
+			"Lower value means better CA responsiveness but possible slower scale down latency."+
+			"Higher value can affect CA performance with big clusters (hundreds of nodes)."+
+			"Set to non posistive value to turn this heuristic off - CA will not limit the number of nodes it considers.")
+	scaleDownCandidatesPoolRatio = flag.Float64("scale-down-candidates-pool-ratio", 0.1,
+		"A ratio of nodes that are considered as additional non empty candidates for"+
+			"scale down when some candidates from previous iteration are no longer valid."+
+			"Lower value means better CA responsiveness but possible slower scale down latency."+
+			"Higher value can affect CA performance with big clusters (hundreds of nodes)."+
+			"Set to 1.0 to turn this heuristics off - CA will take all nodes as additional candidates.")
+	scaleDownCandidatesPoolMinCount = flag.Int("scale-down-candidates-pool-min-count", 50,
+		"Minimum number of nodes that are considered as additional non empty candidates"+
+			"for scale down when some candidates from previous iteration are no longer valid."+
+			"When calculating the pool size for additional candidates we take"+
+			"max(#nodes * scale-down-candidates-pool-ratio, scale-down-candidates-pool-min-count).")
+	scanInterval      = flag.Duration("scan-interval", 10*time.Second, "How often cluster is reevaluated for scale up or down")
+	maxNodesTotal     = flag.Int("max-nodes-total", 0, "Maximum number of nodes in all node groups. Cluster autoscaler will not grow the cluster beyond this number.")
+	coresTotal        = flag.String("cores-total", minMaxFlagString(0, config.DefaultMaxClusterCores), "Minimum and maximum number of cores in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
+	memoryTotal       = flag.String("memory-total", minMaxFlagString(0, config.DefaultMaxClusterMemory), "Minimum and maximum number of gigabytes of memory in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
+	gpuTotal          = multiStringFlag("gpu-total", "Minimum and maximum number of different GPUs in cluster, in the format <gpu_type>:<min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers. Can be passed multiple times. CURRENTLY THIS FLAG ONLY WORKS ON GKE.")
+	cloudProviderFlag = flag.String("cloud-provider", cloudBuilder.DefaultCloudProvider,
+		"Cloud provider type. Available values: ["+strings.Join(cloudBuilder.AvailableCloudProviders, ",")+"]")
+	maxEmptyBulkDeleteFlag     = flag.Int("max-empty-bulk-delete", 10, "Maximum number of empty nodes that can be deleted at the same time.")
+	maxGracefulTerminationFlag = flag.Int("max-graceful-termination-sec", 10*60, "Maximum number of seconds CA waits for pod termination when trying to scale down a node.")
+	maxTotalUnreadyPercentage  = flag.Float64("max-total-unready-percentage", 45, "Maximum percentage of unready nodes in the cluster.  After this is exceeded, CA halts operations")
+	okTotalUnreadyCount        = flag.Int("ok-total-unready-count", 3, "Number of allowed unready nodes, irrespective of max-total-unready-percentage")
+	maxNodeProvisionTime       = flag.Duration("max-node-provision-time", 15*time.Minute, "Maximum time CA waits for node to be provisioned")
+	nodeGroupsFlag             = multiStringFlag(
+		"nodes",
+		"sets min,max size and other configuration data for a node group in a format accepted by cloud provider. Can be used multiple times. Format: <min>:<max>:<other...>")
+	nodeGroupAutoDiscoveryFlag = multiStringFlag(
+		"node-group-auto-discovery",
+		"One or more definition(s) of node group auto-discovery. "+
+			"A definition is expressed `<name of discoverer>:[<key>[=<value>]]`. "+
+			"The `aws` and `gce` cloud providers are currently supported. AWS matches by ASG tags, e.g. `asg:tag=tagKey,anotherTagKey`. "+
+			"GCE matches by IG name prefix, and requires you to specify min and max nodes per IG, e.g. `mig:namePrefix=pfx,min=0,max=10` "+
+			"Can be used multiple times.")
+
+	estimatorFlag = flag.String("estimator", estimator.BinpackingEstimatorName,
+		"Type of resource estimator to be used in scale up. Available values: ["+strings.Join(estimator.AvailableEstimators, ",")+"]")
+
+	expanderFlag = flag.String("expander", expander.RandomExpanderName,
+		"Type of node group expander to be used in scale up. Available values: ["+strings.Join(expander.AvailableExpanders, ",")+"]. "+
+			"Can be a comma separated list of expanders applied in order, each one choosing among the node groups left by the previous one, e.g. priority,least-waste.")
+	grpcExpanderURL      = flag.String("grpc-expander-url", "", "URL of the gRPC expander service, used by the grpc expander.")
+	grpcExpanderCert     = flag.String("grpc-expander-cert", "", "Path to the CA certificate used to verify the gRPC expander service. Connection is insecure if empty.")
+	grpcExpanderTimeout  = flag.Duration("grpc-expander-timeout", 5*time.Second, "How long to wait for the gRPC expander service to answer before using the fallback expander.")
+	grpcExpanderFallback = flag.String("grpc-expander-fallback", expander.RandomExpanderName, "Expander used when the gRPC expander service fails or doesn't answer in time.")
+
+	writeStatusConfigMapFlag         = flag.Bool("write-status-configmap", true, "Should CA write status information to a configmap")
+	balanceSimilarNodeGroupsFlag     = flag.Bool("balance-similar-node-groups", false, "Detect similar node groups and balance the number of nodes between them")
+	nodeAutoprovisioningEnabled      = flag.Bool("node-autoprovisioning-enabled", false, "Should CA autoprovision node groups when needed")
+	maxAutoprovisionedNodeGroupCount = flag.Int("max-autoprovisioned-node-group-count", 15, "The maximum number of autoprovisioned groups in the cluster.")
+
+	unremovableNodeRecheckTimeout = flag.Duration("unremovable-node-recheck-timeout", 5*time.Minute, "The timeout before we check again a node that couldn't be removed before")
+	expendablePodsPriorityCutoff  = flag.Int("expendable-pods-priority-cutoff", -10, "Pods with priority below cutoff will be expendable. They can be killed without any consideration during scale down and they don't cause scale up. Pods with null priority (PodPriority disabled) are non expendable.")
+	regional                      = flag.Bool("regional", false, "Cluster is regional.")
+	dryRun                        = flag.Bool("dry-run", false, "Should CA only compute scale-up and scale-down decisions and report them via events, logs and metrics without acting on them.")
+	loopRecordFile                = flag.String("loop-record-file", "", "Path to a file the inputs of every autoscaler loop are appended to, for offline replay. Recording is disabled if empty.")
+	loopRecordFileMaxSize         = flag.Int64("loop-record-file-max-size", 1024, "Number of megabytes past which the loop record file is renamed to <file>.1, replacing the previous one, and a new file is started. The file grows without limit if 0.")
+	headroomNodes                 = flag.Int("headroom-nodes", 0, "Number of empty nodes worth of free capacity CA keeps in every node group. Can be overridden by the cloud provider for a single node group.")
+	nodeGroupHeadroomCores        = flag.Int64("node-group-headroom-cores", 0, "Number of free cores CA keeps in every node group, on top of --headroom-nodes. Can be overridden by the cloud provider for a single node group.")
+	nodeGroupHeadroomMemory       = flag.Int64("node-group-headroom-memory", 0, "Number of gigabytes of free memory CA keeps in every node group, on top of --headroom-nodes. Can be overridden by the cloud provider for a single node group.")
+	clusterHeadroomNodes          = flag.Int("cluster-headroom-nodes", 0, "Number of nodes worth of free capacity CA keeps in the whole cluster, measured in the largest node of the cluster.")
+	headroomCores                 = flag.Int64("headroom-cores", 0, "Number of free cores CA keeps in the whole cluster.")
+	headroomMemory                = flag.Int64("headroom-memory", 0, "Number of gigabytes of free memory CA keeps in the whole cluster.")
+	minSizeSchedules              = flag.Bool("min-size-schedules", false, "Should CA change min sizes of node groups according to the schedules in the cluster-autoscaler-min-size-schedules ConfigMap.")
+	maxDrainParallelism           = flag.Int("max-drain-parallelism", 1, "Maximum number of non-empty nodes that can be drained and deleted at the same time.")
+	awsPricingOverrideFile        = flag.String("aws-pricing-override-file", "", "Path to a JSON file with prices of AWS instance types by region, e.g. {\"us-east-1\": {\"m5.large\": 0.035}}, overriding the on-demand prices.")
+	azurePricingOverrideFile      = flag.String("azure-pricing-override-file", "", "Path to a JSON file with prices of Azure VM sizes by region, e.g. {\"eastus\": {\"Standard_D2_v3\": 0.05}}, overriding the pay-as-you-go prices.")
+	scaleDownBlackouts            = flag.Bool("scale-down-blackouts", false, "Should CA block scale-down during the blackout windows in the cluster-autoscaler-scale-down-blackouts ConfigMap.")
+	scaleDownOrder                = flag.String("scale-down-order", "",
+		"Comma separated list of policies ordering the scale-down candidates, each one breaking the ties left by the previous one, e.g. deletion-priority,price. "+
+			"Available values: ["+strings.Join(scaledownorder.AvailablePolicies, ",")+"]. Candidates are not reordered if empty.")
+	nodeConsolidation            = flag.Bool("node-consolidation", false, "Should CA replace several underutilized nodes with fewer, cheaper nodes of another node group. Requires a cloud provider with pricing.")
+	maxConsolidatedNodes         = flag.Int("max-consolidated-nodes", 5, "Maximum number of nodes replaced by a single consolidation.")
+	consolidationMinSavingsRatio = flag.Float64("consolidation-min-savings-ratio", 0.2, "Minimum part of the hourly price of the replaced nodes a consolidation has to save.")
+	maxNodeAge                   = flag.Duration("max-node-age", 0, "Age after which CA replaces a node with a new one of the same node group, draining and deleting the old node once the new one is ready. Nodes are not recycled if 0 or if scale down is disabled.")
+	maxConcurrentRecycledNodes   = flag.Int("max-concurrent-recycled-nodes", 1, "Maximum number of nodes CA replaces at the same time because of their age.")
+	scratchPodNamespaces         = flag.String("scratch-pod-namespaces", "",
+		"Comma separated list of namespaces whose pods keep only scratch data in local storage. Such pods don't prevent scale-down "+
+			"even if skip-nodes-with-local-storage is set")
+	scratchPodSelector = flag.String("scratch-pod-selector", "",
+		"Label selector of pods keeping only scratch data in local storage. Such pods don't prevent scale-down "+
+			"even if skip-nodes-with-local-storage is set")
+)
+
+// CreateAutoscalingOptions builds the autoscaling options from the flags registered by this package.
+// Flags must be parsed first.
+func CreateAutoscalingOptions() config.AutoscalingOptions {
+	minCoresTotal, maxCoresTotal, err := parseMinMaxFlag(*coresTotal)
+	if err != nil {
+		glog.Fatalf("Failed to parse flags: %v", err)
+	}
+	minMemoryTotal, maxMemoryTotal, err := parseMinMaxFlag(*memoryTotal)
+	if err != nil {
+		glog.Fatalf("Failed to parse flags: %v", err)
+	}
+	// Convert memory limits to bytes.
+	minMemoryTotal = minMemoryTotal * units.Gigabyte
+	maxMemoryTotal = maxMemoryTotal * units.Gigabyte
+
+	parsedGpuTotal, err := parseMultipleGpuLimits(*gpuTotal)
+	if err != nil {
+		glog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	parsedScratchPodNamespaces, parsedScratchPodSelector, err := parseScratchPodFlags(*scratchPodNamespaces, *scratchPodSelector)
+	if err != nil {
+		glog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	var scaleDownOrderPolicies []string
+	if *scaleDownOrder != "" {
+		scaleDownOrderPolicies = strings.Split(*scaleDownOrder, ",")
+	}
+
+	return config.AutoscalingOptions{
+		CloudConfig:                      *cloudConfig,
+		CloudProviderName:                *cloudProviderFlag,
+		NodeGroupAutoDiscovery:           *nodeGroupAutoDiscoveryFlag,
+		MaxTotalUnreadyPercentage:        *maxTotalUnreadyPercentage,
+		OkTotalUnreadyCount:              *okTotalUnreadyCount,
+		EstimatorName:                    *estimatorFlag,
+		ExpanderNames:                    strings.Split(*expanderFlag, ","),
+		MaxEmptyBulkDelete:               *maxEmptyBulkDeleteFlag,
+		MaxGracefulTerminationSec:        *maxGracefulTerminationFlag,
+		MaxNodeProvisionTime:             *maxNodeProvisionTime,
+		MaxNodesTotal:                    *maxNodesTotal,
+		MaxCoresTotal:                    maxCoresTotal,
+		MinCoresTotal:                    minCoresTotal,
+		MaxMemoryTotal:                   maxMemoryTotal,
+		MinMemoryTotal:                   minMemoryTotal,
+		GpuTotal:                         parsedGpuTotal,
+		NodeGroups:                       *nodeGroupsFlag,
+		ScanInterval:                     *scanInterval,
+		ScaleDownDelayAfterAdd:           *scaleDownDelayAfterAdd,
+		ScaleDownDelayAfterDelete:        *scaleDownDelayAfterDelete,
+		ScaleDownDelayAfterFailure:       *scaleDownDelayAfterFailure,
+		ScaleDownEnabled:                 *scaleDownEnabled,
+		ScaleDownUnneededTime:            *scaleDownUnneededTime,
+		ScaleDownUnreadyTime:             *scaleDownUnreadyTime,
+		ScaleDownUtilizationThreshold:    *scaleDownUtilizationThreshold,
+		ScaleDownNonEmptyCandidatesCount: *scaleDownNonEmptyCandidatesCount,
+		ScaleDownCandidatesPoolRatio:     *scaleDownCandidatesPoolRatio,
+		ScaleDownCandidatesPoolMinCount:  *scaleDownCandidatesPoolMinCount,
+		WriteStatusConfigMap:             *writeStatusConfigMapFlag,
+		BalanceSimilarNodeGroups:         *balanceSimilarNodeGroupsFlag,
+		ConfigNamespace:                  *namespace,
+		ClusterName:                      *clusterName,
+		NodeAutoprovisioningEnabled:      *nodeAutoprovisioningEnabled,
+		MaxAutoprovisionedNodeGroupCount: *maxAutoprovisionedNodeGroupCount,
+		UnremovableNodeRecheckTimeout:    *unremovableNodeRecheckTimeout,
+		ExpendablePodsPriorityCutoff:     *expendablePodsPriorityCutoff,
+		Regional:                         *regional,
+		DryRun:                           *dryRun,
+		LoopRecordFile:                   *loopRecordFile,
+		LoopRecordFileMaxSize:            *loopRecordFileMaxSize * units.Megabyte,
+		GRPCExpander: config.GRPCExpanderOptions{
+			URL:                  *grpcExpanderURL,
+			Cert:                 *grpcExpanderCert,
+			Timeout:              *grpcExpanderTimeout,
+			FallbackExpanderName: *grpcExpanderFallback,
+		},
+		HeadroomNodes:                *headroomNodes,
+		NodeGroupHeadroomCores:       *nodeGroupHeadroomCores,
+		NodeGroupHeadroomMemory:      *nodeGroupHeadroomMemory * units.Gigabyte,
+		ClusterHeadroomNodes:         *clusterHeadroomNodes,
+		HeadroomCores:                *headroomCores,
+		HeadroomMemory:               *headroomMemory * units.Gigabyte,
+		MinSizeSchedulesEnabled:      *minSizeSchedules,
+		ScaleDownBlackoutsEnabled:    *scaleDownBlackouts,
+		MaxDrainParallelism:          *maxDrainParallelism,
+		AWSPricingOverrideFile:       *awsPricingOverrideFile,
+		AzurePricingOverrideFile:     *azurePricingOverrideFile,
+		ScaleDownOrderPolicies:       scaleDownOrderPolicies,
+		NodeConsolidationEnabled:     *nodeConsolidation,
+		MaxConsolidatedNodes:         *maxConsolidatedNodes,
+		ConsolidationMinSavingsRatio: *consolidationMinSavingsRatio,
+		MaxNodeAge:                   *maxNodeAge,
+		MaxConcurrentRecycledNodes:   *maxConcurrentRecycledNodes,
+		ScratchPodNamespaces:         parsedScratchPodNamespaces,
+		ScratchPodSelector:           parsedScratchPodSelector,
+	}
+}
+
+func parseMinMaxFlag(flag string) (int64, int64, error) {
+	tokens := strings.SplitN(flag, ":", 2)
+	if len(tokens) != 2 {
+		return 0, 0, fmt.Errorf("wrong nodes configuration: %s", flag)
+	}
+
+	min, err := strconv.ParseInt(tokens[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to set min size: %s, expected integer, err: %v", tokens[0], err)
+	}
+
+	max, err := strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to set max size: %s, expected integer, err: %v", tokens[1], err)
+	}
+
+	err = validateMinMaxFlag(min, max)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return min, max, nil
+}
+
+func validateMinMaxFlag(min, max int64) error {
+	if min < 0 {
+		return fmt.Errorf("min size must be greater or equal to  0")
+	}
+	if max < min {
+		return fmt.Errorf("max size must be greater or equal to min size")
+	}
+	return nil
+}
+
+func minMaxFlagString(min, max int64) string {
+	return fmt.Sprintf("%v:%v", min, max)
+}
+
+func parseMultipleGpuLimits(flags MultiStringFlag) ([]config.GpuLimits, error) {
+	parsedFlags := make([]config.GpuLimits, 0, len(flags))
+	for _, flag := range flags {
+		parsedFlag, err := parseSingleGpuLimit(flag)
+		if err != nil {
+			return nil, err
+		}
+		parsedFlags = append(parsedFlags, parsedFlag)
+	}
+	return parsedFlags, nil
+}
+
+func parseSingleGpuLimit(limits string) (config.GpuLimits, error) {
+	parts := strings.Split(limits, ":")
+	if len(parts) != 3 {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit specification: %v", limits)
+	}
+	gpuType := parts[0]
+	minVal, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit - min is not integer: %v", limits)
+	}
+	maxVal, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit - max is not integer: %v", limits)
+	}
+	if minVal < 0 {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit - min is less than 0; %v", limits)
+	}
+	if maxVal < 0 {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit - max is less than 0; %v", limits)
+	}
+	if minVal > maxVal {
+		return config.GpuLimits{}, fmt.Errorf("Incorrect gpu limit - min is greater than max; %v", limits)
+	}
+	parsedGpuLimits := config.GpuLimits{
+		GpuType: gpuType,
+		Min:     minVal,
+		Max:     maxVal,
+	}
+	return parsedGpuLimits, nil
+}
+
+// parseScratchPodFlags parses the comma separated list of scratch pod namespaces and the scratch
+// pod label selector. The selector is nil if empty.
+func parseScratchPodFlags(namespaces string, selector string) ([]string, labels.Selector, error) {
+	var parsedNamespaces []string
+	for _, namespace := range strings.Split(namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			parsedNamespaces = append(parsedNamespaces, namespace)
+		}
+	}
+	if selector == "" {
+		return parsedNamespaces, nil, nil
+	}
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid scratch pod selector %q: %v", selector, err)
+	}
+	return parsedNamespaces, parsedSelector, nil
+}
@@ -14,11 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package main
+package flags
 
 import (
 	"testing"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 
 	"github.com/stretchr/testify/assert"
@@ -91,3 +92,19 @@ func TestParseSingleGpuLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestParseScratchPodFlags(t *testing.T) {
+	namespaces, selector, err := parseScratchPodFlags("", "")
+	assert.NoError(t, err)
+	assert.Empty(t, namespaces)
+	assert.Nil(t, selector)
+
+	namespaces, selector, err = parseScratchPodFlags("batch, ci,", "tier in (scratch)")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"batch", "ci"}, namespaces)
+	assert.True(t, selector.Matches(labels.Set{"tier": "scratch"}))
+	assert.False(t, selector.Matches(labels.Set{"tier": "web"}))
+
+	_, _, err = parseScratchPodFlags("batch", "tier in scratch")
+	assert.Error(t, err)
+}
@@ -22,6 +22,8 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/schedule"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	kube_client "k8s.io/client-go/kubernetes"
@@ -42,6 +44,12 @@ type AutoscalingContext struct {
 	PredicateChecker *simulator.PredicateChecker
 	// ExpanderStrategy is the strategy used to choose which node group to expand when scaling up
 	ExpanderStrategy expander.Strategy
+	// MinSizeSchedules provide scheduled min sizes of node groups. Nil if schedules are disabled.
+	MinSizeSchedules *schedule.MinSizeSchedules
+	// ScaleDownBlackouts provide time windows during which scale-down is blocked. Nil if blackouts are disabled.
+	ScaleDownBlackouts *schedule.ScaleDownBlackouts
+	// ScaleDownOrder is the strategy used to order the scale-down candidates. Nil if the candidates are not reordered.
+	ScaleDownOrder scaledownorder.Strategy
 }
 
 // AutoscalingKubeClients contains all Kubernetes API clients,
@@ -26,8 +26,12 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/replay"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/schedule"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	kube_client "k8s.io/client-go/kubernetes"
 )
 
@@ -40,6 +44,10 @@ type AutoscalerOptions struct {
 	PredicateChecker       *simulator.PredicateChecker
 	ExpanderStrategy       expander.Strategy
 	Processors             *ca_processors.AutoscalingProcessors
+	LoopRecorder           replay.Recorder
+	MinSizeSchedules       *schedule.MinSizeSchedules
+	ScaleDownBlackouts     *schedule.ScaleDownBlackouts
+	ScaleDownOrder         scaledownorder.Strategy
 }
 
 // Autoscaler is the main component of CA which scales up/down node groups according to its configuration
@@ -53,15 +61,24 @@ type Autoscaler interface {
 
 // NewAutoscaler creates an autoscaler of an appropriate type according to the parameters
 func NewAutoscaler(opts AutoscalerOptions) (Autoscaler, errors.AutoscalerError) {
-	err := initializeDefaultOptions(&opts)
+	// Closed on exit, stops the watches started for the default options.
+	stopChannel := make(chan struct{})
+	err := initializeDefaultOptions(&opts, stopChannel)
 	if err != nil {
+		close(stopChannel)
 		return nil, errors.ToAutoscalerError(errors.InternalError, err)
 	}
-	return NewStaticAutoscaler(opts.AutoscalingOptions, opts.PredicateChecker, opts.AutoscalingKubeClients, opts.Processors, opts.CloudProvider, opts.ExpanderStrategy), nil
+	autoscaler := NewStaticAutoscaler(opts.AutoscalingOptions, opts.PredicateChecker, opts.AutoscalingKubeClients, opts.Processors, opts.CloudProvider, opts.ExpanderStrategy)
+	autoscaler.stopChannel = stopChannel
+	autoscaler.loopRecorder = opts.LoopRecorder
+	autoscaler.MinSizeSchedules = opts.MinSizeSchedules
+	autoscaler.ScaleDownBlackouts = opts.ScaleDownBlackouts
+	autoscaler.ScaleDownOrder = opts.ScaleDownOrder
+	return autoscaler, nil
 }
 
-// Initialize default options if not provided.
-func initializeDefaultOptions(opts *AutoscalerOptions) error {
+// Initialize default options if not provided. Watches started for them run until stopChannel is closed.
+func initializeDefaultOptions(opts *AutoscalerOptions, stopChannel <-chan struct{}) error {
 	if opts.Processors == nil {
 		opts.Processors = ca_processors.DefaultProcessors()
 	}
@@ -79,14 +96,42 @@ func initializeDefaultOptions(opts *AutoscalerOptions) error {
 	if opts.CloudProvider == nil {
 		opts.CloudProvider = cloudBuilder.NewCloudProvider(opts.AutoscalingOptions)
 	}
+	// Everything configured by config maps shares a single watch of the config maps in the config
+	// namespace, stopped on exit.
+	configMaps := kube_util.NewSharedConfigMapLister(opts.AutoscalingKubeClients.ClientSet, stopChannel, opts.ConfigNamespace)
 	if opts.ExpanderStrategy == nil {
-		expanderStrategy, err := factory.ExpanderStrategyFromString(opts.ExpanderName,
-			opts.CloudProvider, opts.AutoscalingKubeClients.AllNodeLister())
+		expanderStrategy, err := factory.ExpanderStrategyFromStrings(opts.ExpanderNames,
+			opts.CloudProvider, opts.AutoscalingKubeClients, configMaps, opts.GRPCExpander)
 		if err != nil {
 			return err
 		}
 		opts.ExpanderStrategy = expanderStrategy
 	}
+	if opts.LoopRecorder == nil && opts.LoopRecordFile != "" {
+		loopRecorder, err := replay.NewFileRecorder(opts.LoopRecordFile, opts.LoopRecordFileMaxSize)
+		if err != nil {
+			return err
+		}
+		opts.LoopRecorder = loopRecorder
+	}
+	if opts.MinSizeSchedules == nil && opts.MinSizeSchedulesEnabled {
+		opts.MinSizeSchedules = schedule.NewMinSizeSchedules(configMaps.Lister(), opts.AutoscalingKubeClients.LogRecorder)
+	}
+	if opts.ScaleDownBlackouts == nil && opts.ScaleDownBlackoutsEnabled {
+		opts.ScaleDownBlackouts = schedule.NewScaleDownBlackouts(configMaps.Lister(), opts.AutoscalingKubeClients.LogRecorder)
+	}
+	if opts.ScaleDownOrder == nil {
+		scaleDownOrder, err := scaledownorder.StrategyFromStrings(opts.ScaleDownOrderPolicies, opts.CloudProvider)
+		if err != nil {
+			return err
+		}
+		opts.ScaleDownOrder = scaleDownOrder
+	}
+	if opts.NodeConsolidationEnabled {
+		if _, err := opts.CloudProvider.Pricing(); err != nil {
+			return err.AddPrefix("node consolidation requires pricing: ")
+		}
+	}
 
 	return nil
 }
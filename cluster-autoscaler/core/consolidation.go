@@ -0,0 +1,406 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
+
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/golang/glog"
+)
+
+// ConsolidationResult represents the state of node consolidation.
+type ConsolidationResult int
+
+const (
+	// ConsolidationNone - no consolidation is in progress and none was started.
+	ConsolidationNone ConsolidationResult = iota
+	// ConsolidationScaledUp - a node group was scaled up to replace underutilized nodes.
+	ConsolidationScaledUp
+	// ConsolidationWaiting - the replacement nodes or a previous node deletion are not ready yet.
+	ConsolidationWaiting
+	// ConsolidationDrainStarted - the replaced nodes are being drained and deleted.
+	ConsolidationDrainStarted
+)
+
+// consolidationPlan describes a replacement of several nodes with fewer nodes of another node group.
+type consolidationPlan struct {
+	nodeGroup cloudprovider.NodeGroup
+	// newNodes is the number of nodes added to the node group.
+	newNodes int
+	// nodesToDrain are the names of the replaced nodes.
+	nodesToDrain []string
+	// oldPrice and newPrice are the hourly prices of the replaced nodes and of the new nodes.
+	oldPrice float64
+	newPrice float64
+}
+
+// consolidationCandidate is a node that could be replaced, with the pods that have to be moved away from it.
+// The pods are copies that are not bound to the node, so that they can be placed elsewhere in simulations.
+type consolidationCandidate struct {
+	node      *apiv1.Node
+	nodeGroup cloudprovider.NodeGroup
+	pods      []*apiv1.Pod
+	price     float64
+}
+
+// Consolidation replaces several underutilized nodes whose pods don't fit in the rest of the cluster
+// with fewer, cheaper nodes of another node group. The other node group is scaled up first and the
+// replaced nodes are drained and deleted by the scale-down once the new nodes are ready.
+type Consolidation struct {
+	context              *context.AutoscalingContext
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	scaleDown            *ScaleDown
+	// plan is the consolidation waiting for its new nodes, nil if there is none.
+	plan *consolidationPlan
+}
+
+// NewConsolidation builds new Consolidation object.
+func NewConsolidation(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry, scaleDown *ScaleDown) *Consolidation {
+	return &Consolidation{
+		context:              context,
+		clusterStateRegistry: clusterStateRegistry,
+		scaleDown:            scaleDown,
+	}
+}
+
+// Update moves the consolidation in progress forward, or looks for a new consolidation if there is
+// none and canStart is true. Pods are the scheduled pods.
+func (c *Consolidation) Update(allNodes []*apiv1.Node, readyNodes []*apiv1.Node, pods []*apiv1.Pod,
+	pdbs []*policyv1.PodDisruptionBudget, canStart bool, now time.Time) (ConsolidationResult, errors.AutoscalerError) {
+	if c.plan != nil {
+		return c.drainReplacedNodes(allNodes, readyNodes, pods, pdbs, now)
+	}
+	if !canStart {
+		return ConsolidationNone, nil
+	}
+
+	daemonSets, errList := c.context.DaemonSetLister().List()
+	if errList != nil {
+		return ConsolidationNone, errors.ToAutoscalerError(errors.ApiCallError, errList).AddPrefix("failed to list daemon sets: ")
+	}
+	nodeInfos, err := GetNodeInfosForGroups(readyNodes, c.context.CloudProvider, c.context.ClientSet,
+		daemonSets, c.context.PredicateChecker)
+	if err != nil {
+		return ConsolidationNone, err.AddPrefix("failed to build node infos for node groups: ")
+	}
+	plan, err := c.findPlan(allNodes, readyNodes, pods, pdbs, nodeInfos, now)
+	if err != nil {
+		return ConsolidationNone, err
+	}
+	if plan == nil {
+		glog.V(1).Infof("No nodes to consolidate")
+		return ConsolidationNone, nil
+	}
+
+	planner, err := newScaleUpPlanner(c.context, c.clusterStateRegistry, readyNodes, nodeInfos, now)
+	if err != nil {
+		return ConsolidationNone, err
+	}
+	if !planner.canScaleUp(plan.nodeGroup) || planner.addNodes(plan.nodeGroup, plan.newNodes) < plan.newNodes {
+		glog.V(1).Infof("Consolidation: can't add %d nodes to %s within the limits", plan.newNodes, plan.nodeGroup.Id())
+		return ConsolidationNone, nil
+	}
+	nodesToDrain := strings.Join(plan.nodesToDrain, ",")
+	if c.context.DryRun {
+		if _, err := planner.execute(); err != nil {
+			return ConsolidationNone, err
+		}
+		glog.V(0).Infof("Consolidation (dry run): would replace nodes %s (%v per hour) with %d nodes of %s (%v per hour)",
+			nodesToDrain, plan.oldPrice, plan.newNodes, plan.nodeGroup.Id(), plan.newPrice)
+		c.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ConsolidationDryRun",
+			"Consolidation (dry run): would replace nodes %s with %d nodes of %s", nodesToDrain, plan.newNodes, plan.nodeGroup.Id())
+		return ConsolidationNone, nil
+	}
+
+	glog.V(0).Infof("Consolidation: replacing nodes %s (%v per hour) with %d nodes of %s (%v per hour)",
+		nodesToDrain, plan.oldPrice, plan.newNodes, plan.nodeGroup.Id(), plan.newPrice)
+	c.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "Consolidation",
+		"Consolidation: replacing nodes %s with %d nodes of %s", nodesToDrain, plan.newNodes, plan.nodeGroup.Id())
+	if _, err := planner.execute(); err != nil {
+		return ConsolidationNone, err.AddPrefix("failed to scale up for consolidation: ")
+	}
+	c.plan = plan
+	return ConsolidationScaledUp, nil
+}
+
+// drainReplacedNodes drains and deletes the nodes replaced by the consolidation in progress once the new
+// nodes are ready. Only the nodes whose pods fit in the ready nodes and that can be removed within the
+// scale-down limits are deleted; if the new nodes failed to come up, or their room was taken in the
+// meantime, the consolidation is given up.
+func (c *Consolidation) drainReplacedNodes(allNodes []*apiv1.Node, readyNodes []*apiv1.Node, pods []*apiv1.Pod,
+	pdbs []*policyv1.PodDisruptionBudget, now time.Time) (ConsolidationResult, errors.AutoscalerError) {
+	if c.clusterStateRegistry.IsNodeGroupScalingUp(c.plan.nodeGroup.Id()) {
+		glog.V(1).Infof("Consolidation: waiting for new nodes of %s", c.plan.nodeGroup.Id())
+		return ConsolidationWaiting, nil
+	}
+	if c.scaleDown.nodeDeleteStatus.IsDeleteInProgress() {
+		glog.V(1).Infof("Consolidation: waiting for the node deletion in progress")
+		return ConsolidationWaiting, nil
+	}
+	plan := c.plan
+	c.plan = nil
+
+	toDrain := make(map[string]bool, len(plan.nodesToDrain))
+	for _, name := range plan.nodesToDrain {
+		toDrain[name] = true
+	}
+	nodesWithoutMaster := filterOutMasters(readyNodes, pods)
+	candidates := make([]*apiv1.Node, 0, len(plan.nodesToDrain))
+	for _, node := range nodesWithoutMaster {
+		if toDrain[node.Name] {
+			candidates = append(candidates, node)
+		}
+	}
+	candidateNodeGroups := getRemovableNodeGroups(c.context, candidates, now)
+	removable := make([]*apiv1.Node, 0, len(candidates))
+	for _, node := range candidates {
+		if _, found := candidateNodeGroups[node.Name]; found {
+			removable = append(removable, node)
+		}
+	}
+
+	nonExpendablePods := FilterOutExpendablePods(pods, c.context.ExpendablePodsPriorityCutoff)
+	nodesToRemove, _, _, err := simulator.FindNodesToRemove(removable, nodesWithoutMaster, nonExpendablePods, c.context.ClientSet,
+		c.context.PredicateChecker, len(removable), false, c.scaleDown.podLocationHints, c.scaleDown.usageTracker, now,
+		getScratchPodSelector(c.context), pdbs)
+	if err != nil {
+		return ConsolidationNone, err.AddPrefix("failed to find replaced nodes to remove: ")
+	}
+	// Min sizes, resource limits and headroom may have changed since the consolidation was planned.
+	resourceLimiter, errCP := c.context.CloudProvider.GetResourceLimiter()
+	if errCP != nil {
+		return ConsolidationNone, errors.ToAutoscalerError(errors.CloudProviderError, errCP)
+	}
+	allNodesWithoutMaster := filterOutMasters(allNodes, pods)
+	nodesToRemove = filterNodesToRemoveWithinLimits(nodesToRemove, getNodeGroupSizeMap(c.context.CloudProvider), candidateNodeGroups,
+		computeScaleDownResourcesLeftLimits(allNodesWithoutMaster, resourceLimiter, c.context.CloudProvider, now),
+		resourceLimiter.GetResources(), computeHeadroomLimits(c.context, allNodesWithoutMaster, pods, now), c.context, now)
+	if len(nodesToRemove) == 0 {
+		glog.Warningf("Consolidation: giving up, pods of nodes %s don't fit in the cluster", strings.Join(plan.nodesToDrain, ","))
+		c.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "ConsolidationFailed",
+			"Consolidation: giving up, pods of nodes %s don't fit in the cluster", strings.Join(plan.nodesToDrain, ","))
+		return ConsolidationNone, nil
+	}
+	reasons := make(map[string]metrics.NodeScaleDownReason, len(nodesToRemove))
+	for _, toRemove := range nodesToRemove {
+		reasons[toRemove.Node.Name] = metrics.Consolidated
+	}
+	c.scaleDown.startNodeDeletions(nodesToRemove, candidateNodeGroups, reasons)
+	return ConsolidationDrainStarted, nil
+}
+
+// findPlan returns the consolidation saving the most, or nil if no consolidation saves enough.
+func (c *Consolidation) findPlan(allNodes []*apiv1.Node, readyNodes []*apiv1.Node, pods []*apiv1.Pod,
+	pdbs []*policyv1.PodDisruptionBudget, nodeInfos map[string]*schedulercache.NodeInfo, now time.Time) (*consolidationPlan, errors.AutoscalerError) {
+	pricingModel, err := c.context.CloudProvider.Pricing()
+	if err != nil {
+		return nil, err.AddPrefix("failed to get pricing model: ")
+	}
+	resourceLimiter, errCP := c.context.CloudProvider.GetResourceLimiter()
+	if errCP != nil {
+		return nil, errors.ToAutoscalerError(errors.CloudProviderError, errCP)
+	}
+	candidates := c.findCandidates(readyNodes, pods, pdbs, pricingModel, now)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	nodeGroupSize := getNodeGroupSizeMap(c.context.CloudProvider)
+	resourcesLeft := computeScaleDownResourcesLeftLimits(filterOutMasters(allNodes, pods), resourceLimiter, c.context.CloudProvider, now)
+
+	var best *consolidationPlan
+	for _, nodeGroup := range c.context.CloudProvider.NodeGroups() {
+		nodeInfo, found := nodeInfos[nodeGroup.Id()]
+		if !found || !nodeGroup.Exist() || !c.clusterStateRegistry.IsNodeGroupSafeToScaleUp(nodeGroup.Id(), now) {
+			continue
+		}
+		templatePrice, err := pricingModel.NodePrice(nodeInfo.Node(), now, now.Add(time.Hour))
+		if err != nil {
+			glog.Warningf("Failed to get price of the template node of %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		plan := c.planForNodeGroup(nodeGroup, nodeInfo, templatePrice, candidates, nodeGroupSize, resourcesLeft,
+			resourceLimiter.GetResources(), now)
+		if plan != nil && (best == nil || plan.oldPrice-plan.newPrice > best.oldPrice-best.newPrice) {
+			best = plan
+		}
+	}
+	return best, nil
+}
+
+// findCandidates returns the underutilized nodes that could be replaced, in the order they should be
+// replaced in. Empty nodes are left to the scale-down.
+func (c *Consolidation) findCandidates(readyNodes []*apiv1.Node, pods []*apiv1.Pod, pdbs []*policyv1.PodDisruptionBudget,
+	pricingModel cloudprovider.PricingModel, now time.Time) []consolidationCandidate {
+	nonExpendablePods := FilterOutExpendablePods(pods, c.context.ExpendablePodsPriorityCutoff)
+	nodes := filterOutMasters(readyNodes, pods)
+	nodeNameToNodeInfo := scheduler_util.CreateNodeNameToInfoMap(nonExpendablePods, nodes)
+	nodeGroups := getRemovableNodeGroups(c.context, nodes, now)
+	scratchPods := getScratchPodSelector(c.context)
+
+	utilization := make(map[string]float64)
+	byName := make(map[string]consolidationCandidate)
+	underutilized := make([]*apiv1.Node, 0)
+	for _, node := range nodes {
+		nodeGroup, found := nodeGroups[node.Name]
+		nodeInfo, hasInfo := nodeNameToNodeInfo[node.Name]
+		if !found || !hasInfo {
+			continue
+		}
+		nodeUtilization, err := simulator.CalculateUtilization(node, nodeInfo)
+		if err != nil {
+			glog.Warningf("Failed to calculate utilization for %s: %v", node.Name, err)
+			continue
+		}
+		threshold := cloudprovider.GetNodeGroupOptions(nodeGroup, c.context.NodeGroupDefaults()).ScaleDownUtilizationThreshold
+		if nodeUtilization >= threshold {
+			continue
+		}
+		podsToMove, err := simulator.GetPodsToMove(nodeInfo, nil, true, scratchPods, pdbs)
+		if err != nil {
+			glog.V(4).Infof("Consolidation: node %s can't be replaced: %v", node.Name, err)
+			continue
+		}
+		if len(podsToMove) == 0 {
+			continue
+		}
+		price, err := pricingModel.NodePrice(node, now, now.Add(time.Hour))
+		if err != nil {
+			glog.Warningf("Failed to get price of node %s: %v", node.Name, err)
+			continue
+		}
+		podsToPlace := make([]*apiv1.Pod, 0, len(podsToMove))
+		for _, podptr := range podsToMove {
+			newpod := *podptr
+			newpod.Spec.NodeName = ""
+			podsToPlace = append(podsToPlace, &newpod)
+		}
+		utilization[node.Name] = nodeUtilization
+		byName[node.Name] = consolidationCandidate{node: node, nodeGroup: nodeGroup, pods: podsToPlace, price: price}
+		underutilized = append(underutilized, node)
+	}
+
+	order := c.context.ScaleDownOrder
+	if order == nil {
+		order = scaledownorder.NewStrategy(scaledownorder.NewUtilizationPolicy())
+	}
+	candidates := make([]consolidationCandidate, 0, len(underutilized))
+	for _, node := range order.Order(underutilized, utilization, now) {
+		candidates = append(candidates, byName[node.Name])
+	}
+	return candidates
+}
+
+// planForNodeGroup picks the candidates to be replaced with new nodes of the node group. Candidates are
+// added one by one, and the set saving the most is returned, or nil if no set saves enough.
+func (c *Consolidation) planForNodeGroup(nodeGroup cloudprovider.NodeGroup, nodeInfo *schedulercache.NodeInfo, templatePrice float64,
+	candidates []consolidationCandidate, nodeGroupSize map[string]int, resourcesLeft scaleDownResourcesLimits,
+	resourcesWithLimits []string, now time.Time) *consolidationPlan {
+	maxNewNodes := nodeGroup.MaxSize() - nodeGroupSize[nodeGroup.Id()]
+	if maxNewNodes <= 0 {
+		return nil
+	}
+	resourcesLeft = copyScaleDownResourcesLimits(resourcesLeft)
+	removedFromGroup := make(map[string]int)
+	selected := make([]string, 0)
+	podsToPlace := make([]*apiv1.Pod, 0)
+	oldPrice := 0.0
+
+	var best *consolidationPlan
+	for _, candidate := range candidates {
+		if len(selected) >= c.context.MaxConsolidatedNodes {
+			break
+		}
+		groupId := candidate.nodeGroup.Id()
+		if groupId == nodeGroup.Id() || !podsFitNode(c.context.PredicateChecker, candidate.pods, nodeInfo) {
+			continue
+		}
+		if nodeGroupSize[groupId]-removedFromGroup[groupId] <= getMinSize(c.context, candidate.nodeGroup, now) {
+			continue
+		}
+		delta, err := computeScaleDownResourcesDelta(candidate.node, candidate.nodeGroup, resourcesWithLimits)
+		if err != nil {
+			glog.Errorf("Error getting node resources: %v", err)
+			continue
+		}
+		if checkResult := resourcesLeft.tryDecrementLimitsByDelta(delta); checkResult.exceeded {
+			continue
+		}
+		removedFromGroup[groupId]++
+		selected = append(selected, candidate.node.Name)
+		podsToPlace = append(podsToPlace, candidate.pods...)
+		oldPrice += candidate.price
+
+		newNodes := estimateNodeCount(c.context, podsToPlace, nodeInfo)
+		if newNodes >= len(selected) || newNodes > maxNewNodes {
+			continue
+		}
+		newPrice := float64(newNodes) * templatePrice
+		if oldPrice-newPrice <= 0 || oldPrice-newPrice < oldPrice*c.context.ConsolidationMinSavingsRatio {
+			continue
+		}
+		if best == nil || oldPrice-newPrice > best.oldPrice-best.newPrice {
+			best = &consolidationPlan{
+				nodeGroup:    nodeGroup,
+				newNodes:     newNodes,
+				nodesToDrain: append([]string{}, selected...),
+				oldPrice:     oldPrice,
+				newPrice:     newPrice,
+			}
+		}
+	}
+	return best
+}
+
+// podsFitNode tells whether each of the pods fits on its own on the node.
+func podsFitNode(predicateChecker *simulator.PredicateChecker, pods []*apiv1.Pod, nodeInfo *schedulercache.NodeInfo) bool {
+	for _, pod := range pods {
+		if err := predicateChecker.CheckPredicates(pod, nil, nodeInfo); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// estimateNodeCount returns the number of nodes like the template needed to run the pods, using the
+// configured estimator.
+func estimateNodeCount(context *context.AutoscalingContext, pods []*apiv1.Pod, nodeInfo *schedulercache.NodeInfo) int {
+	if context.EstimatorName == estimator.BasicEstimatorName {
+		basicEstimator := estimator.NewBasicNodeEstimator()
+		for _, pod := range pods {
+			basicEstimator.Add(pod)
+		}
+		count, _ := basicEstimator.Estimate(nodeInfo.Node(), nil)
+		return count
+	}
+	binpackingEstimator := estimator.NewBinpackingNodeEstimator(context.PredicateChecker)
+	return binpackingEstimator.Estimate(pods, nodeInfo, nil)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	kube_record "k8s.io/client-go/tools/record"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pricedTestCloudProvider is a test cloud provider pricing nodes by their number of cores.
+type pricedTestCloudProvider struct {
+	*testprovider.TestCloudProvider
+}
+
+func (p *pricedTestCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
+	return &coresPricingModel{}, nil
+}
+
+type coresPricingModel struct{}
+
+func (m *coresPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	cpu := node.Status.Capacity[apiv1.ResourceCPU]
+	return float64(cpu.MilliValue()) / 1000 * 0.1 * endTime.Sub(startTime).Hours(), nil
+}
+
+func (m *coresPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0.0, fmt.Errorf("price for pod %v not found", pod.Name)
+}
+
+// runConsolidation replaces n1 and n2 with a new node and returns the nodes deleted once the new node
+// is ready, with the given cluster headroom set in the meantime.
+func runConsolidation(t *testing.T, headroomCores int64) []string {
+	deletedNodes := make(chan string, 10)
+	fakeClient := &fake.Clientset{}
+
+	n1 := BuildTestNode("n1", 4000, 4000*MB)
+	SetNodeReadyState(n1, true, time.Time{})
+	n2 := BuildTestNode("n2", 4000, 4000*MB)
+	SetNodeReadyState(n2, true, time.Time{})
+	n3 := BuildTestNode("n3", 4000, 4000*MB)
+	SetNodeReadyState(n3, true, time.Time{})
+	p1 := BuildTestPod("p1", 500, 100*MB)
+	p1.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p1.Spec.NodeName = "n1"
+	p2 := BuildTestPod("p2", 500, 100*MB)
+	p2.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p2.Spec.NodeName = "n2"
+	p3 := BuildTestPod("p3", 3500, 100*MB)
+	p3.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p3.Spec.NodeName = "n3"
+	nodesByName := map[string]*apiv1.Node{"n1": n1, "n2": n2, "n3": n3}
+
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+	})
+	fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, kube_errors.NewNotFound(apiv1.Resource("pod"), "whatever")
+	})
+	fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		getAction := action.(core.GetAction)
+		if node, found := nodesByName[getAction.GetName()]; found {
+			return true, node, nil
+		}
+		return true, nil, fmt.Errorf("Wrong node: %v", getAction.GetName())
+	})
+	fakeClient.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		update := action.(core.UpdateAction)
+		return true, update.GetObject(), nil
+	})
+
+	small := BuildTestNode("small", 2000, 2000*MB)
+	SetNodeReadyState(small, true, time.Time{})
+	smallTemplate := schedulercache.NewNodeInfo()
+	smallTemplate.SetNode(small)
+
+	increases := make(map[string]int)
+	provider := &pricedTestCloudProvider{testprovider.NewTestAutoprovisioningCloudProvider(
+		func(nodeGroup string, increase int) error {
+			increases[nodeGroup] += increase
+			return nil
+		}, func(nodeGroup string, node string) error {
+			deletedNodes <- node
+			return nil
+		}, nil, nil, nil, map[string]*schedulercache.NodeInfo{"ng2": smallTemplate})}
+	provider.AddNodeGroup("ng1", 1, 10, 3)
+	provider.AddNodeGroup("ng2", 0, 10, 0)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng1", n3)
+
+	options := config.AutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		MaxGracefulTerminationSec:     60,
+		MaxConsolidatedNodes:          5,
+		ConsolidationMinSavingsRatio:  0.2,
+		MaxCoresTotal:                 config.DefaultMaxClusterCores,
+		MaxMemoryTotal:                config.DefaultMaxClusterMemory * 1024 * MB,
+	}
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+	// Draining two nodes records more events than the default fake recorder holds.
+	context.Recorder = kube_record.NewFakeRecorder(100)
+	daemonSetLister := &daemonSetListerMock{}
+	daemonSetLister.On("List").Return([]*extensionsv1.DaemonSet{}, nil)
+	context.ListerRegistry = kube_util.NewListerRegistry(nil, nil, nil, nil, nil, daemonSetLister)
+
+	nodes := []*apiv1.Node{n1, n2, n3}
+	pods := []*apiv1.Pod{p1, p2, p3}
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes(nodes, time.Now())
+	scaleDown := NewScaleDown(&context, clusterState)
+	consolidation := NewConsolidation(&context, clusterState, scaleDown)
+
+	// Nothing is started unless asked to.
+	result, err := consolidation.Update(nodes, nodes, pods, nil, false, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ConsolidationNone, result)
+	assert.Empty(t, increases)
+
+	// n1 and n2 (0.4 per hour each) are replaced with a single node of ng2 (0.2 per hour). n3 is too
+	// utilized to be replaced.
+	result, err = consolidation.Update(nodes, nodes, pods, nil, true, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ConsolidationScaledUp, result)
+	assert.Equal(t, map[string]int{"ng2": 1}, increases)
+
+	// The new node is not there yet.
+	result, err = consolidation.Update(nodes, nodes, pods, nil, true, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ConsolidationWaiting, result)
+
+	n4 := BuildTestNode("n4", 2000, 2000*MB)
+	SetNodeReadyState(n4, true, time.Time{})
+	provider.AddNode("ng2", n4)
+	nodesByName["n4"] = n4
+	nodes = []*apiv1.Node{n1, n2, n3, n4}
+	clusterState.UpdateNodes(nodes, time.Now())
+	context.HeadroomCores = headroomCores
+
+	result, err = consolidation.Update(nodes, nodes, pods, nil, true, time.Now())
+	waitForDeleteToFinish(t, scaleDown)
+	assert.NoError(t, err)
+	assert.Equal(t, ConsolidationDrainStarted, result)
+	deleted := make([]string, 0)
+	for node := getStringFromChanImmediately(deletedNodes); node != "Nothing returned"; node = getStringFromChanImmediately(deletedNodes) {
+		deleted = append(deleted, node)
+	}
+	sort.Strings(deleted)
+	return deleted
+}
+
+func TestConsolidation(t *testing.T) {
+	assert.Equal(t, []string{"n1", "n2"}, runConsolidation(t, 0))
+}
+
+func TestConsolidationDrainKeepsHeadroom(t *testing.T) {
+	// 9.5 cores are free with the new node, removing both replaced nodes would leave 1.5.
+	assert.Equal(t, 1, len(runConsolidation(t, 5)))
+}
+
+func TestConsolidationNotWorthIt(t *testing.T) {
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+	})
+
+	n1 := BuildTestNode("n1", 2000, 2000*MB)
+	SetNodeReadyState(n1, true, time.Time{})
+	n2 := BuildTestNode("n2", 2000, 2000*MB)
+	SetNodeReadyState(n2, true, time.Time{})
+	p1 := BuildTestPod("p1", 500, 100*MB)
+	p1.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p1.Spec.NodeName = "n1"
+	p2 := BuildTestPod("p2", 500, 100*MB)
+	p2.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p2.Spec.NodeName = "n2"
+
+	big := BuildTestNode("big", 4000, 4000*MB)
+	SetNodeReadyState(big, true, time.Time{})
+	bigTemplate := schedulercache.NewNodeInfo()
+	bigTemplate.SetNode(big)
+
+	increases := make(map[string]int)
+	provider := &pricedTestCloudProvider{testprovider.NewTestAutoprovisioningCloudProvider(
+		func(nodeGroup string, increase int) error {
+			increases[nodeGroup] += increase
+			return nil
+		}, nil, nil, nil, nil, map[string]*schedulercache.NodeInfo{"ng2": bigTemplate})}
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNodeGroup("ng2", 0, 10, 0)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+
+	options := config.AutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		MaxConsolidatedNodes:          5,
+		ConsolidationMinSavingsRatio:  0.2,
+		MaxCoresTotal:                 config.DefaultMaxClusterCores,
+		MaxMemoryTotal:                config.DefaultMaxClusterMemory * 1024 * MB,
+	}
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+	daemonSetLister := &daemonSetListerMock{}
+	daemonSetLister.On("List").Return([]*extensionsv1.DaemonSet{}, nil)
+	context.ListerRegistry = kube_util.NewListerRegistry(nil, nil, nil, nil, nil, daemonSetLister)
+
+	nodes := []*apiv1.Node{n1, n2}
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes(nodes, time.Now())
+	consolidation := NewConsolidation(&context, clusterState, NewScaleDown(&context, clusterState))
+
+	// A single node of ng2 costs as much as n1 and n2 together.
+	result, err := consolidation.Update(nodes, nodes, []*apiv1.Pod{p1, p2}, nil, true, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ConsolidationNone, result)
+	assert.Empty(t, increases)
+}
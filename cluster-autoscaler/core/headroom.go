@@ -0,0 +1,536 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupset"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/golang/glog"
+)
+
+// headroomResources is an amount of cpu (in millicores) and memory (in bytes) that is not requested by any pod.
+type headroomResources struct {
+	milliCPU int64
+	memory   int64
+}
+
+func (r headroomResources) add(other headroomResources) headroomResources {
+	return headroomResources{milliCPU: r.milliCPU + other.milliCPU, memory: r.memory + other.memory}
+}
+
+func (r headroomResources) sub(other headroomResources) headroomResources {
+	return headroomResources{milliCPU: r.milliCPU - other.milliCPU, memory: r.memory - other.memory}
+}
+
+func (r headroomResources) times(n int) headroomResources {
+	return headroomResources{milliCPU: r.milliCPU * int64(n), memory: r.memory * int64(n)}
+}
+
+func (r headroomResources) isZero() bool {
+	return r.milliCPU <= 0 && r.memory <= 0
+}
+
+// covers tells whether r is at least the required amount of every resource that is required at all.
+func (r headroomResources) covers(required headroomResources) bool {
+	return (required.milliCPU <= 0 || r.milliCPU >= required.milliCPU) &&
+		(required.memory <= 0 || r.memory >= required.memory)
+}
+
+// nodesToCover returns how many nodes of the given size are needed to add the missing resources,
+// or 0 if nodes of this size can't add them.
+func nodesToCover(missing headroomResources, nodeSize headroomResources) int {
+	count := int64(0)
+	for _, r := range []struct{ missing, size int64 }{
+		{missing.milliCPU, nodeSize.milliCPU},
+		{missing.memory, nodeSize.memory},
+	} {
+		if r.missing <= 0 {
+			continue
+		}
+		if r.size <= 0 {
+			return 0
+		}
+		if n := (r.missing + r.size - 1) / r.size; n > count {
+			count = n
+		}
+	}
+	return int(count)
+}
+
+func podsRequests(pods []*apiv1.Pod) headroomResources {
+	requested := schedulercache.NewNodeInfo(pods...).RequestedResource()
+	return headroomResources{milliCPU: requested.MilliCPU, memory: requested.Memory}
+}
+
+func nodeAllocatable(node *apiv1.Node) headroomResources {
+	result := headroomResources{}
+	if cpu, found := node.Status.Allocatable[apiv1.ResourceCPU]; found {
+		result.milliCPU = cpu.MilliValue()
+	}
+	if memory, found := node.Status.Allocatable[apiv1.ResourceMemory]; found {
+		result.memory = memory.Value()
+	}
+	return result
+}
+
+func isDaemonSetOrMirrorPod(pod *apiv1.Pod) bool {
+	if drain.IsMirrorPod(pod) {
+		return true
+	}
+	controllerRef := drain.ControllerRef(pod)
+	return controllerRef != nil && controllerRef.Kind == "DaemonSet"
+}
+
+// nodeHeadroomSize returns the free capacity of the node once all pods that can be moved away are
+// gone, that is its allocatable resources less the requests of DaemonSet and mirror pods.
+func nodeHeadroomSize(node *apiv1.Node, pods []*apiv1.Pod) headroomResources {
+	requiredPods := make([]*apiv1.Pod, 0)
+	for _, pod := range pods {
+		if isDaemonSetOrMirrorPod(pod) {
+			requiredPods = append(requiredPods, pod)
+		}
+	}
+	return nodeAllocatable(node).sub(podsRequests(requiredPods))
+}
+
+// templateHeadroomSize returns the free capacity of a new node built from the node group template.
+func templateHeadroomSize(nodeInfo *schedulercache.NodeInfo) headroomResources {
+	return nodeHeadroomSize(nodeInfo.Node(), nodeInfo.Pods())
+}
+
+// max returns the largest amount of every resource of r and other.
+func (r headroomResources) max(other headroomResources) headroomResources {
+	if other.milliCPU > r.milliCPU {
+		r.milliCPU = other.milliCPU
+	}
+	if other.memory > r.memory {
+		r.memory = other.memory
+	}
+	return r
+}
+
+// freeResources is the capacity of ready nodes not requested by non-expendable pods.
+type freeResources struct {
+	cluster    headroomResources
+	nodeGroups map[string]headroomResources
+	// nodeSizes contains the headroom size of every counted node.
+	nodeSizes map[string]headroomResources
+}
+
+// computeFreeResources returns the free capacity of the given nodes. Nodes whose node group can't
+// be found are not counted.
+func computeFreeResources(context *context.AutoscalingContext, nodes []*apiv1.Node, pods []*apiv1.Pod,
+	timestamp time.Time) *freeResources {
+	podsByNode := make(map[string][]*apiv1.Pod)
+	for _, pod := range FilterOutExpendablePods(pods, context.ExpendablePodsPriorityCutoff) {
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+	result := &freeResources{
+		nodeGroups: make(map[string]headroomResources),
+		nodeSizes:  make(map[string]headroomResources),
+	}
+	for _, node := range nodes {
+		// Capacity of nodes that can't run pods doesn't help anyone.
+		if !kube_util.IsNodeReadyAndSchedulable(node) || isNodeBeingDeleted(node, timestamp) {
+			continue
+		}
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			glog.Warningf("Failed to get node group for %s, not counting its free capacity: %v", node.Name, err)
+			continue
+		}
+		free := nodeAllocatable(node).sub(podsRequests(podsByNode[node.Name]))
+		result.cluster = result.cluster.add(free)
+		result.nodeSizes[node.Name] = nodeHeadroomSize(node, podsByNode[node.Name])
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		result.nodeGroups[nodeGroup.Id()] = result.nodeGroups[nodeGroup.Id()].add(free)
+	}
+	return result
+}
+
+// isClusterHeadroomConfigured tells whether any free capacity should be kept in the whole cluster.
+func isClusterHeadroomConfigured(context *context.AutoscalingContext) bool {
+	return context.HeadroomCores > 0 || context.HeadroomMemory > 0 || context.ClusterHeadroomNodes > 0
+}
+
+// getClusterHeadroom returns the free capacity that should be kept in the whole cluster. Headroom
+// in nodes is measured in the largest amount of every resource a counted node can offer.
+func getClusterHeadroom(context *context.AutoscalingContext, free *freeResources) headroomResources {
+	headroom := headroomResources{milliCPU: context.HeadroomCores * 1000, memory: context.HeadroomMemory}
+	if context.ClusterHeadroomNodes <= 0 {
+		return headroom
+	}
+	largestNode := headroomResources{}
+	for _, nodeSize := range free.nodeSizes {
+		largestNode = largestNode.max(nodeSize)
+	}
+	return headroom.add(largestNode.times(context.ClusterHeadroomNodes))
+}
+
+// nodeGroupHeadroom is the free capacity that should be kept in a node group: a number of empty
+// nodes worth of capacity and an amount of resources on top of it.
+type nodeGroupHeadroom struct {
+	nodes     int
+	resources headroomResources
+}
+
+// required returns the free capacity the headroom requires for nodes of the given headroom size.
+func (h nodeGroupHeadroom) required(nodeSize headroomResources) headroomResources {
+	return nodeSize.times(h.nodes).add(h.resources)
+}
+
+// getNodeGroupHeadroom returns the headroom that should be kept in each node group. Node groups
+// without headroom are omitted.
+func getNodeGroupHeadroom(context *context.AutoscalingContext) map[string]nodeGroupHeadroom {
+	result := make(map[string]nodeGroupHeadroom)
+	defaults := context.NodeGroupDefaults()
+	for _, nodeGroup := range context.CloudProvider.NodeGroups() {
+		options := cloudprovider.GetNodeGroupOptions(nodeGroup, defaults)
+		headroom := nodeGroupHeadroom{
+			nodes:     options.HeadroomNodes,
+			resources: headroomResources{milliCPU: options.HeadroomCores * 1000, memory: options.HeadroomMemory},
+		}
+		if headroom.nodes > 0 || !headroom.resources.isZero() {
+			result[nodeGroup.Id()] = headroom
+		}
+	}
+	return result
+}
+
+// ScaleUpForHeadroom scales up the cluster if its free capacity, counting the nodes that are still
+// being provisioned, is below the configured headroom. Headroom of a node group is restored by
+// scaling up the node group itself, headroom of the whole cluster by scaling up the node group
+// picked by the expander. Nodes and pods are the ready nodes and the pods scheduled on them.
+func ScaleUpForHeadroom(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry,
+	nodes []*apiv1.Node, pods []*apiv1.Pod, now time.Time) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	nodeGroupHeadroom := getNodeGroupHeadroom(context)
+	if !isClusterHeadroomConfigured(context) && len(nodeGroupHeadroom) == 0 {
+		return &status.ScaleUpStatus{ScaledUp: false}, nil
+	}
+
+	daemonSets, errList := context.DaemonSetLister().List()
+	if errList != nil {
+		return nil, errors.ToAutoscalerError(errors.ApiCallError, errList).AddPrefix("failed to list daemon sets: ")
+	}
+
+	nodeInfos, err := GetNodeInfosForGroups(nodes, context.CloudProvider, context.ClientSet,
+		daemonSets, context.PredicateChecker)
+	if err != nil {
+		return nil, err.AddPrefix("failed to build node infos for node groups: ")
+	}
+	free := computeFreeResources(context, nodes, pods, now)
+	clusterHeadroom := getClusterHeadroom(context, free)
+	// Upcoming nodes will be empty once they register.
+	for nodeGroupId, numberOfNodes := range clusterStateRegistry.GetUpcomingNodes() {
+		nodeInfo, found := nodeInfos[nodeGroupId]
+		if !found {
+			continue
+		}
+		upcoming := templateHeadroomSize(nodeInfo).times(numberOfNodes)
+		free.cluster = free.cluster.add(upcoming)
+		free.nodeGroups[nodeGroupId] = free.nodeGroups[nodeGroupId].add(upcoming)
+	}
+
+	planner, err := newScaleUpPlanner(context, clusterStateRegistry, nodes, nodeInfos, now)
+	if err != nil {
+		return nil, err
+	}
+	nodeGroups := context.CloudProvider.NodeGroups()
+
+	for _, nodeGroup := range nodeGroups {
+		headroom, found := nodeGroupHeadroom[nodeGroup.Id()]
+		if !found || !planner.canScaleUp(nodeGroup) {
+			continue
+		}
+		nodeSize := templateHeadroomSize(nodeInfos[nodeGroup.Id()])
+		missing := headroom.required(nodeSize).sub(free.nodeGroups[nodeGroup.Id()])
+		if added := planner.addNodes(nodeGroup, nodesToCover(missing, nodeSize)); added > 0 {
+			glog.V(1).Infof("Adding %d nodes to %s to restore its headroom", added, nodeGroup.Id())
+			free.cluster = free.cluster.add(nodeSize.times(added))
+		}
+	}
+
+	if missing := clusterHeadroom.sub(free.cluster); !free.cluster.covers(clusterHeadroom) {
+		options := make([]expander.Option, 0)
+		for _, nodeGroup := range nodeGroups {
+			if !planner.canScaleUp(nodeGroup) {
+				continue
+			}
+			if count := nodesToCover(missing, templateHeadroomSize(nodeInfos[nodeGroup.Id()])); count > 0 {
+				options = append(options, expander.Option{
+					NodeGroup: nodeGroup,
+					NodeCount: count,
+					Pods:      []*apiv1.Pod{},
+					Debug:     fmt.Sprintf("%d nodes needed to restore cluster headroom", count),
+				})
+			}
+		}
+		if bestOption := context.ExpanderStrategy.BestOption(options, nodeInfos); bestOption != nil {
+			if added := planner.addNodes(bestOption.NodeGroup, bestOption.NodeCount); added > 0 {
+				glog.V(1).Infof("Adding %d nodes to %s to restore cluster headroom", added, bestOption.NodeGroup.Id())
+			}
+		} else {
+			glog.V(1).Info("No node group can restore cluster headroom")
+		}
+	}
+
+	if len(planner.scaleUpInfos) == 0 {
+		return &status.ScaleUpStatus{ScaledUp: false}, nil
+	}
+	glog.V(1).Infof("Final headroom scale-up plan: %v", planner.plan())
+	return planner.execute()
+}
+
+// scaleUpPlanner collects the node groups to be scaled up outside of the scale-up for pending pods,
+// e.g. for headroom, keeping the new sizes within node group, cluster size and resource limits.
+type scaleUpPlanner struct {
+	context              *context.AutoscalingContext
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	nodeInfos            map[string]*schedulercache.NodeInfo
+	resourceLimiter      *cloudprovider.ResourceLimiter
+	resourcesLeft        scaleUpResourcesLimits
+	// nodesLeft is the number of nodes that can still be added to the cluster, negative if unlimited.
+	nodesLeft    int
+	scaleUpInfos map[string]*nodegroupset.ScaleUpInfo
+	now          time.Time
+}
+
+// newScaleUpPlanner returns a planner with nothing planned yet. Nodes are the ready nodes.
+func newScaleUpPlanner(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry,
+	nodes []*apiv1.Node, nodeInfos map[string]*schedulercache.NodeInfo, now time.Time) (*scaleUpPlanner, errors.AutoscalerError) {
+	nodesFromNotAutoscaledGroups, err := FilterOutNodesFromNotAutoscaledGroups(nodes, context.CloudProvider)
+	if err != nil {
+		return nil, err.AddPrefix("failed to filter out nodes which are from not autoscaled groups: ")
+	}
+	resourceLimiter, errCP := context.CloudProvider.GetResourceLimiter()
+	if errCP != nil {
+		return nil, errors.ToAutoscalerError(errors.CloudProviderError, errCP)
+	}
+	scaleUpResourcesLeft, err := computeScaleUpResourcesLeftLimits(context.CloudProvider.NodeGroups(), nodeInfos, nodesFromNotAutoscaledGroups, resourceLimiter)
+	if err != nil {
+		return nil, err.AddPrefix("could not compute total resources: ")
+	}
+
+	planner := &scaleUpPlanner{
+		context:              context,
+		clusterStateRegistry: clusterStateRegistry,
+		nodeInfos:            nodeInfos,
+		resourceLimiter:      resourceLimiter,
+		resourcesLeft:        scaleUpResourcesLeft,
+		nodesLeft:            -1,
+		scaleUpInfos:         make(map[string]*nodegroupset.ScaleUpInfo),
+		now:                  now,
+	}
+	if context.MaxNodesTotal > 0 {
+		planner.nodesLeft = context.MaxNodesTotal - len(nodes)
+	}
+	return planner, nil
+}
+
+func (p *scaleUpPlanner) canScaleUp(nodeGroup cloudprovider.NodeGroup) bool {
+	if !nodeGroup.Exist() || !p.clusterStateRegistry.IsNodeGroupSafeToScaleUp(nodeGroup.Id(), p.now) {
+		return false
+	}
+	if _, found := p.nodeInfos[nodeGroup.Id()]; !found {
+		glog.Errorf("No node info for: %s", nodeGroup.Id())
+		return false
+	}
+	return p.nodesLeft != 0
+}
+
+// addNodes plans adding up to count nodes to the node group and returns the number of nodes added to the plan.
+func (p *scaleUpPlanner) addNodes(nodeGroup cloudprovider.NodeGroup, count int) int {
+	info, found := p.scaleUpInfos[nodeGroup.Id()]
+	if !found {
+		currentSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			glog.Errorf("Failed to get node group size: %v", err)
+			return 0
+		}
+		info = &nodegroupset.ScaleUpInfo{Group: nodeGroup, CurrentSize: currentSize, NewSize: currentSize, MaxSize: nodeGroup.MaxSize()}
+	}
+	if info.NewSize+count > info.MaxSize {
+		count = info.MaxSize - info.NewSize
+	}
+	if p.nodesLeft >= 0 && count > p.nodesLeft {
+		glog.V(1).Infof("Capping scale-up size to max cluster total size (%d)", p.context.MaxNodesTotal)
+		count = p.nodesLeft
+	}
+	if count <= 0 {
+		return 0
+	}
+
+	nodeInfo := p.nodeInfos[nodeGroup.Id()]
+	delta, err := computeScaleUpResourcesDelta(nodeInfo, nodeGroup, p.resourceLimiter)
+	if err != nil {
+		glog.Errorf("Skipping node group %s; error getting node group resources: %v", nodeGroup.Id(), err)
+		return 0
+	}
+	if checkResult := p.resourcesLeft.checkScaleUpDeltaWithinLimits(delta); checkResult.exceeded {
+		glog.V(4).Infof("Skipping node group %s; maximal limit exceeded for %v", nodeGroup.Id(), checkResult.exceededResources)
+		return 0
+	}
+	count, err = applyScaleUpResourcesLimits(count, p.resourcesLeft, nodeInfo, nodeGroup, p.resourceLimiter)
+	if err != nil {
+		glog.Errorf("Skipping node group %s; failed to apply resource limits: %v", nodeGroup.Id(), err)
+		return 0
+	}
+	for resource, resourceDelta := range delta {
+		if limit, found := p.resourcesLeft[resource]; found {
+			p.resourcesLeft[resource] = limit - int64(count)*resourceDelta
+		}
+	}
+	if p.nodesLeft >= 0 {
+		p.nodesLeft -= count
+	}
+	info.NewSize += count
+	p.scaleUpInfos[nodeGroup.Id()] = info
+	return count
+}
+
+// plan returns the planned scale-ups, in the order of the node groups of the cloud provider.
+func (p *scaleUpPlanner) plan() []nodegroupset.ScaleUpInfo {
+	scaleUpInfos := make([]nodegroupset.ScaleUpInfo, 0, len(p.scaleUpInfos))
+	for _, nodeGroup := range p.context.CloudProvider.NodeGroups() {
+		if info, found := p.scaleUpInfos[nodeGroup.Id()]; found {
+			scaleUpInfos = append(scaleUpInfos, *info)
+		}
+	}
+	return scaleUpInfos
+}
+
+// execute scales up the node groups according to the plan. In dry run the scale-ups are only reported.
+func (p *scaleUpPlanner) execute() (*status.ScaleUpStatus, errors.AutoscalerError) {
+	scaleUpInfos := p.plan()
+	if p.context.DryRun {
+		for _, info := range scaleUpInfos {
+			reportDryRunScaleUp(p.context, info)
+		}
+		return &status.ScaleUpStatus{ScaledUp: false}, nil
+	}
+	for _, info := range scaleUpInfos {
+		gpuType := gpu.GetGpuTypeForMetrics(p.nodeInfos[info.Group.Id()].Node(), nil)
+		if err := executeScaleUp(p.context, p.clusterStateRegistry, info, gpuType); err != nil {
+			return nil, err
+		}
+	}
+	p.clusterStateRegistry.Recalculate()
+	return &status.ScaleUpStatus{ScaledUp: true, ScaleUpInfos: scaleUpInfos}, nil
+}
+
+// headroomLimits tracks whether nodes can be removed without the free capacity of the cluster or
+// of their node group going below the configured headroom.
+type headroomLimits struct {
+	free              *freeResources
+	clusterHeadroom   headroomResources
+	nodeGroupHeadroom map[string]nodeGroupHeadroom
+}
+
+// computeHeadroomLimits returns the headroom limits for scale-down, or nil if no headroom is configured.
+func computeHeadroomLimits(context *context.AutoscalingContext, nodes []*apiv1.Node, pods []*apiv1.Pod,
+	timestamp time.Time) *headroomLimits {
+	nodeGroupHeadroom := getNodeGroupHeadroom(context)
+	if !isClusterHeadroomConfigured(context) && len(nodeGroupHeadroom) == 0 {
+		return nil
+	}
+	free := computeFreeResources(context, nodes, pods, timestamp)
+	return &headroomLimits{
+		free:              free,
+		clusterHeadroom:   getClusterHeadroom(context, free),
+		nodeGroupHeadroom: nodeGroupHeadroom,
+	}
+}
+
+func (l *headroomLimits) copy() *headroomLimits {
+	if l == nil {
+		return nil
+	}
+	free := &freeResources{
+		cluster:    l.free.cluster,
+		nodeGroups: make(map[string]headroomResources, len(l.free.nodeGroups)),
+		nodeSizes:  make(map[string]headroomResources, len(l.free.nodeSizes)),
+	}
+	for id, resources := range l.free.nodeGroups {
+		free.nodeGroups[id] = resources
+	}
+	for name, resources := range l.free.nodeSizes {
+		free.nodeSizes[name] = resources
+	}
+	return &headroomLimits{
+		free:              free,
+		clusterHeadroom:   l.clusterHeadroom,
+		nodeGroupHeadroom: l.nodeGroupHeadroom,
+	}
+}
+
+// check returns the capacity lost by removing the node and whether the headroom allows it. Pods
+// running on the node are moved to other nodes, so the whole headroom size of the node is lost.
+func (l *headroomLimits) check(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup) (headroomResources, bool) {
+	if l == nil {
+		return headroomResources{}, true
+	}
+	nodeSize, found := l.free.nodeSizes[node.Name]
+	if !found {
+		// The node doesn't contribute to the free capacity.
+		return headroomResources{}, true
+	}
+	if !l.free.cluster.sub(nodeSize).covers(l.clusterHeadroom) {
+		return nodeSize, false
+	}
+	if headroom, found := l.nodeGroupHeadroom[nodeGroup.Id()]; found {
+		if !l.free.nodeGroups[nodeGroup.Id()].sub(nodeSize).covers(headroom.required(nodeSize)) {
+			return nodeSize, false
+		}
+	}
+	return nodeSize, true
+}
+
+// canRemoveNode tells whether the node can be removed without going below the headroom.
+func (l *headroomLimits) canRemoveNode(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup) bool {
+	_, ok := l.check(node, nodeGroup)
+	return ok
+}
+
+// tryRemoveNode accounts for the removal of the node if it doesn't go below the headroom. Returns
+// whether the node can be removed.
+func (l *headroomLimits) tryRemoveNode(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup) bool {
+	nodeSize, ok := l.check(node, nodeGroup)
+	if !ok || l == nil {
+		return ok
+	}
+	l.free.cluster = l.free.cluster.sub(nodeSize)
+	l.free.nodeGroups[nodeGroup.Id()] = l.free.nodeGroups[nodeGroup.Id()].sub(nodeSize)
+	delete(l.free.nodeSizes, node.Name)
+	return true
+}
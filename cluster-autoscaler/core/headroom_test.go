@@ -0,0 +1,221 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesToCover(t *testing.T) {
+	nodeSize := headroomResources{milliCPU: 1000, memory: 1000}
+	assert.Equal(t, 0, nodesToCover(headroomResources{milliCPU: -500, memory: 0}, nodeSize))
+	assert.Equal(t, 1, nodesToCover(headroomResources{milliCPU: 1000, memory: 10}, nodeSize))
+	assert.Equal(t, 3, nodesToCover(headroomResources{milliCPU: 1500, memory: 2500}, nodeSize))
+	assert.Equal(t, 0, nodesToCover(headroomResources{milliCPU: 1500}, headroomResources{memory: 1000}))
+}
+
+type headroomTestNode struct {
+	name   string
+	group  string
+	cpu    int64
+	podCpu int64
+}
+
+func runScaleUpForHeadroom(t *testing.T, options config.AutoscalingOptions, groupOptions map[string]*config.NodeGroupAutoscalingOptions,
+	groupSizes map[string]int, testNodes []headroomTestNode) (*status.ScaleUpStatus, map[string]int) {
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		list := action.(core.ListAction)
+		fieldstring := list.GetListRestrictions().Fields.String()
+		if strings.Contains(fieldstring, "spec.nodeName") {
+			return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+		}
+		return true, nil, fmt.Errorf("Failed to list: %v", list)
+	})
+
+	increases := make(map[string]int)
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		increases[nodeGroup] += increase
+		return nil
+	}, nil)
+	for id, size := range groupSizes {
+		provider.AddNodeGroupWithCustomOptions(id, 1, 10, size, groupOptions[id])
+	}
+	nodes := make([]*apiv1.Node, 0, len(testNodes))
+	pods := make([]*apiv1.Pod, 0, len(testNodes))
+	for _, n := range testNodes {
+		node := BuildTestNode(n.name, n.cpu, 1000*MB)
+		SetNodeReadyState(node, true, time.Now())
+		provider.AddNode(n.group, node)
+		nodes = append(nodes, node)
+		if n.podCpu > 0 {
+			pod := BuildTestPod("p-"+n.name, n.podCpu, 0)
+			pod.Spec.NodeName = n.name
+			pods = append(pods, pod)
+		}
+	}
+
+	options.MaxCoresTotal = config.DefaultMaxClusterCores
+	options.MaxMemoryTotal = config.DefaultMaxClusterMemory * 1024 * MB
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+	daemonSetLister := &daemonSetListerMock{}
+	daemonSetLister.On("List").Return([]*extensionsv1.DaemonSet{}, nil)
+	context.ListerRegistry = kube_util.NewListerRegistry(nil, nil, nil, nil, nil, daemonSetLister)
+
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes(nodes, time.Now())
+
+	scaleUpStatus, err := ScaleUpForHeadroom(&context, clusterState, nodes, pods, time.Now())
+	assert.NoError(t, err)
+	return scaleUpStatus, increases
+}
+
+func TestScaleUpForNodeGroupHeadroom(t *testing.T) {
+	groupOptions := map[string]*config.NodeGroupAutoscalingOptions{
+		"ng1": {HeadroomNodes: 2},
+	}
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, config.AutoscalingOptions{}, groupOptions,
+		map[string]int{"ng1": 2, "ng2": 1},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 600},
+			{"n2", "ng1", 1000, 500},
+			{"n3", "ng2", 1000, 1000},
+		})
+	assert.True(t, scaleUpStatus.ScaledUp)
+	// 900m are free in ng1, 1100m more are needed for 2 nodes worth of headroom.
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+}
+
+func TestScaleUpForNodeGroupHeadroomResources(t *testing.T) {
+	groupOptions := map[string]*config.NodeGroupAutoscalingOptions{
+		"ng1": {HeadroomCores: 2},
+	}
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, config.AutoscalingOptions{}, groupOptions,
+		map[string]int{"ng1": 1, "ng2": 1},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 500},
+			{"n2", "ng2", 1000, 0},
+		})
+	assert.True(t, scaleUpStatus.ScaledUp)
+	// 500m are free in ng1, 1500m more are needed for 2 free cores.
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+}
+
+func TestScaleUpForClusterHeadroom(t *testing.T) {
+	options := config.AutoscalingOptions{HeadroomCores: 2}
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, options, nil,
+		map[string]int{"ng1": 1, "ng2": 1},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 500},
+			{"n2", "ng2", 2000, 1000},
+		})
+	assert.True(t, scaleUpStatus.ScaledUp)
+	assert.Equal(t, 1, len(increases))
+	for _, increase := range increases {
+		assert.Equal(t, 1, increase)
+	}
+}
+
+func TestScaleUpForClusterHeadroomNodes(t *testing.T) {
+	options := config.AutoscalingOptions{ClusterHeadroomNodes: 1}
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, options, nil,
+		map[string]int{"ng1": 1, "ng2": 1},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 500},
+			{"n2", "ng2", 2000, 1000},
+		})
+	// 1500m are free, 500m less than the largest node.
+	assert.True(t, scaleUpStatus.ScaledUp)
+	assert.Equal(t, 1, len(increases))
+	for _, increase := range increases {
+		assert.Equal(t, 1, increase)
+	}
+}
+
+func TestScaleUpForHeadroomCountsUpcomingNodes(t *testing.T) {
+	options := config.AutoscalingOptions{HeadroomCores: 1}
+	// Target size of ng1 is 2, the upcoming node covers the headroom.
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, options, nil,
+		map[string]int{"ng1": 2},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 1000},
+		})
+	assert.False(t, scaleUpStatus.ScaledUp)
+	assert.Empty(t, increases)
+}
+
+func TestScaleUpForHeadroomNotConfigured(t *testing.T) {
+	scaleUpStatus, increases := runScaleUpForHeadroom(t, config.AutoscalingOptions{}, nil,
+		map[string]int{"ng1": 1},
+		[]headroomTestNode{
+			{"n1", "ng1", 1000, 1000},
+		})
+	assert.False(t, scaleUpStatus.ScaledUp)
+	assert.Empty(t, increases)
+}
+
+// nodeGroupForNodeErrorProvider fails to get the node group of the nodes with the given names.
+type nodeGroupForNodeErrorProvider struct {
+	*testprovider.TestCloudProvider
+	failing map[string]bool
+}
+
+func (p *nodeGroupForNodeErrorProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	if p.failing[node.Name] {
+		return nil, fmt.Errorf("failed to get node group of %s", node.Name)
+	}
+	return p.TestCloudProvider.NodeGroupForNode(node)
+}
+
+func TestComputeFreeResourcesSkipsNodesWithoutNodeGroup(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 2)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	provider.AddNode("ng1", n1)
+	n2 := BuildTestNode("n2", 2000, 2000)
+	SetNodeReadyState(n2, true, time.Now())
+	provider.AddNode("ng1", n2)
+	context := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{},
+		&nodeGroupForNodeErrorProvider{TestCloudProvider: provider, failing: map[string]bool{"n2": true}})
+
+	p1 := BuildTestPod("p1", 400, 0)
+	p1.Spec.NodeName = "n1"
+	free := computeFreeResources(&context, []*apiv1.Node{n1, n2}, []*apiv1.Pod{p1}, time.Now())
+	assert.Equal(t, headroomResources{milliCPU: 600, memory: 1000}, free.cluster)
+	assert.Equal(t, map[string]headroomResources{"ng1": {milliCPU: 600, memory: 1000}}, free.nodeGroups)
+	assert.Equal(t, map[string]headroomResources{"n1": {milliCPU: 1000, memory: 1000}}, free.nodeSizes)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupset"
+
+	"github.com/golang/glog"
+)
+
+// getMinSize returns the min size of the node group at the given time, taking the min size schedules
+// into account. Schedules are applied one max node provision time ahead, so that the nodes are ready
+// when a window starts.
+func getMinSize(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup, now time.Time) int {
+	if context.MinSizeSchedules == nil {
+		return nodeGroup.MinSize()
+	}
+	leadTime := cloudprovider.GetNodeGroupOptions(nodeGroup, context.NodeGroupDefaults()).MaxNodeProvisionTime
+	return context.MinSizeSchedules.MinSize(nodeGroup, now, leadTime)
+}
+
+// ScaleUpToScheduledMinSizes increases the node groups whose target size is below the min size set by
+// a schedule. Returns true if any node group was scaled up.
+func ScaleUpToScheduledMinSizes(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry,
+	now time.Time) (bool, errors.AutoscalerError) {
+	if context.MinSizeSchedules == nil {
+		return false, nil
+	}
+	context.MinSizeSchedules.Refresh()
+
+	scaledUp := false
+	for _, nodeGroup := range context.CloudProvider.NodeGroups() {
+		minSize := getMinSize(context, nodeGroup, now)
+		if minSize <= nodeGroup.MinSize() {
+			continue
+		}
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			glog.Errorf("Failed to get node group size: %v", err)
+			continue
+		}
+		if targetSize >= minSize {
+			continue
+		}
+		if !clusterStateRegistry.IsNodeGroupSafeToScaleUp(nodeGroup.Id(), now) {
+			glog.Warningf("Node group %s is below its scheduled min size %d but not ready for scaleup", nodeGroup.Id(), minSize)
+			continue
+		}
+		info := nodegroupset.ScaleUpInfo{
+			Group:       nodeGroup,
+			CurrentSize: targetSize,
+			NewSize:     minSize,
+			MaxSize:     nodeGroup.MaxSize(),
+		}
+		glog.V(1).Infof("Node group %s is below its scheduled min size %d", nodeGroup.Id(), minSize)
+		if context.DryRun {
+			reportDryRunScaleUp(context, info)
+			continue
+		}
+		gpuType := gpu.MetricsNoGPU
+		if nodeInfo, err := nodeGroup.TemplateNodeInfo(); err == nil {
+			gpuType = gpu.GetGpuTypeForMetrics(nodeInfo.Node(), nodeGroup)
+		}
+		if err := executeScaleUp(context, clusterStateRegistry, info, gpuType); err != nil {
+			return scaledUp, err
+		}
+		scaledUp = true
+	}
+	if scaledUp {
+		clusterStateRegistry.Recalculate()
+	}
+	return scaledUp, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/schedule"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// alwaysActiveSchedules hold a schedule that is active at any time.
+const alwaysActiveSchedules = `
+- nodeGroup: ng1
+  start: "* * * * *"
+  duration: 1m
+  minSize: 3
+`
+
+func buildMinSizeSchedulesContext(t *testing.T, provider *testprovider.TestCloudProvider) *context.AutoscalingContext {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NoError(t, store.Add(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "kube-system",
+			Name:            schedule.MinSizeSchedulesConfigMapName,
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{schedule.MinSizeSchedulesConfigMapKey: alwaysActiveSchedules},
+	}))
+	context := NewScaleTestAutoscalingContext(defaultScaleDownOptions, &fake.Clientset{}, provider)
+	context.MinSizeSchedules = schedule.NewMinSizeSchedules(v1lister.NewConfigMapLister(store).ConfigMaps("kube-system"), context.LogRecorder)
+	context.MinSizeSchedules.Refresh()
+	return &context
+}
+
+func TestScaleUpToScheduledMinSizes(t *testing.T) {
+	increases := make(map[string]int)
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		increases[nodeGroup] += increase
+		return nil
+	}, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, time.Now())
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng2", n2)
+
+	context := buildMinSizeSchedulesContext(t, provider)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes([]*apiv1.Node{n1, n2}, time.Now())
+
+	scaledUp, err := ScaleUpToScheduledMinSizes(context, clusterState, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, scaledUp)
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+
+	// Target size is now at the scheduled min size.
+	scaledUp, err = ScaleUpToScheduledMinSizes(context, clusterState, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, scaledUp)
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+}
+
+func TestGetEmptyNodesRespectsScheduledMinSize(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 4)
+	provider.AddNodeGroup("ng2", 1, 10, 4)
+	nodes := make([]*apiv1.Node, 0)
+	for _, group := range []string{"ng1", "ng2"} {
+		for _, name := range []string{"a", "b", "c", "d"} {
+			node := BuildTestNode(group+"-"+name, 1000, 1000)
+			provider.AddNode(group, node)
+			nodes = append(nodes, node)
+		}
+	}
+
+	context := buildMinSizeSchedulesContext(t, provider)
+	emptyNodes := getEmptyNodesNoResourceLimits(nodes, []*apiv1.Pod{}, len(nodes), context, time.Now())
+	// Scheduled min size of ng1 is 3, min size of ng2 is 1.
+	assert.Equal(t, 4, len(emptyNodes))
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
+
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// NodeRecyclingReplacementRequestedKey is the name of annotation marking an old node whose replacement
+	// was requested, so that the node is drained rather than replaced again after a restart.
+	NodeRecyclingReplacementRequestedKey = "cluster-autoscaler.kubernetes.io/recycling-replacement-requested"
+)
+
+// NodeRecyclingResult represents the state of node recycling.
+type NodeRecyclingResult int
+
+const (
+	// NodeRecyclingNone - no node is being recycled and none was picked.
+	NodeRecyclingNone NodeRecyclingResult = iota
+	// NodeRecyclingReplacementRequested - node groups were scaled up to replace old nodes.
+	NodeRecyclingReplacementRequested
+	// NodeRecyclingWaiting - the replacements are not ready yet, or the old nodes can't be drained yet.
+	NodeRecyclingWaiting
+	// NodeRecyclingDrainStarted - old nodes are being drained and deleted.
+	NodeRecyclingDrainStarted
+)
+
+// NodeRecycling replaces nodes older than the max node age. A replacement is first added to the node
+// group of an old node, and the old node is drained and deleted by the scale-down once the replacement
+// is ready.
+type NodeRecycling struct {
+	context              *context.AutoscalingContext
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	scaleDown            *ScaleDown
+	// recycled are the names of the old nodes whose replacement was requested. It is rebuilt from
+	// the NodeRecyclingReplacementRequestedKey annotation of the nodes in every loop.
+	recycled map[string]bool
+}
+
+// NewNodeRecycling builds new NodeRecycling object.
+func NewNodeRecycling(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry, scaleDown *ScaleDown) *NodeRecycling {
+	return &NodeRecycling{
+		context:              context,
+		clusterStateRegistry: clusterStateRegistry,
+		scaleDown:            scaleDown,
+		recycled:             make(map[string]bool),
+	}
+}
+
+// Update drains the old nodes whose replacements are ready and requests replacements for more old nodes,
+// as long as fewer than MaxConcurrentRecycledNodes nodes are being recycled. Pods are the scheduled pods.
+func (r *NodeRecycling) Update(allNodes []*apiv1.Node, readyNodes []*apiv1.Node, pods []*apiv1.Pod,
+	pdbs []*policyv1.PodDisruptionBudget, now time.Time) (NodeRecyclingResult, errors.AutoscalerError) {
+	r.updateRecycledNodes(allNodes)
+
+	result := NodeRecyclingNone
+	if len(r.recycled) > 0 {
+		var err errors.AutoscalerError
+		result, err = r.drainRecycledNodes(readyNodes, pods, pdbs, now)
+		if err != nil || result == NodeRecyclingDrainStarted {
+			return result, err
+		}
+	}
+	requested, err := r.requestReplacements(readyNodes, pods, pdbs, now)
+	if err != nil {
+		return NodeRecyclingNone, err
+	}
+	if requested {
+		return NodeRecyclingReplacementRequested, nil
+	}
+	return result, nil
+}
+
+// updateRecycledNodes starts tracking the annotated old nodes, e.g. after a restart, and stops tracking
+// the old nodes that are gone, whoever deleted them.
+func (r *NodeRecycling) updateRecycledNodes(allNodes []*apiv1.Node) {
+	existing := make(map[string]bool, len(allNodes))
+	for _, node := range allNodes {
+		existing[node.Name] = true
+		if _, found := node.Annotations[NodeRecyclingReplacementRequestedKey]; found && !r.recycled[node.Name] {
+			glog.V(1).Infof("Node recycling: replacement of %s was already requested", node.Name)
+			r.recycled[node.Name] = true
+		}
+	}
+	for name := range r.recycled {
+		if !existing[name] {
+			glog.V(1).Infof("Node recycling: %s is gone", name)
+			delete(r.recycled, name)
+		}
+	}
+}
+
+// drainRecycledNodes starts draining and deleting the old nodes whose node groups are done scaling up.
+// Old nodes whose pods can't be moved yet, e.g. because of PodDisruptionBudgets, are retried in the
+// following loops.
+func (r *NodeRecycling) drainRecycledNodes(readyNodes []*apiv1.Node, pods []*apiv1.Pod, pdbs []*policyv1.PodDisruptionBudget,
+	now time.Time) (NodeRecyclingResult, errors.AutoscalerError) {
+	if r.scaleDown.nodeDeleteStatus.IsDeleteInProgress() {
+		glog.V(1).Infof("Node recycling: waiting for the node deletion in progress")
+		return NodeRecyclingWaiting, nil
+	}
+
+	nodesWithoutMaster := filterOutMasters(readyNodes, pods)
+	recycledNodes := make([]*apiv1.Node, 0, len(r.recycled))
+	for _, node := range nodesWithoutMaster {
+		if r.recycled[node.Name] {
+			recycledNodes = append(recycledNodes, node)
+		}
+	}
+	nodeGroups := getRemovableNodeGroups(r.context, recycledNodes, now)
+	nodeGroupSize := getNodeGroupSizeMap(r.context.CloudProvider)
+	upcomingNodes := r.clusterStateRegistry.GetUpcomingNodes()
+	removedFromGroup := make(map[string]int)
+	candidates := make([]*apiv1.Node, 0, len(recycledNodes))
+	for _, node := range recycledNodes {
+		nodeGroup, found := nodeGroups[node.Name]
+		if !found {
+			continue
+		}
+		if r.waitingForReplacement(node, nodeGroup, upcomingNodes, now) {
+			glog.V(1).Infof("Node recycling: waiting for the replacement of %s in %s", node.Name, nodeGroup.Id())
+			continue
+		}
+		if nodeGroupSize[nodeGroup.Id()]-removedFromGroup[nodeGroup.Id()] <= getMinSize(r.context, nodeGroup, now) {
+			glog.V(1).Infof("Node recycling: can't remove %s, %s is at its min size", node.Name, nodeGroup.Id())
+			continue
+		}
+		removedFromGroup[nodeGroup.Id()]++
+		candidates = append(candidates, node)
+	}
+	if len(candidates) == 0 {
+		return NodeRecyclingWaiting, nil
+	}
+
+	nonExpendablePods := FilterOutExpendablePods(pods, r.context.ExpendablePodsPriorityCutoff)
+	nodesToRemove, unremovable, _, err := simulator.FindNodesToRemove(candidates, nodesWithoutMaster, nonExpendablePods,
+		r.context.ClientSet, r.context.PredicateChecker, len(candidates), false, r.scaleDown.podLocationHints,
+		r.scaleDown.usageTracker, now, getScratchPodSelector(r.context), pdbs)
+	if err != nil {
+		return NodeRecyclingNone, err.AddPrefix("failed to find recycled nodes to remove: ")
+	}
+	for _, node := range unremovable {
+		glog.V(1).Infof("Node recycling: %s can't be drained yet", node.Name)
+	}
+	if len(nodesToRemove) == 0 {
+		return NodeRecyclingWaiting, nil
+	}
+	reasons := make(map[string]metrics.NodeScaleDownReason, len(nodesToRemove))
+	for _, toRemove := range nodesToRemove {
+		reasons[toRemove.Node.Name] = metrics.Recycled
+	}
+	r.scaleDown.startNodeDeletions(nodesToRemove, nodeGroups, reasons)
+	return NodeRecyclingDrainStarted, nil
+}
+
+// waitingForReplacement returns true if the replacement of the old node may still be coming up. Scale-ups
+// requested before a restart are unknown to the cluster state, for them the node group is waited for until
+// the max node provision time has passed since the node was annotated.
+func (r *NodeRecycling) waitingForReplacement(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup,
+	upcomingNodes map[string]int, now time.Time) bool {
+	if r.clusterStateRegistry.IsNodeGroupScalingUp(nodeGroup.Id()) {
+		return true
+	}
+	if upcomingNodes[nodeGroup.Id()] == 0 {
+		return false
+	}
+	requestedAt, err := time.Parse(time.RFC3339, node.Annotations[NodeRecyclingReplacementRequestedKey])
+	if err != nil {
+		return false
+	}
+	maxNodeProvisionTime := cloudprovider.GetNodeGroupOptions(nodeGroup, r.context.NodeGroupDefaults()).MaxNodeProvisionTime
+	return requestedAt.Add(maxNodeProvisionTime).After(now)
+}
+
+// requestReplacements scales up the node groups of the oldest nodes past the max node age, one node
+// per old node. Returns true if any node group was scaled up.
+func (r *NodeRecycling) requestReplacements(readyNodes []*apiv1.Node, pods []*apiv1.Pod, pdbs []*policyv1.PodDisruptionBudget,
+	now time.Time) (bool, errors.AutoscalerError) {
+	slots := r.context.MaxConcurrentRecycledNodes - len(r.recycled)
+	if slots <= 0 {
+		glog.V(4).Infof("Node recycling: %d nodes are already being recycled", len(r.recycled))
+		return false, nil
+	}
+
+	nodesWithoutMaster := filterOutMasters(readyNodes, pods)
+	nodeGroups := getRemovableNodeGroups(r.context, nodesWithoutMaster, now)
+	oldNodes := make([]*apiv1.Node, 0)
+	for _, node := range nodesWithoutMaster {
+		if r.recycled[node.Name] {
+			continue
+		}
+		if _, found := nodeGroups[node.Name]; !found {
+			continue
+		}
+		if now.Sub(node.CreationTimestamp.Time) >= r.context.MaxNodeAge {
+			oldNodes = append(oldNodes, node)
+		}
+	}
+	if len(oldNodes) == 0 {
+		return false, nil
+	}
+	oldNodes = scaledownorder.NewStrategy(scaledownorder.NewAgePolicy()).Order(oldNodes, nil, now)
+
+	daemonSets, errList := r.context.DaemonSetLister().List()
+	if errList != nil {
+		return false, errors.ToAutoscalerError(errors.ApiCallError, errList).AddPrefix("failed to list daemon sets: ")
+	}
+	nodeInfos, err := GetNodeInfosForGroups(readyNodes, r.context.CloudProvider, r.context.ClientSet,
+		daemonSets, r.context.PredicateChecker)
+	if err != nil {
+		return false, err.AddPrefix("failed to build node infos for node groups: ")
+	}
+	planner, err := newScaleUpPlanner(r.context, r.clusterStateRegistry, readyNodes, nodeInfos, now)
+	if err != nil {
+		return false, err
+	}
+
+	nonExpendablePods := FilterOutExpendablePods(pods, r.context.ExpendablePodsPriorityCutoff)
+	nodeNameToNodeInfo := scheduler_util.CreateNodeNameToInfoMap(nonExpendablePods, oldNodes)
+	scratchPods := getScratchPodSelector(r.context)
+	replaced := make(map[string]string)
+	for _, node := range oldNodes {
+		if len(replaced) >= slots {
+			break
+		}
+		// Nodes that can't be drained are not replaced, a replacement would be left unused.
+		if _, err := simulator.GetPodsToMove(nodeNameToNodeInfo[node.Name], nil, true, scratchPods, pdbs); err != nil {
+			glog.V(2).Infof("Node recycling: %s can't be recycled: %v", node.Name, err)
+			continue
+		}
+		nodeGroup := nodeGroups[node.Name]
+		if !planner.canScaleUp(nodeGroup) || planner.addNodes(nodeGroup, 1) < 1 {
+			glog.V(2).Infof("Node recycling: can't add a replacement for %s to %s", node.Name, nodeGroup.Id())
+			continue
+		}
+		replaced[node.Name] = nodeGroup.Id()
+	}
+	if len(replaced) == 0 {
+		return false, nil
+	}
+
+	if r.context.DryRun {
+		if _, err := planner.execute(); err != nil {
+			return false, err
+		}
+		for name, nodeGroupId := range replaced {
+			glog.V(0).Infof("Node recycling (dry run): would replace %s with a new node of %s", name, nodeGroupId)
+		}
+		return false, nil
+	}
+	if _, err := planner.execute(); err != nil {
+		return false, err.AddPrefix("failed to scale up for node recycling: ")
+	}
+	for name, nodeGroupId := range replaced {
+		glog.V(0).Infof("Node recycling: replacing %s with a new node of %s", name, nodeGroupId)
+		r.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "NodeRecycling",
+			"Node recycling: replacing %s with a new node of %s", name, nodeGroupId)
+		r.recycled[name] = true
+		// The node is still tracked in memory if the annotation can't be set, it is only lost on restart.
+		if err := r.markReplacementRequested(name, now); err != nil {
+			glog.Warningf("Node recycling: failed to annotate %s: %v", name, err)
+		}
+	}
+	return true, nil
+}
+
+// markReplacementRequested sets the NodeRecyclingReplacementRequestedKey annotation on the node.
+func (r *NodeRecycling) markReplacementRequested(name string, now time.Time) error {
+	// Get the newest version of the node.
+	freshNode, err := r.context.ClientSet.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil || freshNode == nil {
+		return fmt.Errorf("failed to get node %v: %v", name, err)
+	}
+	if freshNode.Annotations == nil {
+		freshNode.Annotations = make(map[string]string)
+	}
+	freshNode.Annotations[NodeRecyclingReplacementRequestedKey] = now.Format(time.RFC3339)
+	_, err = r.context.ClientSet.CoreV1().Nodes().Update(freshNode)
+	return err
+}
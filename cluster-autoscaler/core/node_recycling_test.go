@@ -0,0 +1,245 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildRecyclingTestNode(name string, age time.Duration, now time.Time) *apiv1.Node {
+	node := BuildTestNode(name, 1000, 1000*MB)
+	SetNodeReadyState(node, true, time.Time{})
+	node.CreationTimestamp = metav1.NewTime(now.Add(-age))
+	return node
+}
+
+func buildRecyclingTestPod(name string, nodeName string) *apiv1.Pod {
+	pod := BuildTestPod(name, 300, 100*MB)
+	pod.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	pod.Spec.NodeName = nodeName
+	pod.Labels = map[string]string{"app": name}
+	return pod
+}
+
+func newNodeRecyclingForTest(options config.AutoscalingOptions, nodes []*apiv1.Node,
+	onScaleUp testprovider.OnScaleUpFunc, onScaleDown testprovider.OnScaleDownFunc) (*NodeRecycling, *clusterstate.ClusterStateRegistry, *testprovider.TestCloudProvider) {
+	nodesByName := make(map[string]*apiv1.Node)
+	for _, node := range nodes {
+		nodesByName[node.Name] = node
+	}
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+	})
+	fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, kube_errors.NewNotFound(apiv1.Resource("pod"), "whatever")
+	})
+	fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		getAction := action.(core.GetAction)
+		if node, found := nodesByName[getAction.GetName()]; found {
+			return true, node, nil
+		}
+		return true, nil, fmt.Errorf("Wrong node: %v", getAction.GetName())
+	})
+	fakeClient.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		update := action.(core.UpdateAction)
+		return true, update.GetObject(), nil
+	})
+
+	provider := testprovider.NewTestCloudProvider(onScaleUp, onScaleDown)
+	provider.AddNodeGroup("ng1", 1, 10, len(nodes))
+	for _, node := range nodes {
+		provider.AddNode("ng1", node)
+	}
+
+	options.MaxGracefulTerminationSec = 60
+	options.MaxCoresTotal = config.DefaultMaxClusterCores
+	options.MaxMemoryTotal = config.DefaultMaxClusterMemory * 1024 * MB
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+	daemonSetLister := &daemonSetListerMock{}
+	daemonSetLister.On("List").Return([]*extensionsv1.DaemonSet{}, nil)
+	context.ListerRegistry = kube_util.NewListerRegistry(nil, nil, nil, nil, nil, daemonSetLister)
+
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes(nodes, time.Now())
+	return NewNodeRecycling(&context, clusterState, NewScaleDown(&context, clusterState)), clusterState, provider
+}
+
+func TestNodeRecycling(t *testing.T) {
+	now := time.Now()
+	deletedNodes := make(chan string, 10)
+	increases := make(map[string]int)
+
+	n1 := buildRecyclingTestNode("n1", 48*time.Hour, now)
+	n2 := buildRecyclingTestNode("n2", time.Hour, now)
+	pods := []*apiv1.Pod{buildRecyclingTestPod("p1", "n1"), buildRecyclingTestPod("p2", "n2")}
+	nodes := []*apiv1.Node{n1, n2}
+
+	options := config.AutoscalingOptions{
+		MaxNodeAge:                 24 * time.Hour,
+		MaxConcurrentRecycledNodes: 1,
+	}
+	nodeRecycling, clusterState, provider := newNodeRecyclingForTest(options, nodes,
+		func(nodeGroup string, increase int) error {
+			increases[nodeGroup] += increase
+			return nil
+		}, func(nodeGroup string, node string) error {
+			deletedNodes <- node
+			return nil
+		})
+
+	// Only n1 is old enough, a replacement is added to its node group.
+	result, err := nodeRecycling.Update(nodes, nodes, pods, nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingReplacementRequested, result)
+	assert.Equal(t, map[string]int{"ng1": 1}, increases)
+	assert.Contains(t, n1.Annotations, NodeRecyclingReplacementRequestedKey)
+
+	// The replacement is not there yet.
+	result, err = nodeRecycling.Update(nodes, nodes, pods, nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingWaiting, result)
+	assert.Equal(t, map[string]int{"ng1": 1}, increases)
+
+	n3 := buildRecyclingTestNode("n3", 0, now)
+	provider.AddNode("ng1", n3)
+	nodes = []*apiv1.Node{n1, n2, n3}
+	clusterState.UpdateNodes(nodes, now)
+
+	result, err = nodeRecycling.Update(nodes, nodes, pods, nil, now)
+	waitForDeleteToFinish(t, nodeRecycling.scaleDown)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingDrainStarted, result)
+	assert.Equal(t, n1.Name, getStringFromChan(deletedNodes))
+
+	// Once n1 is gone nothing else is recycled.
+	nodes = []*apiv1.Node{n2, n3}
+	result, err = nodeRecycling.Update(nodes, nodes, pods[1:], nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingNone, result)
+	assert.Empty(t, nodeRecycling.recycled)
+}
+
+func TestNodeRecyclingAfterRestart(t *testing.T) {
+	now := time.Now()
+	deletedNodes := make(chan string, 10)
+	increases := make(map[string]int)
+
+	n1 := buildRecyclingTestNode("n1", 48*time.Hour, now)
+	n1.Annotations = map[string]string{NodeRecyclingReplacementRequestedKey: now.Add(-time.Minute).Format(time.RFC3339)}
+	n2 := buildRecyclingTestNode("n2", time.Hour, now)
+	pods := []*apiv1.Pod{buildRecyclingTestPod("p1", "n1"), buildRecyclingTestPod("p2", "n2")}
+	nodes := []*apiv1.Node{n1, n2}
+
+	options := config.AutoscalingOptions{
+		MaxNodeAge:                 24 * time.Hour,
+		MaxConcurrentRecycledNodes: 1,
+		MaxNodeProvisionTime:       15 * time.Minute,
+	}
+	nodeRecycling, clusterState, provider := newNodeRecyclingForTest(options, nodes,
+		func(nodeGroup string, increase int) error {
+			increases[nodeGroup] += increase
+			return nil
+		}, func(nodeGroup string, node string) error {
+			deletedNodes <- node
+			return nil
+		})
+	// The replacement of n1 was requested before the restart and is not there yet.
+	provider.GetNodeGroup("ng1").(*testprovider.TestNodeGroup).SetTargetSize(3)
+	clusterState.UpdateNodes(nodes, now)
+
+	result, err := nodeRecycling.Update(nodes, nodes, pods, nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingWaiting, result)
+	assert.Empty(t, increases)
+	assert.Equal(t, map[string]bool{"n1": true}, nodeRecycling.recycled)
+
+	n3 := buildRecyclingTestNode("n3", 0, now)
+	provider.AddNode("ng1", n3)
+	nodes = []*apiv1.Node{n1, n2, n3}
+	clusterState.UpdateNodes(nodes, now)
+
+	result, err = nodeRecycling.Update(nodes, nodes, pods, nil, now)
+	waitForDeleteToFinish(t, nodeRecycling.scaleDown)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingDrainStarted, result)
+	assert.Equal(t, n1.Name, getStringFromChan(deletedNodes))
+	assert.Empty(t, increases)
+}
+
+func TestNodeRecyclingLimits(t *testing.T) {
+	now := time.Now()
+	increases := make(map[string]int)
+
+	n1 := buildRecyclingTestNode("n1", 72*time.Hour, now)
+	n2 := buildRecyclingTestNode("n2", 96*time.Hour, now)
+	n3 := buildRecyclingTestNode("n3", 48*time.Hour, now)
+	n4 := buildRecyclingTestNode("n4", 120*time.Hour, now)
+	pods := []*apiv1.Pod{buildRecyclingTestPod("p1", "n1"), buildRecyclingTestPod("p2", "n2"),
+		buildRecyclingTestPod("p3", "n3"), buildRecyclingTestPod("p4", "n4")}
+	nodes := []*apiv1.Node{n1, n2, n3, n4}
+	// The pod of n4 can't be disrupted.
+	pdbs := []*policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: pods[3].Namespace},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "p4"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{PodDisruptionsAllowed: 0},
+	}}
+
+	options := config.AutoscalingOptions{
+		MaxNodeAge:                 24 * time.Hour,
+		MaxConcurrentRecycledNodes: 2,
+	}
+	nodeRecycling, _, _ := newNodeRecyclingForTest(options, nodes,
+		func(nodeGroup string, increase int) error {
+			increases[nodeGroup] += increase
+			return nil
+		}, nil)
+
+	// The two oldest nodes that can be drained are replaced.
+	result, err := nodeRecycling.Update(nodes, nodes, pods, pdbs, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingReplacementRequested, result)
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+	assert.Equal(t, map[string]bool{"n1": true, "n2": true}, nodeRecycling.recycled)
+
+	// No more nodes are recycled until the replaced ones are gone.
+	result, err = nodeRecycling.Update(nodes, nodes, pods, pdbs, now)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRecyclingWaiting, result)
+	assert.Equal(t, map[string]int{"ng1": 2}, increases)
+}
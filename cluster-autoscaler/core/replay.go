@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
+	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/replay"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const (
+	// How often the replayer checks whether an asynchronous node deletion has finished.
+	replayDeleteCheckInterval = 10 * time.Millisecond
+)
+
+// Replayer runs recorded loops through a StaticAutoscaler backed by a replayed cluster
+// and reports the decisions taken in each of them.
+type Replayer struct {
+	cluster    *replay.Cluster
+	autoscaler *StaticAutoscaler
+}
+
+// NewReplayer builds a Replayer using the given options. Status ConfigMap is never written.
+func NewReplayer(opts config.AutoscalingOptions) (*Replayer, error) {
+	opts.WriteStatusConfigMap = false
+	cluster := replay.NewCluster(context.NewResourceLimiterFromAutoscalingOptions(opts))
+
+	predicateChecker, err := simulator.NewPredicateChecker(fake.NewSimpleClientset(), make(chan struct{}))
+	if err != nil {
+		return nil, err
+	}
+	// Events are dropped, decisions are reported by the replayer.
+	recorder := &kube_record.FakeRecorder{}
+	logRecorder, err := utils.NewStatusMapRecorder(cluster.ClientSet(), opts.ConfigNamespace, recorder, false)
+	if err != nil {
+		return nil, err
+	}
+	kubeClients := &context.AutoscalingKubeClients{
+		ListerRegistry: cluster.ListerRegistry(),
+		ClientSet:      cluster.ClientSet(),
+		Recorder:       recorder,
+		LogRecorder:    logRecorder,
+	}
+	stopChannel := make(chan struct{})
+	configMaps := kube_util.NewSharedConfigMapLister(kubeClients.ClientSet, stopChannel, opts.ConfigNamespace)
+	expanderStrategy, err := factory.ExpanderStrategyFromStrings(opts.ExpanderNames, cluster.CloudProvider(),
+		kubeClients, configMaps, opts.GRPCExpander)
+	if err != nil {
+		close(stopChannel)
+		return nil, err
+	}
+
+	autoscaler := NewStaticAutoscaler(opts, predicateChecker, kubeClients, ca_processors.DefaultProcessors(),
+		cluster.CloudProvider(), expanderStrategy)
+	// Recorded loops carry their own timestamps, decisions shouldn't be delayed
+	// relative to the moment the replay was started.
+	autoscaler.startTime = time.Time{}
+	autoscaler.lastScaleUpTime = time.Time{}
+	autoscaler.lastScaleDownDeleteTime = time.Time{}
+	autoscaler.lastScaleDownFailTime = time.Time{}
+	// There are no taints left by a previous run in a recording.
+	autoscaler.initialized = true
+	autoscaler.stopChannel = stopChannel
+
+	return &Replayer{
+		cluster:    cluster,
+		autoscaler: autoscaler,
+	}, nil
+}
+
+// ReplayLoop runs a single autoscaler loop against the given record and returns the decisions
+// taken in it. Records should be replayed in the order they were recorded.
+func (r *Replayer) ReplayLoop(record *replay.LoopRecord) ([]replay.Decision, errors.AutoscalerError) {
+	r.cluster.Load(record)
+	err := r.autoscaler.RunOnce(record.Timestamp)
+	// Non-empty nodes are deleted asynchronously, include them in the decisions of this loop.
+	for r.autoscaler.scaleDown.nodeDeleteStatus.IsDeleteInProgress() {
+		time.Sleep(replayDeleteCheckInterval)
+	}
+	return r.cluster.TakeDecisions(), err
+}
+
+// Close stops the watches started by the replayer.
+func (r *Replayer) Close() {
+	r.autoscaler.ExitCleanUp()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/replay"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayLoops(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, now.Add(-time.Hour))
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, now.Add(-time.Hour))
+	template := BuildTestNode("ng1-template", 1000, 1000)
+	SetNodeReadyState(template, true, now.Add(-time.Hour))
+
+	p1 := BuildTestPod("p1", 800, 0)
+	p1.Spec.NodeName = "n1"
+	p2 := BuildTestPod("p2", 800, 0)
+	p2.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+
+	options := config.AutoscalingOptions{
+		EstimatorName:                 estimator.BinpackingEstimatorName,
+		ExpanderNames:                 []string{expander.RandomExpanderName},
+		MaxNodesTotal:                 10,
+		MaxCoresTotal:                 10,
+		MaxMemoryTotal:                100000,
+		MaxNodeProvisionTime:          15 * time.Minute,
+		ScaleDownEnabled:              true,
+		ScaleDownUtilizationThreshold: 0.5,
+		ScaleDownUnneededTime:         time.Minute,
+		ScaleDownUnreadyTime:          time.Minute,
+		MaxEmptyBulkDelete:            10,
+		MaxTotalUnreadyPercentage:     45,
+		OkTotalUnreadyCount:           3,
+	}
+	replayer, err := NewReplayer(options)
+	assert.NoError(t, err)
+	defer replayer.Close()
+
+	nodeGroup := func(targetSize int, nodes ...string) *replay.NodeGroupRecord {
+		return &replay.NodeGroupRecord{
+			Id:         "ng1",
+			MinSize:    1,
+			MaxSize:    10,
+			TargetSize: targetSize,
+			Nodes:      nodes,
+			Template:   template,
+		}
+	}
+
+	// Pending pod doesn't fit on n1, a scale-up is expected.
+	decisions, err := replayer.ReplayLoop(&replay.LoopRecord{
+		Timestamp:         now,
+		Nodes:             []*apiv1.Node{n1},
+		ScheduledPods:     []*apiv1.Pod{p1},
+		UnschedulablePods: []*apiv1.Pod{p2},
+		NodeGroups:        []*replay.NodeGroupRecord{nodeGroup(1, "n1")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []replay.Decision{{Timestamp: now, Type: replay.ScaleUpDecision, NodeGroup: "ng1", Delta: 1}}, decisions)
+
+	// The new node is empty and marked unneeded, but it was just added.
+	later := now.Add(2 * time.Minute)
+	decisions, err = replayer.ReplayLoop(&replay.LoopRecord{
+		Timestamp:     later,
+		Nodes:         []*apiv1.Node{n1, n2},
+		ScheduledPods: []*apiv1.Pod{p1},
+		NodeGroups:    []*replay.NodeGroupRecord{nodeGroup(2, "n1", "n2")},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, decisions)
+
+	// Once scale-down is no longer delayed and the node stayed unneeded, it is removed.
+	evenLater := now.Add(20 * time.Minute)
+	decisions, err = replayer.ReplayLoop(&replay.LoopRecord{
+		Timestamp:     evenLater,
+		Nodes:         []*apiv1.Node{n1, n2},
+		ScheduledPods: []*apiv1.Pod{p1},
+		NodeGroups:    []*replay.NodeGroupRecord{nodeGroup(2, "n1", "n2")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []replay.Decision{{Timestamp: evenLater, Type: replay.ScaleDownDecision, NodeGroup: "ng1", Node: "n2"}}, decisions)
+}
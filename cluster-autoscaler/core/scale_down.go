@@ -60,6 +60,8 @@ const (
 	ScaleDownNodeDeleted
 	// ScaleDownNodeDeleteStarted - a node deletion process was started.
 	ScaleDownNodeDeleteStarted
+	// ScaleDownBlockedBySchedule - unneeded nodes may be present but a blackout window forbids removing them.
+	ScaleDownBlockedBySchedule
 )
 
 const (
@@ -81,10 +83,14 @@ const (
 	PodEvictionHeadroom = 30 * time.Second
 )
 
-// NodeDeleteStatus tells whether a node is being deleted right now.
+// NodeDeleteStatus tells whether a node is being deleted right now, and keeps the results of
+// the node deletions that finished.
 type NodeDeleteStatus struct {
 	sync.Mutex
 	deleteInProgress bool
+	// nodeDeleteResults maps names of the nodes whose deletion finished since the results were
+	// last drained to the deletion errors, nil if the node was deleted.
+	nodeDeleteResults map[string]errors.AutoscalerError
 }
 
 // IsDeleteInProgress returns true if a node is being deleted.
@@ -101,6 +107,25 @@ func (n *NodeDeleteStatus) SetDeleteInProgress(status bool) {
 	n.deleteInProgress = status
 }
 
+// AddNodeDeleteResult records the result of a node deletion.
+func (n *NodeDeleteStatus) AddNodeDeleteResult(nodeName string, err errors.AutoscalerError) {
+	n.Lock()
+	defer n.Unlock()
+	if n.nodeDeleteResults == nil {
+		n.nodeDeleteResults = make(map[string]errors.AutoscalerError)
+	}
+	n.nodeDeleteResults[nodeName] = err
+}
+
+// DrainNodeDeleteResults returns the results of node deletions recorded since the last call.
+func (n *NodeDeleteStatus) DrainNodeDeleteResults() map[string]errors.AutoscalerError {
+	n.Lock()
+	defer n.Unlock()
+	results := n.nodeDeleteResults
+	n.nodeDeleteResults = make(map[string]errors.AutoscalerError)
+	return results
+}
+
 type scaleDownResourcesLimits map[string]int64
 type scaleDownResourcesDelta map[string]int64
 
@@ -354,6 +379,9 @@ func (sd *ScaleDown) UpdateUnneededNodes(
 	nodeNameToNodeInfo := scheduler_util.CreateNodeNameToInfoMap(nonExpendablePods, nodes)
 	utilizationMap := make(map[string]float64)
 
+	sd.context.ScaleDownBlackouts.Refresh()
+	sd.clusterStateRegistry.UpdateScaleDownBlackouts(getScaleDownBlackouts(sd.context, timestamp))
+
 	sd.updateUnremovableNodes(nodes)
 	// Filter out nodes that were recently checked
 	filteredNodesToCheck := make([]*apiv1.Node, 0)
@@ -402,16 +430,23 @@ func (sd *ScaleDown) UpdateUnneededNodes(
 		glog.V(4).Infof("Node %s - utilization %f", node.Name, utilization)
 		utilizationMap[node.Name] = utilization
 
-		if utilization >= sd.context.ScaleDownUtilizationThreshold {
+		nodeGroup, err := sd.context.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			glog.Warningf("Failed to get node group for %s, using default scale-down utilization threshold: %v", node.Name, err)
+		}
+		threshold := cloudprovider.GetNodeGroupOptions(nodeGroup, sd.context.NodeGroupDefaults()).ScaleDownUtilizationThreshold
+		if utilization >= threshold {
 			glog.V(4).Infof("Node %s is not suitable for removal - utilization too big (%f)", node.Name, utilization)
 			continue
 		}
 		currentlyUnneededNodes = append(currentlyUnneededNodes, node)
 	}
+	// The order is kept by the candidate selection below, so the preferred nodes are simulated first.
+	currentlyUnneededNodes = sd.orderCandidates(currentlyUnneededNodes, utilizationMap, timestamp)
 
 	emptyNodes := make(map[string]bool)
 
-	emptyNodesList := getEmptyNodesNoResourceLimits(currentlyUnneededNodes, pods, len(currentlyUnneededNodes), sd.context.CloudProvider)
+	emptyNodesList := getEmptyNodesNoResourceLimits(currentlyUnneededNodes, pods, len(currentlyUnneededNodes), sd.context, timestamp)
 	for _, node := range emptyNodesList {
 		emptyNodes[node.Name] = true
 	}
@@ -429,7 +464,7 @@ func (sd *ScaleDown) UpdateUnneededNodes(
 	// Look for nodes to remove in the current candidates
 	nodesToRemove, unremovable, newHints, simulatorErr := simulator.FindNodesToRemove(
 		currentCandidates, nodes, nonExpendablePods, nil, sd.context.PredicateChecker,
-		len(currentCandidates), true, sd.podLocationHints, sd.usageTracker, timestamp, pdbs)
+		len(currentCandidates), true, sd.podLocationHints, sd.usageTracker, timestamp, getScratchPodSelector(sd.context), pdbs)
 	if simulatorErr != nil {
 		return sd.markSimulationError(simulatorErr, timestamp)
 	}
@@ -452,7 +487,7 @@ func (sd *ScaleDown) UpdateUnneededNodes(
 		additionalNodesToRemove, additionalUnremovable, additionalNewHints, simulatorErr :=
 			simulator.FindNodesToRemove(currentNonCandidates[:additionalCandidatesPoolSize], nodes, nonExpendablePods, nil,
 				sd.context.PredicateChecker, additionalCandidatesCount, true,
-				sd.podLocationHints, sd.usageTracker, timestamp, pdbs)
+				sd.podLocationHints, sd.usageTracker, timestamp, getScratchPodSelector(sd.context), pdbs)
 		if simulatorErr != nil {
 			return sd.markSimulationError(simulatorErr, timestamp)
 		}
@@ -554,6 +589,15 @@ func (sd *ScaleDown) chooseCandidates(nodes []*apiv1.Node) ([]*apiv1.Node, []*ap
 	return currentCandidates, currentNonCandidates
 }
 
+// orderCandidates returns the scale-down candidates in the order they should be removed in, according
+// to the configured scale-down order. The candidates are returned as they are if no order is configured.
+func (sd *ScaleDown) orderCandidates(candidates []*apiv1.Node, utilization map[string]float64, timestamp time.Time) []*apiv1.Node {
+	if sd.context.ScaleDownOrder == nil {
+		return candidates
+	}
+	return sd.context.ScaleDownOrder.Order(candidates, utilization, timestamp)
+}
+
 // TryToScaleDown tries to scale down the cluster. It returns ScaleDownResult indicating if any node was
 // removed and error if such occurred.
 func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, pdbs []*policyv1.PodDisruptionBudget, currentTime time.Time) (ScaleDownResult, errors.AutoscalerError) {
@@ -574,6 +618,15 @@ func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, p
 
 	scaleDownResourcesLeft := computeScaleDownResourcesLeftLimits(nodesWithoutMaster, resourceLimiter, sd.context.CloudProvider, currentTime)
 
+	headroomLeft := computeHeadroomLimits(sd.context, nodesWithoutMaster, pods, currentTime)
+
+	blackouts := getScaleDownBlackouts(sd.context, currentTime)
+	if blackouts.Cluster {
+		glog.V(1).Infof("Scale down blocked by schedule")
+		return ScaleDownBlockedBySchedule, nil
+	}
+	blockedBySchedule := false
+
 	nodeGroupSize := getNodeGroupSizeMap(sd.context.CloudProvider)
 	resourcesWithLimits := resourceLimiter.GetResources()
 	for _, node := range nodesWithoutMaster {
@@ -590,23 +643,30 @@ func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, p
 			ready, _, _ := kube_util.GetReadinessState(node)
 			readinessMap[node.Name] = ready
 
-			// Check how long the node was underutilized.
-			if ready && !val.Add(sd.context.ScaleDownUnneededTime).Before(currentTime) {
+			nodeGroup, err := sd.context.CloudProvider.NodeGroupForNode(node)
+			if err != nil {
+				glog.Errorf("Error while checking node group for %s: %v", node.Name, err)
+				continue
+			}
+			if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+				glog.V(4).Infof("Skipping %s - no node group config", node.Name)
 				continue
 			}
+			nodeGroupOptions := cloudprovider.GetNodeGroupOptions(nodeGroup, sd.context.NodeGroupDefaults())
 
-			// Unready nodes may be deleted after a different time than underutilized nodes.
-			if !ready && !val.Add(sd.context.ScaleDownUnreadyTime).Before(currentTime) {
+			// Check how long the node was underutilized.
+			if ready && !val.Add(nodeGroupOptions.ScaleDownUnneededTime).Before(currentTime) {
 				continue
 			}
 
-			nodeGroup, err := sd.context.CloudProvider.NodeGroupForNode(node)
-			if err != nil {
-				glog.Errorf("Error while checking node group for %s: %v", node.Name, err)
+			// Unready nodes may be deleted after a different time than underutilized nodes.
+			if !ready && !val.Add(nodeGroupOptions.ScaleDownUnreadyTime).Before(currentTime) {
 				continue
 			}
-			if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
-				glog.V(4).Infof("Skipping %s - no node group config", node.Name)
+
+			if blackouts.NodeGroups[nodeGroup.Id()] {
+				glog.V(4).Infof("Skipping %s - node group scale down blocked by schedule", node.Name)
+				blockedBySchedule = true
 				continue
 			}
 
@@ -616,7 +676,7 @@ func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, p
 				continue
 			}
 
-			if size <= nodeGroup.MinSize() {
+			if size <= getMinSize(sd.context, nodeGroup, currentTime) {
 				glog.V(1).Infof("Skipping %s - node group min size reached", node.Name)
 				continue
 			}
@@ -633,20 +693,38 @@ func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, p
 				continue
 			}
 
+			if !headroomLeft.canRemoveNode(node, nodeGroup) {
+				glog.V(4).Infof("Skipping %s - headroom would be exceeded", node.Name)
+				continue
+			}
+
 			candidates = append(candidates, node)
 			candidateNodeGroups[node.Name] = nodeGroup
 		}
 	}
 	if len(candidates) == 0 {
+		if blockedBySchedule {
+			glog.V(1).Infof("No candidates for scale down, some node groups blocked by schedule")
+			return ScaleDownBlockedBySchedule, nil
+		}
 		glog.V(1).Infof("No candidates for scale down")
 		return ScaleDownNoUnneeded, nil
 	}
+	candidates = sd.orderCandidates(candidates, sd.nodeUtilizationMap, currentTime)
 
 	// Trying to delete empty nodes in bulk. If there are no empty nodes then CA will
 	// try to delete not-so-empty nodes, possibly killing some pods and allowing them
 	// to recreate on other nodes.
-	emptyNodes := getEmptyNodes(candidates, pods, sd.context.MaxEmptyBulkDelete, scaleDownResourcesLeft, sd.context.CloudProvider)
+	emptyNodes := getEmptyNodes(candidates, pods, sd.context.MaxEmptyBulkDelete, scaleDownResourcesLeft, headroomLeft, sd.context, currentTime)
 	if len(emptyNodes) > 0 {
+		if sd.context.DryRun {
+			for _, node := range emptyNodes {
+				glog.V(0).Infof("Scale-down (dry run): would remove empty node %s", node.Name)
+				sd.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDownEmptyDryRun", "Scale-down (dry run): would remove empty node %s", node.Name)
+			}
+			metrics.RegisterDryRunDecision(metrics.DryRunScaleDown, len(emptyNodes))
+			return ScaleDownNoNodeDeleted, nil
+		}
 		nodeDeletionStart := time.Now()
 		confirmation := make(chan errors.AutoscalerError, len(emptyNodes))
 		sd.scheduleDeleteEmptyNodes(emptyNodes, sd.context.ClientSet, sd.context.Recorder, readinessMap, candidateNodeGroups, confirmation)
@@ -661,55 +739,137 @@ func (sd *ScaleDown) TryToScaleDown(allNodes []*apiv1.Node, pods []*apiv1.Pod, p
 	findNodesToRemoveStart := time.Now()
 	// Only scheduled non expendable pods are taken into account and have to be moved.
 	nonExpendablePods := FilterOutExpendablePods(pods, sd.context.ExpendablePodsPriorityCutoff)
-	// We look for only 1 node so new hints may be incomplete.
+	// We look for only a few nodes so new hints may be incomplete.
 	nodesToRemove, _, _, err := simulator.FindNodesToRemove(candidates, nodesWithoutMaster, nonExpendablePods, sd.context.ClientSet,
-		sd.context.PredicateChecker, 1, false,
-		sd.podLocationHints, sd.usageTracker, time.Now(), pdbs)
+		sd.context.PredicateChecker, maxDrainParallelism(sd.context), false,
+		sd.podLocationHints, sd.usageTracker, time.Now(), getScratchPodSelector(sd.context), pdbs)
 	findNodesToRemoveDuration = time.Now().Sub(findNodesToRemoveStart)
 
 	if err != nil {
 		return ScaleDownError, err.AddPrefix("Find node to remove failed: ")
 	}
+	nodesToRemove = filterNodesToRemoveWithinLimits(nodesToRemove, nodeGroupSize, candidateNodeGroups, scaleDownResourcesLeft,
+		resourcesWithLimits, headroomLeft, sd.context, currentTime)
 	if len(nodesToRemove) == 0 {
 		glog.V(1).Infof("No node to remove")
 		return ScaleDownNoNodeDeleted, nil
 	}
-	toRemove := nodesToRemove[0]
-	utilization := sd.nodeUtilizationMap[toRemove.Node.Name]
-	podNames := make([]string, 0, len(toRemove.PodsToReschedule))
-	for _, pod := range toRemove.PodsToReschedule {
-		podNames = append(podNames, pod.Namespace+"/"+pod.Name)
+	if sd.context.DryRun {
+		for _, toRemove := range nodesToRemove {
+			utilization := sd.nodeUtilizationMap[toRemove.Node.Name]
+			podNames := getPodNames(toRemove.PodsToReschedule)
+			glog.V(0).Infof("Scale-down (dry run): would remove node %s, utilization: %v, pods to reschedule: %s", toRemove.Node.Name, utilization,
+				podNames)
+			sd.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDownDryRun", "Scale-down (dry run): would remove node %s, utilization: %v, pods to reschedule: %s",
+				toRemove.Node.Name, utilization, podNames)
+		}
+		metrics.RegisterDryRunDecision(metrics.DryRunScaleDown, len(nodesToRemove))
+		return ScaleDownNoNodeDeleted, nil
 	}
-	glog.V(0).Infof("Scale-down: removing node %s, utilization: %v, pods to reschedule: %s", toRemove.Node.Name, utilization,
-		strings.Join(podNames, ","))
-	sd.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDown", "Scale-down: removing node %s, utilization: %v, pods to reschedule: %s",
-		toRemove.Node.Name, utilization, strings.Join(podNames, ","))
-
-	// Nothing super-bad should happen if the node is removed from tracker prematurely.
-	simulator.RemoveNodeFromTracker(sd.usageTracker, toRemove.Node.Name, sd.unneededNodes)
-	nodeDeletionStart := time.Now()
 
 	// Starting deletion.
+	nodeDeletionStart := time.Now()
+	reasons := make(map[string]metrics.NodeScaleDownReason, len(nodesToRemove))
+	for _, toRemove := range nodesToRemove {
+		if readinessMap[toRemove.Node.Name] {
+			reasons[toRemove.Node.Name] = metrics.Underutilized
+		} else {
+			reasons[toRemove.Node.Name] = metrics.Unready
+		}
+	}
+	sd.startNodeDeletions(nodesToRemove, candidateNodeGroups, reasons)
 	nodeDeletionDuration = time.Now().Sub(nodeDeletionStart)
+
+	return ScaleDownNodeDeleteStarted, nil
+}
+
+// startNodeDeletions drains and deletes the given nodes in the background, all at the same time.
+// The deletion is in progress until all of them are deleted or failed to be deleted. Node groups
+// and reasons are used for metrics, by node name.
+func (sd *ScaleDown) startNodeDeletions(nodesToRemove []simulator.NodeToBeRemoved, nodeGroups map[string]cloudprovider.NodeGroup,
+	reasons map[string]metrics.NodeScaleDownReason) {
 	sd.nodeDeleteStatus.SetDeleteInProgress(true)
+	var deletions sync.WaitGroup
+	for _, toRemove := range nodesToRemove {
+		utilization := sd.nodeUtilizationMap[toRemove.Node.Name]
+		podNames := getPodNames(toRemove.PodsToReschedule)
+		glog.V(0).Infof("Scale-down: removing node %s, utilization: %v, pods to reschedule: %s", toRemove.Node.Name, utilization,
+			podNames)
+		sd.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDown", "Scale-down: removing node %s, utilization: %v, pods to reschedule: %s",
+			toRemove.Node.Name, utilization, podNames)
+
+		// Nothing super-bad should happen if the node is removed from tracker prematurely.
+		simulator.RemoveNodeFromTracker(sd.usageTracker, toRemove.Node.Name, sd.unneededNodes)
+
+		deletions.Add(1)
+		go func(toRemove simulator.NodeToBeRemoved) {
+			defer deletions.Done()
+			err := sd.deleteNode(toRemove.Node, toRemove.PodsToReschedule)
+			sd.nodeDeleteStatus.AddNodeDeleteResult(toRemove.Node.Name, err)
+			if err != nil {
+				glog.Errorf("Failed to delete %s: %v", toRemove.Node.Name, err)
+				return
+			}
+			metrics.RegisterScaleDown(1, gpu.GetGpuTypeForMetrics(toRemove.Node, nodeGroups[toRemove.Node.Name]), reasons[toRemove.Node.Name])
+		}(toRemove)
+	}
 
 	go func() {
-		// Finishing the delete process once this goroutine is over.
-		defer sd.nodeDeleteStatus.SetDeleteInProgress(false)
-		err := sd.deleteNode(toRemove.Node, toRemove.PodsToReschedule)
+		// Finishing the delete process once all the deletions are over.
+		deletions.Wait()
+		sd.nodeDeleteStatus.SetDeleteInProgress(false)
+	}()
+}
+
+// maxDrainParallelism returns the number of non-empty nodes that can be removed at the same time.
+func maxDrainParallelism(context *context.AutoscalingContext) int {
+	if context.MaxDrainParallelism < 1 {
+		return 1
+	}
+	return context.MaxDrainParallelism
+}
+
+// filterNodesToRemoveWithinLimits returns the nodes that can be removed together without going below
+// the min sizes of node groups, the cluster resource limits or the headroom.
+func filterNodesToRemoveWithinLimits(nodesToRemove []simulator.NodeToBeRemoved, nodeGroupSize map[string]int,
+	nodeGroups map[string]cloudprovider.NodeGroup, resourcesLimits scaleDownResourcesLimits, resourcesWithLimits []string,
+	headroomLimits *headroomLimits, context *context.AutoscalingContext, timestamp time.Time) []simulator.NodeToBeRemoved {
+	resourcesLimitsCopy := copyScaleDownResourcesLimits(resourcesLimits)
+	headroomLimitsCopy := headroomLimits.copy()
+	removedFromGroup := make(map[string]int)
+	result := make([]simulator.NodeToBeRemoved, 0, len(nodesToRemove))
+	for _, toRemove := range nodesToRemove {
+		nodeGroup := nodeGroups[toRemove.Node.Name]
+		if nodeGroupSize[nodeGroup.Id()]-removedFromGroup[nodeGroup.Id()] <= getMinSize(context, nodeGroup, timestamp) {
+			glog.V(4).Infof("Not removing %s together with other nodes - node group min size reached", toRemove.Node.Name)
+			continue
+		}
+		resourcesDelta, err := computeScaleDownResourcesDelta(toRemove.Node, nodeGroup, resourcesWithLimits)
 		if err != nil {
-			glog.Errorf("Failed to delete %s: %v", toRemove.Node.Name, err)
-			return
+			glog.Errorf("Error getting node resources: %v", err)
+			continue
 		}
-		nodeGroup := candidateNodeGroups[toRemove.Node.Name]
-		if readinessMap[toRemove.Node.Name] {
-			metrics.RegisterScaleDown(1, gpu.GetGpuTypeForMetrics(toRemove.Node, nodeGroup), metrics.Underutilized)
-		} else {
-			metrics.RegisterScaleDown(1, gpu.GetGpuTypeForMetrics(toRemove.Node, nodeGroup), metrics.Unready)
+		if !headroomLimitsCopy.canRemoveNode(toRemove.Node, nodeGroup) {
+			glog.V(4).Infof("Not removing %s together with other nodes - headroom would be exceeded", toRemove.Node.Name)
+			continue
 		}
-	}()
+		if checkResult := resourcesLimitsCopy.tryDecrementLimitsByDelta(resourcesDelta); checkResult.exceeded {
+			glog.V(4).Infof("Not removing %s together with other nodes - minimal limit exceeded for %v", toRemove.Node.Name, checkResult.exceededResources)
+			continue
+		}
+		headroomLimitsCopy.tryRemoveNode(toRemove.Node, nodeGroup)
+		removedFromGroup[nodeGroup.Id()]++
+		result = append(result, toRemove)
+	}
+	return result
+}
 
-	return ScaleDownNodeDeleteStarted, nil
+func getPodNames(pods []*apiv1.Pod) string {
+	podNames := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podNames = append(podNames, pod.Namespace+"/"+pod.Name)
+	}
+	return strings.Join(podNames, ",")
 }
 
 // updateScaleDownMetrics registers duration of different parts of scale down.
@@ -722,24 +882,67 @@ func updateScaleDownMetrics(scaleDownStart time.Time, findNodesToRemoveDuration
 	metrics.UpdateDuration(metrics.ScaleDownMiscOperations, miscDuration)
 }
 
+// getScaleDownBlackouts returns where scale-down is blocked by schedule at the given time.
+func getScaleDownBlackouts(context *context.AutoscalingContext, now time.Time) clusterstate.ScaleDownBlackouts {
+	blackouts := clusterstate.ScaleDownBlackouts{NodeGroups: make(map[string]bool)}
+	if context.ScaleDownBlackouts == nil {
+		return blackouts
+	}
+	blackouts.Cluster = context.ScaleDownBlackouts.ClusterBlackedOut(now)
+	for _, nodeGroup := range context.CloudProvider.NodeGroups() {
+		if blackouts.Cluster || context.ScaleDownBlackouts.NodeGroupBlackedOut(nodeGroup, now) {
+			blackouts.NodeGroups[nodeGroup.Id()] = true
+		}
+	}
+	return blackouts
+}
+
+// getRemovableNodeGroups returns the node groups of the nodes that may be removed now, by node name. Nodes
+// outside of node groups, with scale-down disabled, being deleted or blocked by a scale-down blackout
+// are left out.
+func getRemovableNodeGroups(context *context.AutoscalingContext, nodes []*apiv1.Node, now time.Time) map[string]cloudprovider.NodeGroup {
+	blackouts := getScaleDownBlackouts(context, now)
+	result := make(map[string]cloudprovider.NodeGroup)
+	if blackouts.Cluster {
+		return result
+	}
+	for _, node := range nodes {
+		if hasNoScaleDownAnnotation(node) || isNodeBeingDeleted(node, now) {
+			continue
+		}
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			glog.Errorf("Error while checking node group for %s: %v", node.Name, err)
+			continue
+		}
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() || blackouts.NodeGroups[nodeGroup.Id()] {
+			continue
+		}
+		result[node.Name] = nodeGroup
+	}
+	return result
+}
+
 func getEmptyNodesNoResourceLimits(candidates []*apiv1.Node, pods []*apiv1.Pod, maxEmptyBulkDelete int,
-	cloudProvider cloudprovider.CloudProvider) []*apiv1.Node {
-	return getEmptyNodes(candidates, pods, maxEmptyBulkDelete, noScaleDownLimitsOnResources(), cloudProvider)
+	context *context.AutoscalingContext, timestamp time.Time) []*apiv1.Node {
+	return getEmptyNodes(candidates, pods, maxEmptyBulkDelete, noScaleDownLimitsOnResources(), nil, context, timestamp)
 }
 
 // This functions finds empty nodes among passed candidates and returns a list of empty nodes
-// that can be deleted at the same time.
+// that can be deleted at the same time. Nil headroom limits mean no headroom is kept.
 func getEmptyNodes(candidates []*apiv1.Node, pods []*apiv1.Pod, maxEmptyBulkDelete int,
-	resourcesLimits scaleDownResourcesLimits, cloudProvider cloudprovider.CloudProvider) []*apiv1.Node {
+	resourcesLimits scaleDownResourcesLimits, headroomLimits *headroomLimits, context *context.AutoscalingContext,
+	timestamp time.Time) []*apiv1.Node {
 
 	emptyNodes := simulator.FindEmptyNodesToRemove(candidates, pods)
 	availabilityMap := make(map[string]int)
 	result := make([]*apiv1.Node, 0)
 	resourcesLimitsCopy := copyScaleDownResourcesLimits(resourcesLimits) // we do not want to modify input parameter
+	headroomLimitsCopy := headroomLimits.copy()
 	resourcesNames := sets.StringKeySet(resourcesLimits).List()
 
 	for _, node := range emptyNodes {
-		nodeGroup, err := cloudProvider.NodeGroupForNode(node)
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
 		if err != nil {
 			glog.Errorf("Failed to get group for %s", node.Name)
 			continue
@@ -756,7 +959,7 @@ func getEmptyNodes(candidates []*apiv1.Node, pods []*apiv1.Pod, maxEmptyBulkDele
 				glog.Errorf("Failed to get size for %s: %v ", nodeGroup.Id(), err)
 				continue
 			}
-			available = size - nodeGroup.MinSize()
+			available = size - getMinSize(context, nodeGroup, timestamp)
 			if available < 0 {
 				available = 0
 			}
@@ -768,10 +971,14 @@ func getEmptyNodes(candidates []*apiv1.Node, pods []*apiv1.Pod, maxEmptyBulkDele
 				glog.Errorf("Error: %v", err)
 				continue
 			}
+			if !headroomLimitsCopy.canRemoveNode(node, nodeGroup) {
+				continue
+			}
 			checkResult := resourcesLimitsCopy.tryDecrementLimitsByDelta(resourcesDelta)
 			if checkResult.exceeded {
 				continue
 			}
+			headroomLimitsCopy.tryRemoveNode(node, nodeGroup)
 			available -= 1
 			availabilityMap[nodeGroup.Id()] = available
 			result = append(result, node)
@@ -30,14 +30,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/scaledownorder"
+	"k8s.io/autoscaler/cluster-autoscaler/schedule"
+	autoscaler_errors "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 	"k8s.io/client-go/kubernetes/fake"
+	v1lister "k8s.io/client-go/listers/core/v1"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
 
 	"strconv"
 
@@ -317,6 +324,46 @@ func TestFindUnneededMaxCandidates(t *testing.T) {
 	assert.NotContains(t, sd.unneededNodes, deleted)
 }
 
+func TestFindUnneededNodesOrder(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 100, 10)
+
+	ownerRef := GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	nodes := make([]*apiv1.Node, 0, 10)
+	pods := make([]*apiv1.Pod, 0, 10)
+	for i := 0; i < 10; i++ {
+		n := BuildTestNode(fmt.Sprintf("n%v", i), 1000, 10)
+		SetNodeReadyState(n, true, time.Time{})
+		provider.AddNode("ng1", n)
+		nodes = append(nodes, n)
+
+		p := BuildTestPod(fmt.Sprintf("p%v", i), 100, 0)
+		p.Spec.NodeName = n.Name
+		p.OwnerReferences = ownerRef
+		pods = append(pods, p)
+	}
+	nodes[7].Annotations = map[string]string{scaledownorder.DeletionPriorityAnnotationKey: "10"}
+	nodes[3].Annotations = map[string]string{scaledownorder.DeletionPriorityAnnotationKey: "5"}
+
+	options := config.AutoscalingOptions{
+		ScaleDownUtilizationThreshold:    0.35,
+		ScaleDownNonEmptyCandidatesCount: 2,
+		ScaleDownCandidatesPoolRatio:     1,
+		ScaleDownCandidatesPoolMinCount:  1000,
+	}
+	context := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, provider)
+	context.ScaleDownOrder = scaledownorder.NewStrategy(scaledownorder.NewDeletionPriorityPolicy())
+
+	clusterStateRegistry := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	sd := NewScaleDown(&context, clusterStateRegistry)
+
+	sd.UpdateUnneededNodes(nodes, nodes, pods, time.Now(), nil)
+	assert.Equal(t, 2, len(sd.unneededNodes))
+	assert.Contains(t, sd.unneededNodes, "n7")
+	assert.Contains(t, sd.unneededNodes, "n3")
+	assert.Equal(t, "n7", sd.unneededNodesList[0].Name)
+}
+
 func TestFindUnneededEmptyNodes(t *testing.T) {
 	provider := testprovider.NewTestCloudProvider(nil, nil)
 	provider.AddNodeGroup("ng1", 1, 100, 100)
@@ -772,6 +819,99 @@ func TestScaleDown(t *testing.T) {
 	assert.Equal(t, n1.Name, getStringFromChan(updatedNodes))
 }
 
+func TestScaleDownParallelDrain(t *testing.T) {
+	testCases := []struct {
+		name                string
+		minSize             int
+		maxDrainParallelism int
+		expectedDeleted     int
+	}{
+		{"single node by default", 1, 0, 1},
+		{"two nodes", 1, 2, 2},
+		{"limited by min size", 3, 3, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deletedNodes := make(chan string, 10)
+			fakeClient := &fake.Clientset{}
+			nodes := make([]*apiv1.Node, 0)
+			nodesMap := make(map[string]*apiv1.Node)
+			pods := make([]*apiv1.Pod, 0)
+			provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+				deletedNodes <- node
+				return nil
+			})
+			provider.AddNodeGroup("ng1", tc.minSize, 10, 4)
+			for i := 1; i <= 4; i++ {
+				node := BuildTestNode(fmt.Sprintf("n%d", i), 1000, 1000)
+				SetNodeReadyState(node, true, time.Time{})
+				provider.AddNode("ng1", node)
+				nodes = append(nodes, node)
+				nodesMap[node.Name] = node
+				pod := BuildTestPod(fmt.Sprintf("p%d", i), 200, 0)
+				pod.OwnerReferences = GenerateOwnerReferences("job", "Job", "extensions/v1beta1", "")
+				pod.Spec.NodeName = node.Name
+				pods = append(pods, pod)
+			}
+
+			fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewNotFound(apiv1.Resource("pod"), "whatever")
+			})
+			fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				getAction := action.(core.GetAction)
+				if node, found := nodesMap[getAction.GetName()]; found {
+					return true, node, nil
+				}
+				return true, nil, fmt.Errorf("Wrong node: %v", getAction.GetName())
+			})
+			fakeClient.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				return true, action.(core.UpdateAction).GetObject(), nil
+			})
+
+			options := config.AutoscalingOptions{
+				ScaleDownUtilizationThreshold: 0.5,
+				ScaleDownUnneededTime:         time.Minute,
+				MaxGracefulTerminationSec:     60,
+				MaxDrainParallelism:           tc.maxDrainParallelism,
+			}
+			context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+			// Parallel deletions emit more events than the default fake recorder can buffer.
+			context.Recorder = kube_record.NewFakeRecorder(100)
+			clusterStateRegistry := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+			scaleDown := NewScaleDown(&context, clusterStateRegistry)
+			scaleDown.UpdateUnneededNodes(nodes, nodes, pods, time.Now().Add(-5*time.Minute), nil)
+			result, err := scaleDown.TryToScaleDown(nodes, pods, nil, time.Now())
+			waitForDeleteToFinish(t, scaleDown)
+			close(deletedNodes)
+			assert.NoError(t, err)
+			assert.Equal(t, ScaleDownNodeDeleteStarted, result)
+
+			deleted := make([]string, 0)
+			for node := range deletedNodes {
+				deleted = append(deleted, node)
+			}
+			assert.Equal(t, tc.expectedDeleted, len(deleted))
+			results := scaleDown.nodeDeleteStatus.DrainNodeDeleteResults()
+			assert.Equal(t, tc.expectedDeleted, len(results))
+			for _, node := range deleted {
+				assert.Contains(t, results, node)
+				assert.Nil(t, results[node])
+			}
+			assert.Empty(t, scaleDown.nodeDeleteStatus.DrainNodeDeleteResults())
+		})
+	}
+}
+
+func TestNodeDeleteStatusResults(t *testing.T) {
+	status := &NodeDeleteStatus{}
+	assert.Empty(t, status.DrainNodeDeleteResults())
+	failure := autoscaler_errors.NewAutoscalerError(autoscaler_errors.ApiCallError, "failed to drain")
+	status.AddNodeDeleteResult("n1", nil)
+	status.AddNodeDeleteResult("n2", failure)
+	assert.Equal(t, map[string]autoscaler_errors.AutoscalerError{"n1": nil, "n2": failure}, status.DrainNodeDeleteResults())
+	assert.Empty(t, status.DrainNodeDeleteResults())
+}
+
 func waitForDeleteToFinish(t *testing.T, sd *ScaleDown) {
 	for start := time.Now(); time.Since(start) < 20*time.Second; time.Sleep(100 * time.Millisecond) {
 		if !sd.nodeDeleteStatus.IsDeleteInProgress() {
@@ -899,6 +1039,66 @@ func TestScaleDownEmptyMinGpuLimitHit(t *testing.T) {
 	simpleScaleDownEmpty(t, config)
 }
 
+func TestScaleDownEmptyNodeGroupHeadroomHit(t *testing.T) {
+	options := defaultScaleDownOptions
+	options.HeadroomNodes = 2
+	config := &scaleTestConfig{
+		nodes: []nodeConfig{
+			{"n1", 1000, 1000, 0, true, "ng1"},
+			{"n2", 1000, 1000, 0, true, "ng1"},
+			{"n3", 1000, 1000, 0, true, "ng1"},
+		},
+		options:            options,
+		expectedScaleDowns: []string{"n1"},
+	}
+	simpleScaleDownEmpty(t, config)
+}
+
+func TestScaleDownEmptyNodeGroupHeadroomCoresHit(t *testing.T) {
+	options := defaultScaleDownOptions
+	options.NodeGroupHeadroomCores = 2
+	config := &scaleTestConfig{
+		nodes: []nodeConfig{
+			{"n1", 1000, 1000, 0, true, "ng1"},
+			{"n2", 1000, 1000, 0, true, "ng1"},
+			{"n3", 1000, 1000, 0, true, "ng1"},
+		},
+		options:            options,
+		expectedScaleDowns: []string{"n1"},
+	}
+	simpleScaleDownEmpty(t, config)
+}
+
+func TestScaleDownEmptyClusterHeadroomHit(t *testing.T) {
+	options := defaultScaleDownOptions
+	options.HeadroomCores = 2
+	config := &scaleTestConfig{
+		nodes: []nodeConfig{
+			{"n1", 1000, 1000, 0, true, "ng1"},
+			{"n2", 1000, 1000, 0, true, "ng1"},
+			{"n3", 1000, 1000, 0, true, "ng1"},
+		},
+		options:            options,
+		expectedScaleDowns: []string{"n1"},
+	}
+	simpleScaleDownEmpty(t, config)
+}
+
+func TestScaleDownEmptyClusterHeadroomNodesHit(t *testing.T) {
+	options := defaultScaleDownOptions
+	options.ClusterHeadroomNodes = 2
+	config := &scaleTestConfig{
+		nodes: []nodeConfig{
+			{"n1", 1000, 1000, 0, true, "ng1"},
+			{"n2", 1000, 1000, 0, true, "ng1"},
+			{"n3", 1000, 1000, 0, true, "ng1"},
+		},
+		options:            options,
+		expectedScaleDowns: []string{"n1"},
+	}
+	simpleScaleDownEmpty(t, config)
+}
+
 func TestScaleDownEmptyMinGroupSizeLimitHit(t *testing.T) {
 	options := defaultScaleDownOptions
 	config := &scaleTestConfig{
@@ -1079,6 +1279,88 @@ func TestNoScaleDownUnready(t *testing.T) {
 	assert.Equal(t, n1.Name, getStringFromChan(deletedNodes))
 }
 
+func TestScaleDownWithNodeGroupOptions(t *testing.T) {
+	fakeClient := &fake.Clientset{}
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Time{})
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, time.Time{})
+	n3 := BuildTestNode("n3", 1000, 1000)
+	SetNodeReadyState(n3, true, time.Time{})
+	ownerRef := GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	p1 := BuildTestPod("p1", 600, 0)
+	p1.Spec.NodeName = "n1"
+	p1.OwnerReferences = ownerRef
+	p2 := BuildTestPod("p2", 600, 0)
+	p2.Spec.NodeName = "n2"
+	p2.OwnerReferences = ownerRef
+
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{*p2}}, nil
+	})
+	fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewNotFound(apiv1.Resource("pod"), "whatever")
+	})
+	fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		getAction := action.(core.GetAction)
+		switch getAction.GetName() {
+		case n1.Name:
+			return true, n1, nil
+		case n2.Name:
+			return true, n2, nil
+		case n3.Name:
+			return true, n3, nil
+		}
+		return true, nil, fmt.Errorf("Wrong node: %v", getAction.GetName())
+	})
+
+	deletedNodes := make(chan string, 10)
+	provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+		deletedNodes <- node
+		return nil
+	})
+	provider.AddNodeGroup("ng1", 1, 10, 2)
+	provider.AddNodeGroupWithCustomOptions("ng2", 0, 10, 1, &config.NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.9,
+		ScaleDownUnneededTime:         time.Minute,
+		ScaleDownUnreadyTime:          time.Minute,
+	})
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng2", n2)
+	provider.AddNode("ng1", n3)
+
+	options := config.AutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		ScaleDownUnneededTime:         time.Hour,
+		ScaleDownUnreadyTime:          time.Hour,
+		MaxGracefulTerminationSec:     60,
+	}
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+
+	nodes := []*apiv1.Node{n1, n2, n3}
+	pods := []*apiv1.Pod{p1, p2}
+	clusterStateRegistry := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	scaleDown := NewScaleDown(&context, clusterStateRegistry)
+	scaleDown.UpdateUnneededNodes(nodes, nodes, pods, time.Now().Add(-5*time.Minute), nil)
+
+	// N1 is above the default utilization threshold, n2 is below the threshold of its node group.
+	_, found := scaleDown.unneededNodes["n1"]
+	assert.False(t, found)
+	_, found = scaleDown.unneededNodes["n2"]
+	assert.True(t, found)
+	_, found = scaleDown.unneededNodes["n3"]
+	assert.True(t, found)
+
+	// Only n2 has been unneeded for longer than the unneeded time of its node group.
+	result, err := scaleDown.TryToScaleDown(nodes, pods, nil, time.Now())
+	waitForDeleteToFinish(t, scaleDown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ScaleDownNodeDeleteStarted, result)
+	assert.Equal(t, n2.Name, getStringFromChan(deletedNodes))
+	assert.Equal(t, "Nothing returned", getStringFromChanImmediately(deletedNodes))
+}
+
 func TestScaleDownNoMove(t *testing.T) {
 	fakeClient := &fake.Clientset{}
 
@@ -1155,6 +1437,78 @@ func TestScaleDownNoMove(t *testing.T) {
 	assert.Equal(t, ScaleDownNoUnneeded, result)
 }
 
+func TestScaleDownDryRun(t *testing.T) {
+	fakeClient := &fake.Clientset{}
+
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job",
+			Namespace: "default",
+			SelfLink:  "/apivs/extensions/v1beta1/namespaces/default/jobs/job",
+		},
+	}
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Time{})
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, time.Time{})
+	n3 := BuildTestNode("n3", 1000, 1000)
+	SetNodeReadyState(n3, true, time.Time{})
+
+	p1 := BuildTestPod("p1", 100, 0)
+	p1.OwnerReferences = GenerateOwnerReferences(job.Name, "Job", "extensions/v1beta1", "")
+	p2 := BuildTestPod("p2", 800, 0)
+	p1.Spec.NodeName = "n1"
+	p2.Spec.NodeName = "n2"
+
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{*p1, *p2}}, nil
+	})
+	fakeClient.Fake.AddReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		t.Fatalf("Unexpected pod deletion in dry run")
+		return false, nil, nil
+	})
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		t.Fatalf("Unexpected pod eviction in dry run")
+		return false, nil, nil
+	})
+	fakeClient.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		t.Fatalf("Unexpected node update in dry run")
+		return false, nil, nil
+	})
+	provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+		t.Fatalf("Unexpected deletion of %s in dry run", node)
+		return nil
+	})
+	provider.AddNodeGroup("ng1", 1, 10, 3)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng1", n3)
+
+	options := defaultScaleDownOptions
+	options.DryRun = true
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+
+	clusterStateRegistry := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	scaleDown := NewScaleDown(&context, clusterStateRegistry)
+
+	// n3 is empty.
+	nodes := []*apiv1.Node{n1, n2, n3}
+	pods := []*apiv1.Pod{p1, p2}
+	scaleDown.UpdateUnneededNodes(nodes, nodes, pods, time.Now().Add(-5*time.Minute), nil)
+	result, err := scaleDown.TryToScaleDown(nodes, pods, nil, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ScaleDownNoNodeDeleted, result)
+	assert.False(t, scaleDown.nodeDeleteStatus.IsDeleteInProgress())
+
+	// Without n3 only the drain of n1 is simulated.
+	nodes = []*apiv1.Node{n1, n2}
+	scaleDown.UpdateUnneededNodes(nodes, nodes, pods, time.Now().Add(-5*time.Minute), nil)
+	result, err = scaleDown.TryToScaleDown(nodes, pods, nil, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, ScaleDownNoNodeDeleted, result)
+	assert.False(t, scaleDown.nodeDeleteStatus.IsDeleteInProgress())
+}
+
 func getStringFromChan(c chan string) string {
 	select {
 	case val := <-c:
@@ -1330,3 +1684,89 @@ func TestCheckScaleDownDeltaWithinLimits(t *testing.T) {
 		}
 	}
 }
+
+func TestScaleDownBlockedBySchedule(t *testing.T) {
+	testCases := []struct {
+		name           string
+		blackouts      string
+		expectedResult ScaleDownResult
+		expectedStatus api.ClusterAutoscalerConditionStatus
+	}{
+		{
+			name:           "cluster blackout",
+			blackouts:      "- start: \"* * * * *\"\n  duration: 1m\n",
+			expectedResult: ScaleDownBlockedBySchedule,
+			expectedStatus: api.ClusterAutoscalerBlockedBySchedule,
+		},
+		{
+			name:           "node group blackout",
+			blackouts:      "- nodeGroup: ng1\n  start: \"* * * * *\"\n  duration: 1m\n",
+			expectedResult: ScaleDownBlockedBySchedule,
+			expectedStatus: api.ClusterAutoscalerCandidatesPresent,
+		},
+		{
+			name:           "other node group blackout",
+			blackouts:      "- nodeGroup: ng2\n  start: \"* * * * *\"\n  duration: 1m\n",
+			expectedResult: ScaleDownNodeDeleted,
+			expectedStatus: api.ClusterAutoscalerCandidatesPresent,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+			})
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Time{})
+			n2 := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(n2, true, time.Time{})
+			fakeClient.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				return true, n1, nil
+			})
+			fakeClient.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				return true, action.(core.UpdateAction).GetObject(), nil
+			})
+
+			provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+				return nil
+			})
+			provider.AddNodeGroup("ng1", 1, 10, 2)
+			provider.AddNodeGroup("ng2", 1, 10, 1)
+			provider.AddNode("ng1", n1)
+			provider.AddNode("ng1", n2)
+
+			store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			assert.NoError(t, store.Add(&apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "kube-system",
+					Name:            schedule.ScaleDownBlackoutsConfigMapName,
+					ResourceVersion: "1",
+				},
+				Data: map[string]string{schedule.ScaleDownBlackoutsConfigMapKey: tc.blackouts},
+			}))
+			context := NewScaleTestAutoscalingContext(defaultScaleDownOptions, fakeClient, provider)
+			context.ScaleDownBlackouts = schedule.NewScaleDownBlackouts(v1lister.NewConfigMapLister(store).ConfigMaps("kube-system"), context.LogRecorder)
+
+			nodes := []*apiv1.Node{n1, n2}
+			clusterStateRegistry := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+			scaleDown := NewScaleDown(&context, clusterStateRegistry)
+			scaleDown.UpdateUnneededNodes(nodes, nodes, []*apiv1.Pod{}, time.Now().Add(-5*time.Minute), nil)
+			result, err := scaleDown.TryToScaleDown(nodes, []*apiv1.Pod{}, nil, time.Now())
+			waitForDeleteToFinish(t, scaleDown)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+
+			status := clusterStateRegistry.GetStatus(time.Now())
+			assert.Equal(t, tc.expectedStatus,
+				api.GetConditionByType(api.ClusterAutoscalerScaleDown, status.ClusterwideConditions).Status)
+			for _, nodeGroupStatus := range status.NodeGroupStatuses {
+				if nodeGroupStatus.ProviderID == "ng1" {
+					condition := api.GetConditionByType(api.ClusterAutoscalerScaleDown, nodeGroupStatus.Conditions)
+					assert.Equal(t, tc.expectedResult == ScaleDownBlockedBySchedule,
+						condition.Status == api.ClusterAutoscalerBlockedBySchedule)
+				}
+			}
+		})
+	}
+}
@@ -417,9 +417,13 @@ func ScaleUp(context *context.AutoscalingContext, processors *ca_processors.Auto
 		return &status.ScaleUpStatus{ScaledUp: false, PodsRemainUnschedulable: getRemainingPods(podsRemainUnschedulable, skippedNodeGroups)}, nil
 	}
 
-	// Pick some expansion option.
-	bestOption := context.ExpanderStrategy.BestOption(expansionOptions, nodeInfos)
-	if bestOption != nil && bestOption.NodeCount > 0 {
+	// Pick some expansion option. If the chosen node group can't create instances for a reason
+	// other node groups may not share, like a stockout, fall back to the next best option.
+	for len(expansionOptions) > 0 {
+		bestOption := context.ExpanderStrategy.BestOption(expansionOptions, nodeInfos)
+		if bestOption == nil || bestOption.NodeCount <= 0 {
+			break
+		}
 		glog.V(1).Infof("Best option to resize: %s", bestOption.NodeGroup.Id())
 		if len(bestOption.Debug) > 0 {
 			glog.V(1).Info(bestOption.Debug)
@@ -438,7 +442,8 @@ func ScaleUp(context *context.AutoscalingContext, processors *ca_processors.Auto
 			}
 		}
 
-		if !bestOption.NodeGroup.Exist() {
+		// In dry run the node group is never created, the decision is only reported.
+		if !bestOption.NodeGroup.Exist() && !context.DryRun {
 			oldId := bestOption.NodeGroup.Id()
 			bestOption.NodeGroup, err = processors.NodeGroupManager.CreateNodeGroup(context, bestOption.NodeGroup)
 			if err != nil {
@@ -504,12 +509,30 @@ func ScaleUp(context *context.AutoscalingContext, processors *ca_processors.Auto
 			return nil, typedErr
 		}
 		glog.V(1).Infof("Final scale-up plan: %v", scaleUpInfos)
-		for _, info := range scaleUpInfos {
+		if context.DryRun {
+			for _, info := range scaleUpInfos {
+				reportDryRunScaleUp(context, info)
+			}
+			return &status.ScaleUpStatus{ScaledUp: false, PodsRemainUnschedulable: getRemainingPods(podsRemainUnschedulable, skippedNodeGroups)}, nil
+		}
+		fallBack := false
+		for i, info := range scaleUpInfos {
 			typedErr := executeScaleUp(context, clusterStateRegistry, info, gpu.GetGpuTypeForMetrics(nodeInfo.Node(), nil))
 			if typedErr != nil {
+				// Only fall back if nothing was scaled up yet, not to add more nodes than needed.
+				if i == 0 && canFallBackAfter(typedErr) {
+					glog.Warningf("Scale-up of node group %s failed, falling back to other node groups: %v", info.Group.Id(), typedErr)
+					fallBack = true
+					break
+				}
 				return nil, typedErr
 			}
 		}
+		if fallBack {
+			// The failed node group is backed off now, so it's filtered out.
+			expansionOptions = filterExpansionOptionsSafeToScaleUp(expansionOptions, clusterStateRegistry, now)
+			continue
+		}
 
 		clusterStateRegistry.Recalculate()
 		return &status.ScaleUpStatus{
@@ -587,7 +610,11 @@ func executeScaleUp(context *context.AutoscalingContext, clusterStateRegistry *c
 	increase := info.NewSize - info.CurrentSize
 	if err := info.Group.IncreaseSize(increase); err != nil {
 		context.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Scale-up failed for group %s: %v", info.Group.Id(), err)
-		clusterStateRegistry.RegisterFailedScaleUp(info.Group.Id(), metrics.APIError)
+		clusterStateRegistry.RegisterFailedScaleUp(info.Group.Id(), cloudprovider.GetInstanceErrorClass(err))
+		// Keep the class of the error, it decides whether to fall back to another node group.
+		if instanceErr, ok := err.(cloudprovider.InstanceCreationError); ok {
+			return instanceErr.AddPrefix("failed to increase node group size: ")
+		}
 		return errors.NewAutoscalerError(errors.CloudProviderError,
 			"failed to increase node group size: %v", err)
 	}
@@ -596,7 +623,7 @@ func executeScaleUp(context *context.AutoscalingContext, clusterStateRegistry *c
 			NodeGroupName:   info.Group.Id(),
 			Increase:        increase,
 			Time:            time.Now(),
-			ExpectedAddTime: time.Now().Add(context.MaxNodeProvisionTime),
+			ExpectedAddTime: time.Now().Add(cloudprovider.GetNodeGroupOptions(info.Group, context.NodeGroupDefaults()).MaxNodeProvisionTime),
 		})
 	metrics.RegisterScaleUp(increase, gpuType)
 	context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup",
@@ -604,6 +631,37 @@ func executeScaleUp(context *context.AutoscalingContext, clusterStateRegistry *c
 	return nil
 }
 
+// canFallBackAfter tells whether scale-up should try other node groups after the given error.
+// This is the case if instances couldn't be created because of the node group itself, e.g. its
+// zone is out of capacity, rather than because of a failing cloud provider API.
+func canFallBackAfter(err error) bool {
+	switch cloudprovider.GetInstanceErrorClass(err) {
+	case cloudprovider.QuotaExceededErrorClass, cloudprovider.StockoutErrorClass, cloudprovider.InvalidConfigurationErrorClass:
+		return true
+	}
+	return false
+}
+
+// filterExpansionOptionsSafeToScaleUp returns the expansion options of node groups that are still safe to scale up.
+func filterExpansionOptionsSafeToScaleUp(options []expander.Option, clusterStateRegistry *clusterstate.ClusterStateRegistry, now time.Time) []expander.Option {
+	result := make([]expander.Option, 0, len(options))
+	for _, option := range options {
+		if clusterStateRegistry.IsNodeGroupSafeToScaleUp(option.NodeGroup.Id(), now) {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+// reportDryRunScaleUp reports a scale-up that would have been executed if dry run was disabled.
+func reportDryRunScaleUp(context *context.AutoscalingContext, info nodegroupset.ScaleUpInfo) {
+	increase := info.NewSize - info.CurrentSize
+	glog.V(0).Infof("Scale-up (dry run): would set group %s size to %d", info.Group.Id(), info.NewSize)
+	context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleUpDryRun",
+		"Scale-up (dry run): would set group %s size to %d", info.Group.Id(), info.NewSize)
+	metrics.RegisterDryRunDecision(metrics.DryRunScaleUp, increase)
+}
+
 func applyScaleUpResourcesLimits(
 	newNodes int,
 	scaleUpResourcesLeft scaleUpResourcesLimits,
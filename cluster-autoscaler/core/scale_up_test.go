@@ -540,6 +540,56 @@ func TestScaleUpNodeComingNoScale(t *testing.T) {
 	assert.False(t, status.ScaledUp)
 }
 
+func TestScaleUpDryRun(t *testing.T) {
+	n1 := BuildTestNode("n1", 100, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, time.Now())
+
+	p1 := BuildTestPod("p1", 80, 0)
+	p2 := BuildTestPod("p2", 800, 0)
+	p1.Spec.NodeName = "n1"
+	p2.Spec.NodeName = "n2"
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		list := action.(core.ListAction)
+		fieldstring := list.GetListRestrictions().Fields.String()
+		if strings.Contains(fieldstring, "n1") {
+			return true, &apiv1.PodList{Items: []apiv1.Pod{*p1}}, nil
+		}
+		if strings.Contains(fieldstring, "n2") {
+			return true, &apiv1.PodList{Items: []apiv1.Pod{*p2}}, nil
+		}
+		return true, nil, fmt.Errorf("Failed to list: %v", list)
+	})
+
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		t.Fatalf("No expansion is expected in dry run, but increased %s by %d", nodeGroup, increase)
+		return nil
+	}, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng2", n2)
+
+	options := defaultOptions
+	options.DryRun = true
+	context := NewScaleTestAutoscalingContext(options, fakeClient, provider)
+
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes([]*apiv1.Node{n1, n2}, time.Now())
+
+	p3 := BuildTestPod("p-new", 500, 0)
+
+	processors := ca_processors.TestProcessors()
+
+	status, err := ScaleUp(&context, processors, clusterState, []*apiv1.Pod{p3}, []*apiv1.Node{n1, n2}, []*extensionsv1.DaemonSet{})
+	assert.NoError(t, err)
+	assert.False(t, status.ScaledUp)
+	assert.Empty(t, clusterState.GetUpcomingNodes())
+}
+
 func TestScaleUpNodeComingHasScale(t *testing.T) {
 	n1 := BuildTestNode("n1", 100, 1000)
 	SetNodeReadyState(n1, true, time.Now())
@@ -826,6 +876,76 @@ func TestScaleUpAutoprovisionedNodeGroup(t *testing.T) {
 	assert.Equal(t, "autoprovisioned-T1-1", getStringFromChan(expandedGroups))
 }
 
+// preferringStrategy picks the first node group of the preference list that has an expansion option.
+type preferringStrategy struct {
+	preferred []string
+}
+
+func (s preferringStrategy) BestOption(options []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) *expander.Option {
+	for _, id := range s.preferred {
+		for _, option := range options {
+			if option.NodeGroup.Id() == id {
+				return &option
+			}
+		}
+	}
+	return nil
+}
+
+func runScaleUpWithFailingNodeGroup(t *testing.T, scaleUpErr error) (map[string]int, *clusterstate.ClusterStateRegistry, bool, error) {
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.PodList{Items: []apiv1.Pod{}}, nil
+	})
+
+	increases := make(map[string]int)
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		if nodeGroup == "ng1" {
+			return scaleUpErr
+		}
+		increases[nodeGroup] += increase
+		return nil
+	}, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	provider.AddNode("ng1", n1)
+	n2 := BuildTestNode("n2", 2000, 1000)
+	SetNodeReadyState(n2, true, time.Now())
+	provider.AddNode("ng2", n2)
+	nodes := []*apiv1.Node{n1, n2}
+
+	context := NewScaleTestAutoscalingContext(defaultOptions, fakeClient, provider)
+	context.ExpanderStrategy = preferringStrategy{preferred: []string{"ng1", "ng2"}}
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder)
+	clusterState.UpdateNodes(nodes, time.Now())
+
+	p1 := BuildTestPod("p1", 500, 0)
+	status, err := ScaleUp(&context, ca_processors.TestProcessors(), clusterState, []*apiv1.Pod{p1}, nodes, []*extensionsv1.DaemonSet{})
+	if err != nil {
+		return increases, clusterState, false, err
+	}
+	return increases, clusterState, status.ScaledUp, nil
+}
+
+func TestScaleUpFallsBackAfterStockout(t *testing.T) {
+	increases, clusterState, scaledUp, err := runScaleUpWithFailingNodeGroup(t,
+		cloudprovider.NewInstanceCreationError(cloudprovider.StockoutErrorClass, "ZONE_RESOURCE_POOL_EXHAUSTED", "zone is out of capacity"))
+	assert.NoError(t, err)
+	assert.True(t, scaledUp)
+	assert.Equal(t, map[string]int{"ng2": 1}, increases)
+	assert.False(t, clusterState.IsNodeGroupSafeToScaleUp("ng1", time.Now()))
+}
+
+func TestScaleUpDoesNotFallBackAfterOtherErrors(t *testing.T) {
+	increases, clusterState, _, err := runScaleUpWithFailingNodeGroup(t, fmt.Errorf("connection refused"))
+	assert.Error(t, err)
+	assert.Equal(t, cloudprovider.OtherErrorClass, cloudprovider.GetInstanceErrorClass(err))
+	assert.Empty(t, increases)
+	assert.False(t, clusterState.IsNodeGroupSafeToScaleUp("ng1", time.Now()))
+}
+
 func TestCheckScaleUpDeltaWithinLimits(t *testing.T) {
 	type testcase struct {
 		limits            scaleUpResourcesLimits
@@ -27,6 +27,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/replay"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
@@ -59,8 +60,16 @@ type StaticAutoscaler struct {
 	lastScaleDownDeleteTime time.Time
 	lastScaleDownFailTime   time.Time
 	scaleDown               *ScaleDown
+	consolidation           *Consolidation
+	nodeRecycling           *NodeRecycling
 	processors              *ca_processors.AutoscalingProcessors
 	initialized             bool
+	// loopRecorder persists the inputs of every loop, nil if recording is disabled.
+	loopRecorder replay.Recorder
+	// loopTemplates are the templates of node groups reused between loop records.
+	loopTemplates *replay.TemplateCache
+	// stopChannel is closed on exit to stop the watches started for the autoscaler, if not nil.
+	stopChannel chan struct{}
 }
 
 // NewStaticAutoscaler creates an instance of Autoscaler filled with provided parameters
@@ -83,8 +92,11 @@ func NewStaticAutoscaler(opts config.AutoscalingOptions, predicateChecker *simul
 		lastScaleDownDeleteTime: time.Now(),
 		lastScaleDownFailTime:   time.Now(),
 		scaleDown:               scaleDown,
+		consolidation:           NewConsolidation(autoscalingContext, clusterStateRegistry, scaleDown),
+		nodeRecycling:           NewNodeRecycling(autoscalingContext, clusterStateRegistry, scaleDown),
 		processors:              processors,
 		clusterStateRegistry:    clusterStateRegistry,
+		loopTemplates:           replay.NewTemplateCache(),
 	}
 }
 
@@ -96,7 +108,10 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 	}
 
 	// CA can die at any time. Removing taints that might have been left from the previous run.
-	if readyNodes, err := a.ReadyNodeLister().List(); err != nil {
+	// In dry run CA doesn't add the taints, and those left by another CA are not its own to remove.
+	if a.DryRun {
+		glog.V(1).Infof("Dry run, not cleaning up ToBeDeleted taints")
+	} else if readyNodes, err := a.ReadyNodeLister().List(); err != nil {
 		glog.Errorf("Failed to list ready nodes, not cleaning up taints: %v", err)
 	} else {
 		cleanToBeDeleted(readyNodes, a.AutoscalingContext.ClientSet, a.Recorder)
@@ -107,6 +122,10 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 // RunOnce iterates over node groups and scales them up/down if necessary
 func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError {
 	a.cleanUpIfRequired()
+	if a.DryRun {
+		// Decisions are published when the loop is done, regardless of reason.
+		defer metrics.UpdateDryRunDecisions()
+	}
 
 	unschedulablePodLister := a.UnschedulablePodLister()
 	scheduledPodLister := a.ScheduledPodLister()
@@ -187,6 +206,17 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 		return nil
 	}
 
+	scaledUpToMinSize, typedErr := ScaleUpToScheduledMinSizes(autoscalingContext, a.clusterStateRegistry, currentTime)
+	if typedErr != nil {
+		glog.Errorf("Failed to scale up to scheduled min sizes: %v", typedErr)
+		return typedErr
+	}
+	if scaledUpToMinSize {
+		a.lastScaleUpTime = currentTime
+		glog.V(0).Infof("Some node group was scaled up to its scheduled min size, skipping the iteration")
+		return nil
+	}
+
 	metrics.UpdateLastTime(metrics.Autoscaling, time.Now())
 
 	allUnschedulablePods, err := unschedulablePodLister.List()
@@ -202,6 +232,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 		return errors.ToAutoscalerError(errors.ApiCallError, err)
 	}
 
+	if a.loopRecorder != nil {
+		a.recordLoop(currentTime, allNodes, allScheduled, allUnschedulablePods)
+	}
+
 	allUnschedulablePods, allScheduled, err = a.processors.PodListProcessor.Process(a.AutoscalingContext, allUnschedulablePods, allScheduled, allNodes)
 	if err != nil {
 		glog.Errorf("Failed to process pod list: %v", err)
@@ -285,6 +319,19 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 		}
 	}
 
+	// Free capacity is restored only if no scale-up for pending pods happened, it will be recomputed
+	// with the new nodes in the next iteration.
+	headroomScaleUpStatus, typedErr := ScaleUpForHeadroom(autoscalingContext, a.clusterStateRegistry, readyNodes, allScheduled, currentTime)
+	if typedErr != nil {
+		glog.Errorf("Failed to scale up for headroom: %v", typedErr)
+		return typedErr
+	}
+	if headroomScaleUpStatus.ScaledUp {
+		a.lastScaleUpTime = currentTime
+		// No scale down in this iteration.
+		return nil
+	}
+
 	if a.ScaleDownEnabled {
 		pdbs, err := pdbLister.List()
 		if err != nil {
@@ -292,6 +339,21 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 			return errors.ToAutoscalerError(errors.ApiCallError, err)
 		}
 
+		if a.MaxNodeAge > 0 {
+			nodeRecyclingStart := time.Now()
+			metrics.UpdateLastTime(metrics.NodeRecycling, nodeRecyclingStart)
+			nodeRecyclingResult, typedErr := a.nodeRecycling.Update(allNodes, readyNodes, allScheduled, pdbs, currentTime)
+			metrics.UpdateDurationFromStart(metrics.NodeRecycling, nodeRecyclingStart)
+
+			if typedErr != nil {
+				glog.Errorf("Failed to recycle nodes: %v", typedErr)
+				return typedErr
+			}
+			if nodeRecyclingResult == NodeRecyclingReplacementRequested {
+				a.lastScaleUpTime = currentTime
+			}
+		}
+
 		unneededStart := time.Now()
 
 		glog.V(4).Infof("Calculating unneeded nodes")
@@ -313,6 +375,13 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 			}
 		}
 
+		for nodeName, err := range scaleDown.nodeDeleteStatus.DrainNodeDeleteResults() {
+			if err != nil {
+				glog.Warningf("Scale-down of node %s failed: %v", nodeName, err)
+				a.lastScaleDownFailTime = currentTime
+			}
+		}
+
 		// In dry run only utilization is updated
 		calculateUnneededOnly := scaleDownForbidden ||
 			a.lastScaleUpTime.Add(a.ScaleDownDelayAfterAdd).After(currentTime) ||
@@ -325,12 +394,15 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 			calculateUnneededOnly, a.lastScaleUpTime, a.lastScaleDownDeleteTime, a.lastScaleDownFailTime,
 			scaleDownForbidden, scaleDown.nodeDeleteStatus.IsDeleteInProgress())
 
+		scaleDownIdle := false
 		if !calculateUnneededOnly {
 			glog.V(4).Infof("Starting scale down")
 
 			// We want to delete unneeded Node Groups only if there was no recent scale up,
 			// and there is no current delete in progress and there was no recent errors.
-			a.processors.NodeGroupManager.RemoveUnneededNodeGroups(autoscalingContext)
+			if !a.DryRun {
+				a.processors.NodeGroupManager.RemoveUnneededNodeGroups(autoscalingContext)
+			}
 
 			scaleDownStart := time.Now()
 			metrics.UpdateLastTime(metrics.ScaleDown, scaleDownStart)
@@ -345,6 +417,24 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 			if result == ScaleDownNodeDeleted {
 				a.lastScaleDownDeleteTime = currentTime
 			}
+			scaleDownIdle = result == ScaleDownNoUnneeded || result == ScaleDownNoNodeDeleted
+		}
+
+		if a.NodeConsolidationEnabled {
+			// A new consolidation is only looked for when the scale-down has nothing to remove, the one in
+			// progress is moved forward in every loop.
+			consolidationStart := time.Now()
+			metrics.UpdateLastTime(metrics.Consolidation, consolidationStart)
+			consolidationResult, typedErr := a.consolidation.Update(allNodes, readyNodes, allScheduled, pdbs, scaleDownIdle, currentTime)
+			metrics.UpdateDurationFromStart(metrics.Consolidation, consolidationStart)
+
+			if typedErr != nil {
+				glog.Errorf("Failed to consolidate nodes: %v", typedErr)
+				return typedErr
+			}
+			if consolidationResult == ConsolidationScaledUp {
+				a.lastScaleUpTime = currentTime
+			}
 		}
 	}
 	return nil
@@ -354,12 +444,40 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) errors.AutoscalerError
 func (a *StaticAutoscaler) ExitCleanUp() {
 	a.processors.CleanUp()
 
+	if a.stopChannel != nil {
+		close(a.stopChannel)
+	}
+
+	if a.loopRecorder != nil {
+		if err := a.loopRecorder.Close(); err != nil {
+			glog.Warningf("Failed to close loop recorder: %v", err)
+		}
+	}
+
 	if !a.AutoscalingContext.WriteStatusConfigMap {
 		return
 	}
 	utils.DeleteStatusConfigMap(a.AutoscalingContext.ClientSet, a.AutoscalingContext.ConfigNamespace)
 }
 
+// recordLoop persists the inputs of the current loop. Failures are logged and don't affect the loop.
+func (a *StaticAutoscaler) recordLoop(currentTime time.Time, allNodes []*apiv1.Node, allScheduled []*apiv1.Pod, allUnschedulablePods []*apiv1.Pod) {
+	pdbs, err := a.PodDisruptionBudgetLister().List()
+	if err != nil {
+		glog.Warningf("Failed to list pod disruption budgets for loop record: %v", err)
+		return
+	}
+	daemonSets, err := a.DaemonSetLister().List()
+	if err != nil {
+		glog.Warningf("Failed to list daemon sets for loop record: %v", err)
+		return
+	}
+	record := replay.NewLoopRecord(currentTime, a.CloudProvider, a.loopTemplates, allNodes, allScheduled, allUnschedulablePods, pdbs, daemonSets)
+	if err := a.loopRecorder.Record(record); err != nil {
+		glog.Warningf("Failed to record loop: %v", err)
+	}
+}
+
 func (a *StaticAutoscaler) obtainNodeLists() ([]*apiv1.Node, []*apiv1.Node, errors.AutoscalerError) {
 	allNodes, err := a.AllNodeLister().List()
 	if err != nil {
@@ -669,3 +669,23 @@ func TestStaticAutoscalerRunOncePodsWithPriorities(t *testing.T) {
 		podDisruptionBudgetListerMock, daemonSetListerMock, onScaleUpMock, onScaleDownMock)
 
 }
+
+func TestStaticAutoscalerCleanUpDryRun(t *testing.T) {
+	readyNodeListerMock := &nodeListerMock{}
+	fakeClient := &fake.Clientset{}
+
+	options := config.AutoscalingOptions{
+		DryRun: true,
+	}
+	context := NewScaleTestAutoscalingContext(options, fakeClient, nil)
+	context.ListerRegistry = kube_util.NewListerRegistry(nil, readyNodeListerMock, nil, nil, nil, nil)
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext: &context,
+	}
+
+	// ToBeDeleted taints are left alone, nodes are not even listed.
+	autoscaler.cleanUpIfRequired()
+	assert.True(t, autoscaler.initialized)
+	assert.Empty(t, fakeClient.Actions())
+	mock.AssertExpectationsForObjects(t, readyNodeListerMock)
+}
@@ -384,13 +384,15 @@ func removeOldUnregisteredNodes(unregisteredNodes []clusterstate.UnregisteredNod
 	currentTime time.Time, logRecorder *utils.LogEventRecorder) (bool, error) {
 	removedAny := false
 	for _, unregisteredNode := range unregisteredNodes {
-		if unregisteredNode.UnregisteredSince.Add(context.MaxNodeProvisionTime).Before(currentTime) {
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(unregisteredNode.Node)
+		if err != nil {
+			glog.Warningf("Failed to get node group for %s: %v", unregisteredNode.Node.Name, err)
+			return removedAny, err
+		}
+		maxNodeProvisionTime := cloudprovider.GetNodeGroupOptions(nodeGroup, context.NodeGroupDefaults()).MaxNodeProvisionTime
+		// Instances that failed to be created will never register, so they are removed right away.
+		if unregisteredNode.CreationFailed() || unregisteredNode.UnregisteredSince.Add(maxNodeProvisionTime).Before(currentTime) {
 			glog.V(0).Infof("Removing unregistered node %v", unregisteredNode.Node.Name)
-			nodeGroup, err := context.CloudProvider.NodeGroupForNode(unregisteredNode.Node)
-			if err != nil {
-				glog.Warningf("Failed to get node group for %s: %v", unregisteredNode.Node.Name, err)
-				return removedAny, err
-			}
 			if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
 				glog.Warningf("No node group for node %s, skipping", unregisteredNode.Node.Name)
 				continue
@@ -404,8 +406,19 @@ func removeOldUnregisteredNodes(unregisteredNodes []clusterstate.UnregisteredNod
 				glog.Warningf("Failed to remove node %s: node group min size reached, skipping unregistered node removal", unregisteredNode.Node.Name)
 				continue
 			}
-			logRecorder.Eventf(apiv1.EventTypeNormal, "DeleteUnregistered",
-				"Removing unregistered node %v", unregisteredNode.Node.Name)
+			if context.DryRun {
+				glog.V(0).Infof("Dry run: would remove unregistered node %v", unregisteredNode.Node.Name)
+				continue
+			}
+			if unregisteredNode.CreationFailed() {
+				errorInfo := unregisteredNode.Status.ErrorInfo
+				logRecorder.Eventf(apiv1.EventTypeNormal, "DeleteUnregistered",
+					"Removing unregistered node %v that failed to be created: %s %s", unregisteredNode.Node.Name,
+					errorInfo.ErrorCode, errorInfo.ErrorMessage)
+			} else {
+				logRecorder.Eventf(apiv1.EventTypeNormal, "DeleteUnregistered",
+					"Removing unregistered node %v", unregisteredNode.Node.Name)
+			}
 			err = nodeGroup.DeleteNodes([]*apiv1.Node{unregisteredNode.Node})
 			if err != nil {
 				glog.Warningf("Failed to remove node %s: %v", unregisteredNode.Node.Name, err)
@@ -427,9 +440,17 @@ func fixNodeGroupSize(context *context.AutoscalingContext, clusterStateRegistry
 		if incorrectSize == nil {
 			continue
 		}
-		if incorrectSize.FirstObserved.Add(context.MaxNodeProvisionTime).Before(currentTime) {
+		maxNodeProvisionTime := cloudprovider.GetNodeGroupOptions(nodeGroup, context.NodeGroupDefaults()).MaxNodeProvisionTime
+		if incorrectSize.FirstObserved.Add(maxNodeProvisionTime).Before(currentTime) {
 			delta := incorrectSize.CurrentSize - incorrectSize.ExpectedSize
 			if delta < 0 {
+				if context.DryRun {
+					glog.V(0).Infof("Dry run: would decrease size of %s, expected=%d current=%d delta=%d", nodeGroup.Id(),
+						incorrectSize.ExpectedSize,
+						incorrectSize.CurrentSize,
+						delta)
+					continue
+				}
 				glog.V(0).Infof("Decreasing size of %s, expected=%d current=%d delta=%d", nodeGroup.Id(),
 					incorrectSize.ExpectedSize,
 					incorrectSize.CurrentSize,
@@ -548,6 +569,11 @@ func getNodeGroupSizeMap(cloudProvider cloudprovider.CloudProvider) map[string]i
 	return nodeGroupSize
 }
 
+// getScratchPodSelector returns the selector of the pods whose local storage doesn't prevent scale-down.
+func getScratchPodSelector(context *context.AutoscalingContext) *drain.ScratchPodSelector {
+	return drain.NewScratchPodSelector(context.ScratchPodNamespaces, context.ScratchPodSelector)
+}
+
 // UpdateClusterStateMetrics updates metrics related to cluster state
 func UpdateClusterStateMetrics(csr *clusterstate.ClusterStateRegistry) {
 	if csr == nil || reflect.ValueOf(csr).IsNil() {
@@ -24,7 +24,7 @@ import (
 
 var (
 	// AvailableExpanders is a list of available expander options
-	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName}
+	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName, PriorityBasedExpanderName, GRPCExpanderName}
 	// RandomExpanderName selects a node group at random
 	RandomExpanderName = "random"
 	// MostPodsExpanderName selects a node group that fits the most pods
@@ -34,6 +34,11 @@ var (
 	// PriceBasedExpanderName selects a node group that is the most cost-effective and consistent with
 	// the preferred node size for the cluster
 	PriceBasedExpanderName = "price"
+	// PriorityBasedExpanderName selects a node group with the highest priority configured by the user
+	// in a ConfigMap, picking at random among node groups with the same priority
+	PriorityBasedExpanderName = "priority"
+	// GRPCExpanderName delegates the choice of a node group to an external gRPC service
+	GRPCExpanderName = "grpc"
 )
 
 // Option describes an option to expand the cluster.
@@ -48,3 +53,9 @@ type Option struct {
 type Strategy interface {
 	BestOption(options []Option, nodeInfo map[string]*schedulercache.NodeInfo) *Option
 }
+
+// Filter describes an interface for narrowing the options down to the equally good ones according
+// to some criteria. Filters can be chained, each one working on the options left by the previous one.
+type Filter interface {
+	BestOptions(options []Option, nodeInfo map[string]*schedulercache.NodeInfo) []Option
+}
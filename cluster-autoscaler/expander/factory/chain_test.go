@@ -0,0 +1,150 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/mostpods"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/random"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+)
+
+// idFilter leaves the options whose node group ids are in the given set.
+type idFilter map[string]bool
+
+func (f idFilter) BestOptions(options []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
+	var result []expander.Option
+	for _, option := range options {
+		if f[option.NodeGroup.Id()] {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+func buildOptions(podCounts map[string]int) []expander.Option {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	options := make([]expander.Option, 0, len(podCounts))
+	for id, podCount := range podCounts {
+		provider.AddNodeGroup(id, 0, 10, 1)
+		pods := make([]*apiv1.Pod, 0, podCount)
+		for i := 0; i < podCount; i++ {
+			pods = append(pods, BuildTestPod("p", 100, 0))
+		}
+		options = append(options, expander.Option{NodeGroup: provider.GetNodeGroup(id), NodeCount: 1, Pods: pods})
+	}
+	return options
+}
+
+func TestChainStrategy(t *testing.T) {
+	options := buildOptions(map[string]int{"ng1": 1, "ng2": 2, "ng3": 2, "ng4": 3})
+
+	// The first filter narrows the options down, the second one picks among the survivors.
+	strategy := newChainStrategy([]expander.Filter{idFilter{"ng1": true, "ng2": true, "ng3": true}, mostpods.NewFilter()}, random.NewStrategy())
+	picked := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		picked[strategy.BestOption(options, nil).NodeGroup.Id()] = true
+	}
+	assert.Equal(t, map[string]bool{"ng2": true, "ng3": true}, picked)
+
+	// Filters after the one leaving a single option don't matter.
+	strategy = newChainStrategy([]expander.Filter{idFilter{"ng1": true}, mostpods.NewFilter()}, random.NewStrategy())
+	assert.Equal(t, "ng1", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	// Order of filters matters.
+	strategy = newChainStrategy([]expander.Filter{mostpods.NewFilter(), idFilter{"ng1": true, "ng2": true, "ng3": true}}, random.NewStrategy())
+	assert.Equal(t, "ng4", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	// No option left.
+	strategy = newChainStrategy([]expander.Filter{idFilter{"ng5": true}, mostpods.NewFilter()}, random.NewStrategy())
+	assert.Nil(t, strategy.BestOption(options, nil))
+}
+
+func TestExpanderStrategyFromStrings(t *testing.T) {
+	grpcOptions := config.GRPCExpanderOptions{URL: "localhost:1234", Timeout: time.Second, FallbackExpanderName: expander.LeastWasteExpanderName}
+
+	_, err := ExpanderStrategyFromStrings([]string{expander.MostPodsExpanderName, expander.LeastWasteExpanderName}, nil, nil, nil, grpcOptions)
+	assert.NoError(t, err)
+
+	_, err = ExpanderStrategyFromStrings([]string{expander.MostPodsExpanderName, "unknown"}, nil, nil, nil, grpcOptions)
+	assert.Error(t, err)
+
+	_, err = ExpanderStrategyFromStrings([]string{expander.MostPodsExpanderName, expander.MostPodsExpanderName}, nil, nil, nil, grpcOptions)
+	assert.Error(t, err)
+
+	_, err = ExpanderStrategyFromStrings([]string{}, nil, nil, nil, grpcOptions)
+	assert.Error(t, err)
+
+	_, err = ExpanderStrategyFromStrings([]string{expander.GRPCExpanderName, expander.RandomExpanderName}, nil, nil, nil, grpcOptions)
+	assert.NoError(t, err)
+
+	grpcOptions.FallbackExpanderName = expander.GRPCExpanderName
+	_, err = ExpanderStrategyFromStrings([]string{expander.GRPCExpanderName}, nil, nil, nil, grpcOptions)
+	assert.Error(t, err)
+}
+
+func TestExpanderStrategyFromStringsStop(t *testing.T) {
+	watcher := watch.NewFake()
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.ConfigMapList{}, nil
+	})
+	fakeClient.Fake.AddWatchReactor("configmaps", core.DefaultWatchReactor(watcher, nil))
+	kubeClients := &context.AutoscalingKubeClients{ClientSet: fakeClient}
+	stopChannel := make(chan struct{})
+
+	configMaps := kube_util.NewSharedConfigMapLister(fakeClient, stopChannel, "kube-system")
+	_, err := ExpanderStrategyFromStrings([]string{expander.PriorityBasedExpanderName}, nil, kubeClients, configMaps,
+		config.GRPCExpanderOptions{})
+	assert.NoError(t, err)
+
+	// The priority ConfigMap is watched until the stop channel is closed.
+	waitFor(t, func() bool {
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "watch" {
+				return true
+			}
+		}
+		return false
+	})
+	assert.False(t, watcher.IsStopped())
+	close(stopChannel)
+	waitFor(t, watcher.IsStopped)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	for start := time.Now(); !condition(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("Condition not met")
+		}
+	}
+}
@@ -18,9 +18,13 @@ package factory
 
 import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/mostpods"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/price"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/priority"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/random"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/waste"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
@@ -28,24 +32,66 @@ import (
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 )
 
-// ExpanderStrategyFromString creates an expander.Strategy according to its name
-func ExpanderStrategyFromString(expanderFlag string, cloudProvider cloudprovider.CloudProvider,
-	nodeLister kube_util.NodeLister) (expander.Strategy, errors.AutoscalerError) {
-	switch expanderFlag {
+// ExpanderStrategyFromStrings creates an expander.Strategy according to an ordered list of expander
+// names. The expanders are applied in the given order, each one narrowing down the options left by
+// the previous one, and the final choice among the remaining options is random. Expanders configured
+// by config maps read them from configMaps.
+func ExpanderStrategyFromStrings(expanderNames []string, cloudProvider cloudprovider.CloudProvider,
+	autoscalingKubeClients *context.AutoscalingKubeClients, configMaps *kube_util.SharedConfigMapLister,
+	grpcExpanderOptions config.GRPCExpanderOptions) (expander.Strategy, errors.AutoscalerError) {
+	if len(expanderNames) == 0 {
+		return nil, errors.NewAutoscalerError(errors.InternalError, "No expander specified")
+	}
+	filters := []expander.Filter{}
+	seen := make(map[string]bool)
+	for _, name := range expanderNames {
+		if seen[name] {
+			return nil, errors.NewAutoscalerError(errors.InternalError, "Expander %s is used more than once", name)
+		}
+		seen[name] = true
+		filter, err := expanderFilterFromString(name, cloudProvider, autoscalingKubeClients, configMaps, grpcExpanderOptions)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return newChainStrategy(filters, random.NewStrategy()), nil
+}
+
+func expanderFilterFromString(expanderName string, cloudProvider cloudprovider.CloudProvider,
+	autoscalingKubeClients *context.AutoscalingKubeClients, configMaps *kube_util.SharedConfigMapLister,
+	grpcExpanderOptions config.GRPCExpanderOptions) (expander.Filter, errors.AutoscalerError) {
+	switch expanderName {
 	case expander.RandomExpanderName:
-		return random.NewStrategy(), nil
+		return random.NewFilter(), nil
 	case expander.MostPodsExpanderName:
-		return mostpods.NewStrategy(), nil
+		return mostpods.NewFilter(), nil
 	case expander.LeastWasteExpanderName:
-		return waste.NewStrategy(), nil
+		return waste.NewFilter(), nil
 	case expander.PriceBasedExpanderName:
 		pricing, err := cloudProvider.Pricing()
 		if err != nil {
 			return nil, err
 		}
-		return price.NewStrategy(pricing,
-			price.NewSimplePreferredNodeProvider(nodeLister),
+		return price.NewFilter(pricing,
+			price.NewSimplePreferredNodeProvider(autoscalingKubeClients.AllNodeLister()),
 			price.SimpleNodeUnfitness), nil
+	case expander.PriorityBasedExpanderName:
+		return priority.NewFilter(configMaps.Lister(), autoscalingKubeClients.LogRecorder), nil
+	case expander.GRPCExpanderName:
+		if grpcExpanderOptions.FallbackExpanderName == expander.GRPCExpanderName {
+			return nil, errors.NewAutoscalerError(errors.InternalError, "Expander %s can't be its own fallback", expanderName)
+		}
+		fallbackFilter, err := expanderFilterFromString(grpcExpanderOptions.FallbackExpanderName, cloudProvider,
+			autoscalingKubeClients, configMaps, grpcExpanderOptions)
+		if err != nil {
+			return nil, err
+		}
+		filter, grpcErr := grpcplugin.NewFilter(grpcExpanderOptions, fallbackFilter)
+		if grpcErr != nil {
+			return nil, errors.ToAutoscalerError(errors.InternalError, grpcErr)
+		}
+		return filter, nil
 	}
-	return nil, errors.NewAutoscalerError(errors.InternalError, "Expander %s not supported", expanderFlag)
+	return nil, errors.NewAutoscalerError(errors.InternalError, "Expander %s not supported", expanderName)
 }
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type grpcclientstrategy struct {
+	fallbackFilter expander.Filter
+	grpcClient     protos.ExpanderClient
+	timeout        time.Duration
+}
+
+// NewFilter returns a filter that leaves the options chosen by an external gRPC service. The options are
+// narrowed down by the fallback filter instead if the service fails or doesn't answer within the timeout.
+func NewFilter(options config.GRPCExpanderOptions, fallbackFilter expander.Filter) (expander.Filter, error) {
+	grpcClient, err := createGRPCClient(options.URL, options.Cert)
+	if err != nil {
+		return nil, err
+	}
+	return newGRPCClientStrategy(grpcClient, options.Timeout, fallbackFilter), nil
+}
+
+func newGRPCClientStrategy(grpcClient protos.ExpanderClient, timeout time.Duration, fallbackFilter expander.Filter) *grpcclientstrategy {
+	return &grpcclientstrategy{
+		fallbackFilter: fallbackFilter,
+		grpcClient:     grpcClient,
+		timeout:        timeout,
+	}
+}
+
+// createGRPCClient connects to the service lazily, so an unavailable service doesn't prevent CA from starting.
+func createGRPCClient(url string, certPath string) (protos.ExpanderClient, error) {
+	if url == "" {
+		return nil, fmt.Errorf("gRPC expander URL is not set")
+	}
+	var dialOption grpc.DialOption
+	if certPath == "" {
+		glog.Warningf("No certificate given for gRPC expander, connecting to %s without TLS", url)
+		dialOption = grpc.WithInsecure()
+	} else {
+		creds, err := credentials.NewClientTLSFromFile(certPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gRPC expander certificate %s: %v", certPath, err)
+		}
+		dialOption = grpc.WithTransportCredentials(creds)
+	}
+	conn, err := grpc.Dial(url, dialOption, grpc.WithCodec(grpccodec.Codec{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC expander connection to %s: %v", url, err)
+	}
+	return protos.NewExpanderClient(conn), nil
+}
+
+// BestOptions sends the options and the template nodes of their node groups to the gRPC service and
+// returns the options it picked.
+func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
+	if len(expansionOptions) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	response, err := g.grpcClient.BestOptions(ctx, buildRequest(expansionOptions, nodeInfo))
+	if err != nil {
+		glog.Warningf("gRPC expander failed, falling back: %v", err)
+		return g.fallbackFilter.BestOptions(expansionOptions, nodeInfo)
+	}
+
+	bestOptions := optionsFromResponse(response, expansionOptions)
+	if len(bestOptions) == 0 {
+		glog.Warningf("gRPC expander didn't return any of the given node groups, falling back")
+		return g.fallbackFilter.BestOptions(expansionOptions, nodeInfo)
+	}
+	return bestOptions
+}
+
+func buildRequest(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) *protos.BestOptionsRequest {
+	request := &protos.BestOptionsRequest{
+		Options: make([]*protos.Option, 0, len(expansionOptions)),
+		NodeMap: make(map[string]*v1.Node),
+	}
+	for _, option := range expansionOptions {
+		id := option.NodeGroup.Id()
+		request.Options = append(request.Options, &protos.Option{
+			NodeGroupId: id,
+			NodeCount:   int32(option.NodeCount),
+			Debug:       option.Debug,
+			Pod:         option.Pods,
+		})
+		if info, found := nodeInfo[id]; found && info.Node() != nil {
+			request.NodeMap[id] = info.Node()
+		}
+	}
+	return request
+}
+
+// optionsFromResponse returns the given options whose node groups were picked by the service.
+func optionsFromResponse(response *protos.BestOptionsResponse, expansionOptions []expander.Option) []expander.Option {
+	picked := make(map[string]bool)
+	for _, option := range response.GetOptions() {
+		picked[option.GetNodeGroupId()] = true
+	}
+	var result []expander.Option
+	for _, option := range expansionOptions {
+		if picked[option.NodeGroup.Id()] {
+			result = append(result, option)
+		}
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	apiv1 "k8s.io/api/core/v1"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+)
+
+// fakeExpanderServer picks the options with the largest template node that can fit the pods.
+type fakeExpanderServer struct {
+	delay    time.Duration
+	err      error
+	requests []*protos.BestOptionsRequest
+}
+
+func (s *fakeExpanderServer) BestOptions(ctx context.Context, request *protos.BestOptionsRequest) (*protos.BestOptionsResponse, error) {
+	s.requests = append(s.requests, request)
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	var best []*protos.Option
+	var bestCpu int64
+	for _, option := range request.GetOptions() {
+		node := request.GetNodeMap()[option.GetNodeGroupId()]
+		cpu := node.Status.Capacity.Cpu().MilliValue()
+		if best == nil || cpu > bestCpu {
+			best = []*protos.Option{option}
+			bestCpu = cpu
+		} else if cpu == bestCpu {
+			best = append(best, option)
+		}
+	}
+	return &protos.BestOptionsResponse{Options: best}, nil
+}
+
+// firstFilter leaves only the first option.
+type firstFilter struct{}
+
+func (firstFilter) BestOptions(options []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
+	return options[:1]
+}
+
+func startServer(t *testing.T, server protos.ExpanderServer) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	grpcServer := grpc.NewServer(grpc.CustomCodec(grpccodec.Codec{}))
+	protos.RegisterExpanderServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	return listener.Addr().String(), grpcServer.Stop
+}
+
+func buildOptions() ([]expander.Option, map[string]*schedulercache.NodeInfo) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	options := []expander.Option{}
+	nodeInfos := make(map[string]*schedulercache.NodeInfo)
+	for _, group := range []struct {
+		id  string
+		cpu int64
+	}{{"small", 1000}, {"large-1", 4000}, {"large-2", 4000}} {
+		provider.AddNodeGroup(group.id, 0, 10, 1)
+		nodeInfo := schedulercache.NewNodeInfo()
+		nodeInfo.SetNode(BuildTestNode(group.id+"-template", group.cpu, 1000))
+		nodeInfos[group.id] = nodeInfo
+		options = append(options, expander.Option{
+			NodeGroup: provider.GetNodeGroup(group.id),
+			NodeCount: 1,
+			Pods:      []*apiv1.Pod{BuildTestPod("p", 500, 0)},
+			Debug:     group.id,
+		})
+	}
+	return options, nodeInfos
+}
+
+func optionIds(options []expander.Option) []string {
+	ids := []string{}
+	for _, option := range options {
+		ids = append(ids, option.NodeGroup.Id())
+	}
+	return ids
+}
+
+func TestGRPCExpander(t *testing.T) {
+	server := &fakeExpanderServer{}
+	url, stop := startServer(t, server)
+	defer stop()
+
+	filter, err := NewFilter(config.GRPCExpanderOptions{URL: url, Timeout: 5 * time.Second}, firstFilter{})
+	assert.NoError(t, err)
+
+	options, nodeInfos := buildOptions()
+	assert.Equal(t, []string{"large-1", "large-2"}, optionIds(filter.BestOptions(options, nodeInfos)))
+
+	assert.Equal(t, 1, len(server.requests))
+	request := server.requests[0]
+	assert.Equal(t, 3, len(request.GetOptions()))
+	assert.Equal(t, "small", request.GetOptions()[0].GetNodeGroupId())
+	assert.Equal(t, int32(1), request.GetOptions()[0].GetNodeCount())
+	assert.Equal(t, "small", request.GetOptions()[0].GetDebug())
+	assert.Equal(t, "p", request.GetOptions()[0].GetPod()[0].Name)
+	assert.Equal(t, "small-template", request.GetNodeMap()["small"].Name)
+
+	assert.Nil(t, filter.BestOptions([]expander.Option{}, nodeInfos))
+}
+
+func TestGRPCExpanderFallback(t *testing.T) {
+	options, nodeInfos := buildOptions()
+
+	// Service fails.
+	server := &fakeExpanderServer{err: fmt.Errorf("no capacity data")}
+	url, stop := startServer(t, server)
+	filter, err := NewFilter(config.GRPCExpanderOptions{URL: url, Timeout: 5 * time.Second}, firstFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"small"}, optionIds(filter.BestOptions(options, nodeInfos)))
+	stop()
+
+	// Service doesn't answer in time.
+	server = &fakeExpanderServer{delay: time.Second}
+	url, stop = startServer(t, server)
+	filter, err = NewFilter(config.GRPCExpanderOptions{URL: url, Timeout: 100 * time.Millisecond}, firstFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"small"}, optionIds(filter.BestOptions(options, nodeInfos)))
+	stop()
+
+	// Service is not running.
+	filter, err = NewFilter(config.GRPCExpanderOptions{URL: url, Timeout: 100 * time.Millisecond}, firstFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"small"}, optionIds(filter.BestOptions(options, nodeInfos)))
+
+	// Service picks an unknown node group.
+	assert.Empty(t, optionsFromResponse(&protos.BestOptionsResponse{Options: []*protos.Option{{NodeGroupId: "unknown"}}}, options))
+}
+
+func TestNewFilterErrors(t *testing.T) {
+	_, err := NewFilter(config.GRPCExpanderOptions{Timeout: time.Second}, firstFilter{})
+	assert.Error(t, err)
+	_, err = NewFilter(config.GRPCExpanderOptions{URL: "localhost:1234", Cert: "/nonexistent/ca.crt", Timeout: time.Second}, firstFilter{})
+	assert.Error(t, err)
+}
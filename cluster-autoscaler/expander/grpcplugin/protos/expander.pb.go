@@ -0,0 +1,290 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: expander/grpcplugin/protos/expander.proto
+
+package protos
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import v1 "k8s.io/api/core/v1"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BestOptionsRequest struct {
+	Options []*Option `protobuf:"bytes,1,rep,name=options" json:"options,omitempty"`
+	// Template nodes of the node groups, keyed by node group id.
+	NodeMap              map[string]*v1.Node `protobuf:"bytes,2,rep,name=nodeMap" json:"nodeMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *BestOptionsRequest) Reset()         { *m = BestOptionsRequest{} }
+func (m *BestOptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*BestOptionsRequest) ProtoMessage()    {}
+func (*BestOptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_expander_76d7e29ecf49c7b1, []int{0}
+}
+func (m *BestOptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BestOptionsRequest.Unmarshal(m, b)
+}
+func (m *BestOptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BestOptionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *BestOptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BestOptionsRequest.Merge(dst, src)
+}
+func (m *BestOptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_BestOptionsRequest.Size(m)
+}
+func (m *BestOptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BestOptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BestOptionsRequest proto.InternalMessageInfo
+
+func (m *BestOptionsRequest) GetOptions() []*Option {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *BestOptionsRequest) GetNodeMap() map[string]*v1.Node {
+	if m != nil {
+		return m.NodeMap
+	}
+	return nil
+}
+
+type BestOptionsResponse struct {
+	// Options chosen by the expander. Only nodeGroupId is used by Cluster Autoscaler.
+	Options              []*Option `protobuf:"bytes,1,rep,name=options" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BestOptionsResponse) Reset()         { *m = BestOptionsResponse{} }
+func (m *BestOptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*BestOptionsResponse) ProtoMessage()    {}
+func (*BestOptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_expander_76d7e29ecf49c7b1, []int{1}
+}
+func (m *BestOptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BestOptionsResponse.Unmarshal(m, b)
+}
+func (m *BestOptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BestOptionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *BestOptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BestOptionsResponse.Merge(dst, src)
+}
+func (m *BestOptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_BestOptionsResponse.Size(m)
+}
+func (m *BestOptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BestOptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BestOptionsResponse proto.InternalMessageInfo
+
+func (m *BestOptionsResponse) GetOptions() []*Option {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type Option struct {
+	// Id of the node group to expand.
+	NodeGroupId string `protobuf:"bytes,1,opt,name=nodeGroupId" json:"nodeGroupId,omitempty"`
+	// Number of nodes that would be added to the node group.
+	NodeCount int32 `protobuf:"varint,2,opt,name=nodeCount" json:"nodeCount,omitempty"`
+	// Debug information about the option.
+	Debug string `protobuf:"bytes,3,opt,name=debug" json:"debug,omitempty"`
+	// Pending pods that would be scheduled on the added nodes.
+	Pod                  []*v1.Pod `protobuf:"bytes,4,rep,name=pod" json:"pod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Option) Reset()         { *m = Option{} }
+func (m *Option) String() string { return proto.CompactTextString(m) }
+func (*Option) ProtoMessage()    {}
+func (*Option) Descriptor() ([]byte, []int) {
+	return fileDescriptor_expander_76d7e29ecf49c7b1, []int{2}
+}
+func (m *Option) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Option.Unmarshal(m, b)
+}
+func (m *Option) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Option.Marshal(b, m, deterministic)
+}
+func (dst *Option) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Option.Merge(dst, src)
+}
+func (m *Option) XXX_Size() int {
+	return xxx_messageInfo_Option.Size(m)
+}
+func (m *Option) XXX_DiscardUnknown() {
+	xxx_messageInfo_Option.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Option proto.InternalMessageInfo
+
+func (m *Option) GetNodeGroupId() string {
+	if m != nil {
+		return m.NodeGroupId
+	}
+	return ""
+}
+
+func (m *Option) GetNodeCount() int32 {
+	if m != nil {
+		return m.NodeCount
+	}
+	return 0
+}
+
+func (m *Option) GetDebug() string {
+	if m != nil {
+		return m.Debug
+	}
+	return ""
+}
+
+func (m *Option) GetPod() []*v1.Pod {
+	if m != nil {
+		return m.Pod
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BestOptionsRequest)(nil), "grpcplugin.BestOptionsRequest")
+	proto.RegisterMapType((map[string]*v1.Node)(nil), "grpcplugin.BestOptionsRequest.NodeMapEntry")
+	proto.RegisterType((*BestOptionsResponse)(nil), "grpcplugin.BestOptionsResponse")
+	proto.RegisterType((*Option)(nil), "grpcplugin.Option")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Expander service
+
+type ExpanderClient interface {
+	// BestOptions returns the best options among the given ones. Returning more
+	// than one option lets Cluster Autoscaler pick among them.
+	BestOptions(ctx context.Context, in *BestOptionsRequest, opts ...grpc.CallOption) (*BestOptionsResponse, error)
+}
+
+type expanderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewExpanderClient(cc *grpc.ClientConn) ExpanderClient {
+	return &expanderClient{cc}
+}
+
+func (c *expanderClient) BestOptions(ctx context.Context, in *BestOptionsRequest, opts ...grpc.CallOption) (*BestOptionsResponse, error) {
+	out := new(BestOptionsResponse)
+	err := grpc.Invoke(ctx, "/grpcplugin.Expander/BestOptions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Expander service
+
+type ExpanderServer interface {
+	// BestOptions returns the best options among the given ones. Returning more
+	// than one option lets Cluster Autoscaler pick among them.
+	BestOptions(context.Context, *BestOptionsRequest) (*BestOptionsResponse, error)
+}
+
+func RegisterExpanderServer(s *grpc.Server, srv ExpanderServer) {
+	s.RegisterService(&_Expander_serviceDesc, srv)
+}
+
+func _Expander_BestOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BestOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpanderServer).BestOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcplugin.Expander/BestOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpanderServer).BestOptions(ctx, req.(*BestOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Expander_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcplugin.Expander",
+	HandlerType: (*ExpanderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BestOptions",
+			Handler:    _Expander_BestOptions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "expander/grpcplugin/protos/expander.proto",
+}
+
+func init() {
+	proto.RegisterFile("expander/grpcplugin/protos/expander.proto", fileDescriptor_expander_76d7e29ecf49c7b1)
+}
+
+var fileDescriptor_expander_76d7e29ecf49c7b1 = []byte{
+	// 349 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcf, 0x4a, 0xeb, 0x40,
+	0x14, 0xc6, 0x6f, 0x9a, 0xdb, 0x7f, 0x27, 0x77, 0x71, 0x99, 0x7b, 0xc1, 0x50, 0x44, 0x43, 0x56,
+	0x2d, 0xca, 0x84, 0xd6, 0x4d, 0x71, 0xd9, 0x52, 0xc4, 0x85, 0x5a, 0x06, 0x57, 0xe2, 0x26, 0xed,
+	0x1c, 0x42, 0x68, 0x99, 0x19, 0x67, 0x26, 0xc5, 0x3e, 0x82, 0x4f, 0xe9, 0xab, 0x48, 0x32, 0x2d,
+	0x8d, 0xf8, 0x07, 0x5c, 0x25, 0xe7, 0x3b, 0xbf, 0x73, 0xf8, 0xbe, 0x93, 0xc0, 0x00, 0x9f, 0x55,
+	0x2a, 0x38, 0xea, 0x24, 0xd3, 0x6a, 0xa9, 0xd6, 0x45, 0x96, 0x8b, 0x44, 0x69, 0x69, 0xa5, 0x49,
+	0xf6, 0x2d, 0x5a, 0xd5, 0x04, 0x0e, 0x44, 0x2f, 0x5e, 0x8d, 0x0d, 0xcd, 0x65, 0x92, 0xaa, 0x3c,
+	0x59, 0x4a, 0x8d, 0xc9, 0x66, 0x98, 0x64, 0x28, 0x50, 0xa7, 0x16, 0xb9, 0xe3, 0xe3, 0x57, 0x0f,
+	0xc8, 0x04, 0x8d, 0xbd, 0x53, 0x36, 0x97, 0xc2, 0x30, 0x7c, 0x2a, 0xd0, 0x58, 0x72, 0x0e, 0x6d,
+	0xe9, 0x94, 0xd0, 0x8b, 0xfc, 0x7e, 0x30, 0x22, 0xf4, 0xb0, 0x98, 0x3a, 0x98, 0xed, 0x11, 0x32,
+	0x83, 0xb6, 0x90, 0x1c, 0x6f, 0x52, 0x15, 0x36, 0x2a, 0xfa, 0xac, 0x4e, 0x7f, 0x5c, 0x4f, 0x6f,
+	0x1d, 0x3d, 0x13, 0x56, 0x6f, 0xd9, 0x7e, 0xb6, 0x77, 0x0f, 0x7f, 0xea, 0x0d, 0xf2, 0x17, 0xfc,
+	0x15, 0x6e, 0x43, 0x2f, 0xf2, 0xfa, 0x5d, 0x56, 0xbe, 0x12, 0x0a, 0xcd, 0x4d, 0xba, 0x2e, 0x30,
+	0x6c, 0x44, 0x5e, 0x3f, 0x18, 0x85, 0xd4, 0x25, 0xa4, 0xa9, 0xca, 0x69, 0x99, 0x90, 0x6e, 0x86,
+	0xd5, 0x6e, 0xe6, 0xb0, 0xcb, 0xc6, 0xd8, 0x8b, 0xa7, 0xf0, 0xef, 0x9d, 0x03, 0xa3, 0xa4, 0x30,
+	0xf8, 0xb3, 0x84, 0xf1, 0x8b, 0x07, 0x2d, 0xa7, 0x91, 0x08, 0x82, 0xd2, 0xf0, 0x95, 0x96, 0x85,
+	0xba, 0xe6, 0x3b, 0x77, 0x75, 0x89, 0x1c, 0x43, 0xb7, 0x2c, 0xa7, 0xb2, 0x10, 0xb6, 0x72, 0xda,
+	0x64, 0x07, 0x81, 0xfc, 0x87, 0x26, 0xc7, 0x45, 0x91, 0x85, 0x7e, 0x35, 0xe9, 0x0a, 0x32, 0x00,
+	0x5f, 0x49, 0x1e, 0xfe, 0xae, 0xac, 0x1c, 0x7d, 0x96, 0x6b, 0x2e, 0x39, 0x2b, 0x99, 0xd1, 0x23,
+	0x74, 0x66, 0xbb, 0x8f, 0x4e, 0xe6, 0x10, 0xd4, 0xc2, 0x91, 0x93, 0xef, 0xef, 0xde, 0x3b, 0xfd,
+	0xb2, 0xef, 0xae, 0x12, 0xff, 0x9a, 0x74, 0x1e, 0x5a, 0xee, 0xcf, 0x5a, 0xb8, 0xe7, 0xc5, 0x5b,
+	0x00, 0x00, 0x00, 0xff, 0xff, 0x4b, 0xbb, 0xcb, 0xc8, 0x7e, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protos
+
+// Messages embedding Kubernetes objects are encoded by hand, see the grpccodec package.
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/utils/grpccodec"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Marshal encodes the request in the protobuf wire format.
+func (m *BestOptionsRequest) Marshal() ([]byte, error) {
+	var data []byte
+	for _, option := range m.Options {
+		encoded, err := option.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		data = grpccodec.AppendBytesField(data, 1, encoded)
+	}
+	for id, node := range m.NodeMap {
+		entry := grpccodec.AppendBytesField(nil, 1, []byte(id))
+		if node != nil {
+			encoded, err := node.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			entry = grpccodec.AppendBytesField(entry, 2, encoded)
+		}
+		data = grpccodec.AppendBytesField(data, 2, entry)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the request from the protobuf wire format.
+func (m *BestOptionsRequest) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		switch field {
+		case 1:
+			option := &Option{}
+			if err := option.Unmarshal(value); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, option)
+		case 2:
+			var id string
+			node := &v1.Node{}
+			err := grpccodec.ReadFields(value, func(field uint64, value []byte) error {
+				switch field {
+				case 1:
+					id = string(value)
+				case 2:
+					return node.Unmarshal(value)
+				}
+				return nil
+			}, nil)
+			if err != nil {
+				return err
+			}
+			if m.NodeMap == nil {
+				m.NodeMap = make(map[string]*v1.Node)
+			}
+			m.NodeMap[id] = node
+		}
+		return nil
+	}, nil)
+}
+
+// Marshal encodes the response in the protobuf wire format.
+func (m *BestOptionsResponse) Marshal() ([]byte, error) {
+	var data []byte
+	for _, option := range m.Options {
+		encoded, err := option.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		data = grpccodec.AppendBytesField(data, 1, encoded)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the response from the protobuf wire format.
+func (m *BestOptionsResponse) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		if field == 1 {
+			option := &Option{}
+			if err := option.Unmarshal(value); err != nil {
+				return err
+			}
+			m.Options = append(m.Options, option)
+		}
+		return nil
+	}, nil)
+}
+
+// Marshal encodes the option in the protobuf wire format.
+func (m *Option) Marshal() ([]byte, error) {
+	var data []byte
+	if m.NodeGroupId != "" {
+		data = grpccodec.AppendBytesField(data, 1, []byte(m.NodeGroupId))
+	}
+	if m.NodeCount != 0 {
+		data = grpccodec.AppendVarintField(data, 2, uint64(m.NodeCount))
+	}
+	if m.Debug != "" {
+		data = grpccodec.AppendBytesField(data, 3, []byte(m.Debug))
+	}
+	for _, pod := range m.Pod {
+		encoded, err := pod.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		data = grpccodec.AppendBytesField(data, 4, encoded)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes the option from the protobuf wire format.
+func (m *Option) Unmarshal(data []byte) error {
+	return grpccodec.ReadFields(data, func(field uint64, value []byte) error {
+		switch field {
+		case 1:
+			m.NodeGroupId = string(value)
+		case 3:
+			m.Debug = string(value)
+		case 4:
+			pod := &v1.Pod{}
+			if err := pod.Unmarshal(value); err != nil {
+				return err
+			}
+			m.Pod = append(m.Pod, pod)
+		}
+		return nil
+	}, func(field uint64, value uint64) error {
+		if field == 2 {
+			m.NodeCount = int32(value)
+		}
+		return nil
+	})
+}
@@ -31,8 +31,23 @@ func NewStrategy() expander.Strategy {
 	return &mostpods{random.NewStrategy()}
 }
 
+// NewFilter returns a scale up filter that leaves the node groups that can schedule the most pods
+func NewFilter() expander.Filter {
+	return &mostpods{random.NewStrategy()}
+}
+
 // BestOption Selects the expansion option that schedules the most pods
 func (m *mostpods) BestOption(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) *expander.Option {
+	maxOptions := m.BestOptions(expansionOptions, nodeInfo)
+	if len(maxOptions) == 0 {
+		return nil
+	}
+
+	return m.fallbackStrategy.BestOption(maxOptions, nodeInfo)
+}
+
+// BestOptions Selects the expansion options that schedule the most pods
+func (m *mostpods) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
 	var maxPods int
 	var maxOptions []expander.Option
 
@@ -47,9 +62,5 @@ func (m *mostpods) BestOption(expansionOptions []expander.Option, nodeInfo map[s
 		}
 	}
 
-	if len(maxOptions) == 0 {
-		return nil
-	}
-
-	return m.fallbackStrategy.BestOption(maxOptions, nodeInfo)
+	return maxOptions
 }
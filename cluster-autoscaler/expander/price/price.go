@@ -85,9 +85,31 @@ func NewStrategy(pricingModel cloudprovider.PricingModel,
 	}
 }
 
+// NewFilter returns an expansion filter that leaves the options with the best score based on price
+// and preferred node type.
+func NewFilter(pricingModel cloudprovider.PricingModel,
+	preferredNodeProvider PreferredNodeProvider,
+	nodeUnfitness NodeUnfitness,
+) expander.Filter {
+	return &priceBased{
+		pricingModel:          pricingModel,
+		preferredNodeProvider: preferredNodeProvider,
+		nodeUnfitness:         nodeUnfitness,
+	}
+}
+
 // BestOption selects option based on cost and preferred node type.
 func (p *priceBased) BestOption(expansionOptions []expander.Option, nodeInfos map[string]*schedulercache.NodeInfo) *expander.Option {
-	var bestOption *expander.Option
+	bestOptions := p.BestOptions(expansionOptions, nodeInfos)
+	if len(bestOptions) == 0 {
+		return nil
+	}
+	return &bestOptions[0]
+}
+
+// BestOptions selects the options with the best score based on cost and preferred node type.
+func (p *priceBased) BestOptions(expansionOptions []expander.Option, nodeInfos map[string]*schedulercache.NodeInfo) []expander.Option {
+	var bestOptions []expander.Option
 	bestOptionScore := 0.0
 	now := time.Now()
 	then := now.Add(time.Hour)
@@ -162,17 +184,20 @@ nextoption:
 
 		glog.V(5).Infof("Price expander for %s: %s", option.NodeGroup.Id(), debug)
 
-		if bestOption == nil || bestOptionScore > optionScore {
-			bestOption = &expander.Option{
-				NodeGroup: option.NodeGroup,
-				NodeCount: option.NodeCount,
-				Debug:     fmt.Sprintf("%s | price-expander: %s", option.Debug, debug),
-				Pods:      option.Pods,
-			}
+		scoredOption := expander.Option{
+			NodeGroup: option.NodeGroup,
+			NodeCount: option.NodeCount,
+			Debug:     fmt.Sprintf("%s | price-expander: %s", option.Debug, debug),
+			Pods:      option.Pods,
+		}
+		if bestOptions == nil || bestOptionScore > optionScore {
+			bestOptions = []expander.Option{scoredOption}
 			bestOptionScore = optionScore
+		} else if bestOptionScore == optionScore {
+			bestOptions = append(bestOptions, scoredOption)
 		}
 	}
-	return bestOption
+	return bestOptions
 }
 
 // buildPod creates a pod with specified resources.
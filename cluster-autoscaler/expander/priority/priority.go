@@ -0,0 +1,173 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/random"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+)
+
+const (
+	// PriorityConfigMapName is the name of the ConfigMap holding the priority expander configuration.
+	PriorityConfigMapName = "cluster-autoscaler-priority-expander"
+	// PrioritiesConfigMapKey is the key under which priorities are stored in the ConfigMap.
+	PrioritiesConfigMapKey = "priorities"
+)
+
+// priorityEntry holds the node group name patterns configured for a single priority.
+type priorityEntry struct {
+	priority int
+	patterns []*regexp.Regexp
+}
+
+type priority struct {
+	fallbackStrategy expander.Strategy
+	reader           *kube_util.ConfigMapReader
+	// priorities parsed from the ConfigMap, sorted from the highest. Nil if there is no valid configuration.
+	priorities []priorityEntry
+}
+
+// NewStrategy returns a strategy that selects the node groups with the highest priority configured in
+// the priority expander ConfigMap, picking randomly among node groups with the same priority. The
+// ConfigMap is read through the given lister, so changes are picked up without a restart.
+func NewStrategy(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder) expander.Strategy {
+	return newPriority(configMapLister, logRecorder)
+}
+
+// NewFilter returns a filter that leaves the options whose node groups have the highest priority
+// configured in the priority expander ConfigMap.
+func NewFilter(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder) expander.Filter {
+	return newPriority(configMapLister, logRecorder)
+}
+
+func newPriority(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder) *priority {
+	return &priority{
+		fallbackStrategy: random.NewStrategy(),
+		reader: kube_util.NewConfigMapReader(configMapLister, logRecorder, PriorityConfigMapName,
+			PrioritiesConfigMapKey, "PriorityConfigMapInvalid"),
+	}
+}
+
+// BestOption selects the option whose node group matches the highest priority, picking randomly among ties.
+func (p *priority) BestOption(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) *expander.Option {
+	bestOptions := p.BestOptions(expansionOptions, nodeInfo)
+	if len(bestOptions) == 0 {
+		return nil
+	}
+	return p.fallbackStrategy.BestOption(bestOptions, nodeInfo)
+}
+
+// BestOptions selects the options whose node groups match the highest priority. Node groups that
+// don't match any priority are only considered if none of the options does.
+func (p *priority) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
+	if len(expansionOptions) == 0 {
+		return nil
+	}
+	p.reloadConfig()
+	if p.priorities == nil {
+		glog.Warningf("Priority expander has no valid configuration, considering all node groups")
+		return expansionOptions
+	}
+
+	var bestPriority int
+	var bestOptions []expander.Option
+	for _, option := range expansionOptions {
+		optionPriority, found := p.priorityForNodeGroup(option.NodeGroup.Id())
+		if !found {
+			glog.V(4).Infof("Node group %s doesn't match any priority", option.NodeGroup.Id())
+			continue
+		}
+		if bestOptions == nil || optionPriority > bestPriority {
+			bestPriority = optionPriority
+			bestOptions = []expander.Option{option}
+		} else if optionPriority == bestPriority {
+			bestOptions = append(bestOptions, option)
+		}
+	}
+	if bestOptions == nil {
+		glog.Warningf("No node group matches any priority of the priority expander, considering all node groups")
+		return expansionOptions
+	}
+	for _, option := range bestOptions {
+		glog.V(2).Infof("Priority expander: %s has priority %d", option.NodeGroup.Id(), bestPriority)
+	}
+	return bestOptions
+}
+
+// priorityForNodeGroup returns the highest priority with a pattern matching the node group id.
+func (p *priority) priorityForNodeGroup(id string) (int, bool) {
+	for _, entry := range p.priorities {
+		for _, pattern := range entry.patterns {
+			if pattern.MatchString(id) {
+				return entry.priority, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// reloadConfig parses the ConfigMap again if it changed since it was last read. An invalid
+// configuration is reported and the previous one is kept.
+func (p *priority) reloadConfig() {
+	p.reader.Refresh(func(data string) error {
+		priorities, err := parsePriorities(data)
+		if err != nil {
+			return err
+		}
+		glog.V(1).Infof("Loaded priority expander configuration from ConfigMap %s", PriorityConfigMapName)
+		p.priorities = priorities
+		return nil
+	}, func() {
+		p.priorities = nil
+	})
+}
+
+// parsePriorities parses a YAML map from priority to a list of node group id regular expressions.
+// An expression has to match the whole node group id.
+func parsePriorities(config string) ([]priorityEntry, error) {
+	raw := make(map[int][]string)
+	if err := yaml.Unmarshal([]byte(config), &raw); err != nil {
+		return nil, fmt.Errorf("can't parse priorities: %v", err)
+	}
+
+	result := make([]priorityEntry, 0, len(raw))
+	for priority, expressions := range raw {
+		entry := priorityEntry{priority: priority}
+		for _, expression := range expressions {
+			pattern, err := regexp.Compile("^(?:" + expression + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("can't compile expression %q of priority %d: %v", expression, priority, err)
+			}
+			entry.patterns = append(entry.patterns, pattern)
+		}
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].priority > result[j].priority
+	})
+	return result, nil
+}
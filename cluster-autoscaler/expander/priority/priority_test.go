@@ -0,0 +1,149 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"testing"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const (
+	testNamespace = "kube-system"
+	config        = `
+10:
+  - spot-.*
+50:
+  - on-demand-.*
+  - reserved
+100:
+  - gpu
+`
+	changedConfig = `
+10:
+  - on-demand-.*
+50:
+  - spot-.*
+`
+)
+
+func buildConfigMap(resourceVersion string, priorities string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            PriorityConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{
+			PrioritiesConfigMapKey: priorities,
+		},
+	}
+}
+
+func buildOptions(ids ...string) []expander.Option {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	options := make([]expander.Option, 0, len(ids))
+	for _, id := range ids {
+		provider.AddNodeGroup(id, 0, 10, 1)
+		options = append(options, expander.Option{NodeGroup: provider.GetNodeGroup(id), NodeCount: 1})
+	}
+	return options
+}
+
+func setUp(t *testing.T) (*priority, cache.Indexer) {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps(testNamespace)
+	logRecorder, err := utils.NewStatusMapRecorder(fake.NewSimpleClientset(), testNamespace, kube_record.NewFakeRecorder(10), false)
+	assert.NoError(t, err)
+	return NewStrategy(lister, logRecorder).(*priority), store
+}
+
+func TestHighestPriorityIsPicked(t *testing.T) {
+	strategy, store := setUp(t)
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+
+	options := buildOptions("spot-1", "on-demand-1", "other")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "on-demand-1", strategy.BestOption(options, nil).NodeGroup.Id())
+	}
+
+	options = buildOptions("spot-1", "gpu", "on-demand-1")
+	assert.Equal(t, "gpu", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	// Patterns have to match the whole id.
+	options = buildOptions("spot-1", "reserved-1")
+	assert.Equal(t, "spot-1", strategy.BestOption(options, nil).NodeGroup.Id())
+}
+
+func TestTiesArePickedRandomly(t *testing.T) {
+	strategy, store := setUp(t)
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+
+	options := buildOptions("spot-1", "on-demand-1", "reserved")
+	picked := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		picked[strategy.BestOption(options, nil).NodeGroup.Id()] = true
+	}
+	assert.Equal(t, map[string]bool{"on-demand-1": true, "reserved": true}, picked)
+}
+
+func TestConfigReload(t *testing.T) {
+	strategy, store := setUp(t)
+	options := buildOptions("spot-1", "on-demand-1", "other")
+
+	// No ConfigMap, all options are considered.
+	assert.NotNil(t, strategy.BestOption(options, nil))
+
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+	assert.Equal(t, "on-demand-1", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	assert.NoError(t, store.Update(buildConfigMap("2", changedConfig)))
+	assert.Equal(t, "spot-1", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	// Invalid configuration is ignored, the last valid one is used.
+	assert.NoError(t, store.Update(buildConfigMap("3", "10: [\"(\"]")))
+	assert.Equal(t, "spot-1", strategy.BestOption(options, nil).NodeGroup.Id())
+	assert.NoError(t, store.Update(buildConfigMap("4", "not a map")))
+	assert.Equal(t, "spot-1", strategy.BestOption(options, nil).NodeGroup.Id())
+
+	assert.NoError(t, store.Delete(buildConfigMap("4", "")))
+	assert.NotNil(t, strategy.BestOption(options, nil))
+	assert.Nil(t, strategy.priorities)
+}
+
+func TestNoMatchingNodeGroup(t *testing.T) {
+	strategy, store := setUp(t)
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+
+	options := buildOptions("other-1", "other-2")
+	picked := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		picked[strategy.BestOption(options, nil).NodeGroup.Id()] = true
+	}
+	assert.Equal(t, map[string]bool{"other-1": true, "other-2": true}, picked)
+	assert.Nil(t, strategy.BestOption([]expander.Option{}, nil))
+}
@@ -31,8 +31,25 @@ func NewStrategy() expander.Strategy {
 	return &random{}
 }
 
+// NewFilter returns an expansion filter that leaves a single, randomly picked option
+func NewFilter() expander.Filter {
+	return &random{}
+}
+
+// BestOptions selects a single option at random
+func (r *random) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) []expander.Option {
+	best := r.BestOption(expansionOptions, nodeInfo)
+	if best == nil {
+		return nil
+	}
+	return []expander.Option{*best}
+}
+
 // RandomExpansion Selects from the expansion options at random
 func (r *random) BestOption(expansionOptions []expander.Option, nodeInfo map[string]*schedulercache.NodeInfo) *expander.Option {
+	if len(expansionOptions) == 0 {
+		return nil
+	}
 	pos := rand.Int31n(int32(len(expansionOptions)))
 	return &expansionOptions[pos]
 }
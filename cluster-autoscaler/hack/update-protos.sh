@@ -0,0 +1,55 @@
+#!/bin/bash
+
+# Copyright 2018 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Regenerates the Go code of the gRPC protocols of Cluster Autoscaler.
+# Requires protoc 3.x older than 3.14 in PATH, whose well-known types map to
+# github.com/golang/protobuf/ptypes. protoc-gen-go is built at the golang/protobuf
+# revision from Godeps, so that the generated code matches the vendored library.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+CA_ROOT="$(cd "$(dirname "${BASH_SOURCE}")/.." && pwd)"
+
+PROTOS=(
+  cloudprovider/externalgrpc/protos/externalgrpc.proto
+  expander/grpcplugin/protos/expander.proto
+)
+
+PROTOBUF_REV=$(grep -A2 '"ImportPath": "github.com/golang/protobuf/proto"' "${CA_ROOT}/Godeps/Godeps.json" | sed -n 's/.*"Rev": "\(.*\)".*/\1/p')
+
+TOOLS_GOPATH="$(mktemp -d)"
+trap 'rm -rf "${TOOLS_GOPATH}"' EXIT
+
+git clone --quiet https://github.com/golang/protobuf "${TOOLS_GOPATH}/src/github.com/golang/protobuf"
+git -C "${TOOLS_GOPATH}/src/github.com/golang/protobuf" checkout --quiet "${PROTOBUF_REV}"
+GOPATH="${TOOLS_GOPATH}" GO111MODULE=off go install github.com/golang/protobuf/protoc-gen-go
+
+# k8s.io/api/core/v1/generated.proto imports the apiextensions protos without using them, and they
+# are not vendored. An empty file stands in for them.
+STUBS="${TOOLS_GOPATH}/protos"
+APIEXTENSIONS_PROTO=k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1/generated.proto
+mkdir -p "$(dirname "${STUBS}/${APIEXTENSIONS_PROTO}")"
+cat > "${STUBS}/${APIEXTENSIONS_PROTO}" <<EOF
+syntax = 'proto2';
+package k8s.io.apiextensions_apiserver.pkg.apis.apiextensions.v1beta1;
+EOF
+
+cd "${CA_ROOT}"
+for proto in "${PROTOS[@]}"; do
+  protoc -I . -I vendor -I "${STUBS}" --plugin=protoc-gen-go="${TOOLS_GOPATH}/bin/protoc-gen-go" --go_out=plugins=grpc:. "${proto}"
+done
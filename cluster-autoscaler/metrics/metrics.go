@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
@@ -39,6 +40,9 @@ type FunctionLabel string
 // NodeGroupType describes node group relation to CA
 type NodeGroupType string
 
+// DryRunDecision describes an action CA would have taken if dry run was disabled
+type DryRunDecision string
+
 const (
 	caNamespace           = "cluster_autoscaler"
 	readyLabel            = "ready"
@@ -53,11 +57,26 @@ const (
 	Empty NodeScaleDownReason = "empty"
 	// Unready node was removed
 	Unready NodeScaleDownReason = "unready"
+	// Consolidated node was removed after its pods were given room on new, cheaper nodes
+	Consolidated NodeScaleDownReason = "consolidated"
+	// Recycled node was removed after being replaced because of its age
+	Recycled NodeScaleDownReason = "recycled"
 
 	// APIError caused scale-up to fail
 	APIError FailedScaleUpReason = "apiCallError"
 	// Timeout was encountered when trying to scale-up
 	Timeout FailedScaleUpReason = "timeout"
+	// QuotaExceeded - a quota of the cloud provider didn't allow the scale-up
+	QuotaExceeded FailedScaleUpReason = "quotaExceeded"
+	// Stockout - the cloud provider was out of capacity for the node group
+	Stockout FailedScaleUpReason = "stockout"
+	// InvalidConfiguration - instances couldn't be created from the node group template
+	InvalidConfiguration FailedScaleUpReason = "invalidConfiguration"
+
+	// DryRunScaleUp - nodes would have been added to a node group
+	DryRunScaleUp DryRunDecision = "scaleUp"
+	// DryRunScaleDown - nodes would have been removed from the cluster
+	DryRunScaleDown DryRunDecision = "scaleDown"
 
 	// autoscaledGroup is managed by CA
 	autoscaledGroup NodeGroupType = "autoscaled"
@@ -79,6 +98,8 @@ const (
 	ScaleDownFindNodesToRemove FunctionLabel = "scaleDown:findNodesToRemove"
 	ScaleDownMiscOperations    FunctionLabel = "scaleDown:miscOperations"
 	ScaleUp                    FunctionLabel = "scaleUp"
+	Consolidation              FunctionLabel = "consolidation"
+	NodeRecycling              FunctionLabel = "nodeRecycling"
 	FindUnneeded               FunctionLabel = "findUnneeded"
 	UpdateState                FunctionLabel = "updateClusterState"
 	FilterOutSchedulable       FunctionLabel = "filterOutSchedulable"
@@ -205,6 +226,14 @@ var (
 		},
 	)
 
+	dryRunDecisionsCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "dry_run_decisions",
+			Help:      "Number of nodes CA would have added or removed in the last loop if dry run was disabled, by decision.",
+		}, []string{"decision"},
+	)
+
 	/**** Metrics related to NodeAutoprovisioning ****/
 	napEnabled = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -231,6 +260,12 @@ var (
 	)
 )
 
+var (
+	dryRunDecisionsLock sync.Mutex
+	// pendingDryRunDecisions are the nodes affected by the dry run decisions of the current loop.
+	pendingDryRunDecisions = make(map[DryRunDecision]int)
+)
+
 // RegisterAll registers all metrics.
 func RegisterAll() {
 	prometheus.MustRegister(clusterSafeToAutoscale)
@@ -247,6 +282,7 @@ func RegisterAll() {
 	prometheus.MustRegister(gpuScaleDownCount)
 	prometheus.MustRegister(evictionsCount)
 	prometheus.MustRegister(unneededNodesCount)
+	prometheus.MustRegister(dryRunDecisionsCount)
 	prometheus.MustRegister(napEnabled)
 	prometheus.MustRegister(nodeGroupCreationCount)
 	prometheus.MustRegister(nodeGroupDeletionCount)
@@ -340,6 +376,25 @@ func UpdateUnneededNodesCount(nodesCount int) {
 	unneededNodesCount.Set(float64(nodesCount))
 }
 
+// RegisterDryRunDecision records number of nodes affected by a decision which was not acted upon because of dry run.
+// Decisions of the current loop are published by UpdateDryRunDecisions.
+func RegisterDryRunDecision(decision DryRunDecision, nodesCount int) {
+	dryRunDecisionsLock.Lock()
+	defer dryRunDecisionsLock.Unlock()
+	pendingDryRunDecisions[decision] += nodesCount
+}
+
+// UpdateDryRunDecisions records number of nodes affected by the dry run decisions registered since the last call,
+// so that decisions repeated in every loop are not counted again.
+func UpdateDryRunDecisions() {
+	dryRunDecisionsLock.Lock()
+	defer dryRunDecisionsLock.Unlock()
+	for _, decision := range []DryRunDecision{DryRunScaleUp, DryRunScaleDown} {
+		dryRunDecisionsCount.WithLabelValues(string(decision)).Set(float64(pendingDryRunDecisions[decision]))
+	}
+	pendingDryRunDecisions = make(map[DryRunDecision]int)
+}
+
 // UpdateNapEnabled records if NodeAutoprovisioning is enabled
 func UpdateNapEnabled(enabled bool) {
 	if enabled {
@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDryRunDecisions(t *testing.T, decision DryRunDecision) float64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, dryRunDecisionsCount.WithLabelValues(string(decision)).Write(metric))
+	return metric.GetGauge().GetValue()
+}
+
+func TestUpdateDryRunDecisions(t *testing.T) {
+	RegisterDryRunDecision(DryRunScaleUp, 2)
+	RegisterDryRunDecision(DryRunScaleUp, 1)
+	RegisterDryRunDecision(DryRunScaleDown, 1)
+	UpdateDryRunDecisions()
+	assert.Equal(t, 3.0, getDryRunDecisions(t, DryRunScaleUp))
+	assert.Equal(t, 1.0, getDryRunDecisions(t, DryRunScaleDown))
+
+	// The same decision in the next loop doesn't add up.
+	RegisterDryRunDecision(DryRunScaleUp, 3)
+	UpdateDryRunDecisions()
+	assert.Equal(t, 3.0, getDryRunDecisions(t, DryRunScaleUp))
+	assert.Equal(t, 0.0, getDryRunDecisions(t, DryRunScaleDown))
+
+	UpdateDryRunDecisions()
+	assert.Equal(t, 0.0, getDryRunDecisions(t, DryRunScaleUp))
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+)
+
+// cloudProvider delegates to a TestCloudProvider which is replaced every time
+// a new record is loaded, so that the autoscaler can keep a single reference.
+type cloudProvider struct {
+	sync.Mutex
+	current *testprovider.TestCloudProvider
+}
+
+var _ cloudprovider.CloudProvider = (*cloudProvider)(nil)
+
+func (p *cloudProvider) set(provider *testprovider.TestCloudProvider) {
+	p.Lock()
+	defer p.Unlock()
+	p.current = provider
+}
+
+func (p *cloudProvider) get() *testprovider.TestCloudProvider {
+	p.Lock()
+	defer p.Unlock()
+	return p.current
+}
+
+// Name returns name of the cloud provider.
+func (p *cloudProvider) Name() string {
+	return "replay"
+}
+
+// NodeGroups returns all node groups of the current record.
+func (p *cloudProvider) NodeGroups() []cloudprovider.NodeGroup {
+	return p.get().NodeGroups()
+}
+
+// NodeGroupForNode returns the node group for the given node.
+func (p *cloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	return p.get().NodeGroupForNode(node)
+}
+
+// Pricing is not available for replayed clusters.
+func (p *cloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
+	return p.get().Pricing()
+}
+
+// GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
+func (p *cloudProvider) GetAvailableMachineTypes() ([]string, error) {
+	return p.get().GetAvailableMachineTypes()
+}
+
+// NewNodeGroup builds a theoretical node group based on the node definition provided.
+func (p *cloudProvider) NewNodeGroup(machineType string, labels map[string]string, systemLabels map[string]string,
+	taints []apiv1.Taint, extraResources map[string]resource.Quantity) (cloudprovider.NodeGroup, error) {
+	return nil, cloudprovider.ErrNotImplemented
+}
+
+// GetResourceLimiter returns struct containing limits (max, min) for resources (cores, memory etc.).
+func (p *cloudProvider) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
+	return p.get().GetResourceLimiter()
+}
+
+// Cleanup cleans up open resources before the cloud provider is destroyed.
+func (p *cloudProvider) Cleanup() error {
+	return nil
+}
+
+// Refresh is a no-op, the state changes only when a new record is loaded.
+func (p *cloudProvider) Refresh() error {
+	return nil
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+)
+
+// DecisionType describes the kind of action taken by the autoscaler.
+type DecisionType string
+
+const (
+	// ScaleUpDecision - a node group was increased.
+	ScaleUpDecision DecisionType = "ScaleUp"
+	// DecreaseTargetSizeDecision - target size of a node group was decreased without deleting nodes.
+	DecreaseTargetSizeDecision DecisionType = "DecreaseTargetSize"
+	// ScaleDownDecision - a node was deleted.
+	ScaleDownDecision DecisionType = "ScaleDown"
+)
+
+// Decision is an action taken by the autoscaler while replaying a loop.
+type Decision struct {
+	// Timestamp of the loop in which the decision was taken.
+	Timestamp time.Time
+	// Type of the decision.
+	Type DecisionType
+	// NodeGroup affected by the decision.
+	NodeGroup string
+	// Delta is the change of node group size, set for ScaleUp and DecreaseTargetSize.
+	Delta int
+	// Node is the name of the deleted node, set for ScaleDown.
+	Node string
+}
+
+// String returns a human readable description of the decision.
+func (d Decision) String() string {
+	switch d.Type {
+	case ScaleDownDecision:
+		return fmt.Sprintf("%s %s: removed node %s from %s", d.Timestamp.Format(time.RFC3339), d.Type, d.Node, d.NodeGroup)
+	default:
+		return fmt.Sprintf("%s %s: changed size of %s by %d", d.Timestamp.Format(time.RFC3339), d.Type, d.NodeGroup, d.Delta)
+	}
+}
+
+// Cluster serves the contents of a single LoopRecord at a time through Kubernetes listers, a fake
+// Kubernetes client and a test cloud provider, and collects the decisions taken against it.
+type Cluster struct {
+	sync.Mutex
+	record          *LoopRecord
+	resourceLimiter *cloudprovider.ResourceLimiter
+	cloudProvider   *cloudProvider
+	clientSet       *fake.Clientset
+	decisions       []Decision
+}
+
+// NewCluster builds an empty Cluster. The given resource limiter is returned by the cloud provider.
+func NewCluster(resourceLimiter *cloudprovider.ResourceLimiter) *Cluster {
+	cluster := &Cluster{
+		record:          &LoopRecord{},
+		resourceLimiter: resourceLimiter,
+		decisions:       make([]Decision, 0),
+	}
+	cluster.cloudProvider = &cloudProvider{current: testprovider.NewTestCloudProvider(nil, nil)}
+	cluster.clientSet = cluster.buildClientSet()
+	return cluster
+}
+
+// Load replaces the state of the cluster with the given record.
+func (c *Cluster) Load(record *LoopRecord) {
+	templates := make(map[string]*schedulercache.NodeInfo)
+	for _, group := range record.NodeGroups {
+		if group.Template == nil {
+			continue
+		}
+		nodeInfo := schedulercache.NewNodeInfo(group.TemplatePods...)
+		nodeInfo.SetNode(group.Template)
+		templates[group.Id] = nodeInfo
+	}
+
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(
+		func(id string, delta int) error {
+			if delta < 0 {
+				c.addDecision(Decision{Type: DecreaseTargetSizeDecision, NodeGroup: id, Delta: delta})
+			} else {
+				c.addDecision(Decision{Type: ScaleUpDecision, NodeGroup: id, Delta: delta})
+			}
+			return nil
+		}, func(id string, name string) error {
+			c.addDecision(Decision{Type: ScaleDownDecision, NodeGroup: id, Node: name})
+			return nil
+		}, nil, nil, nil, templates)
+	provider.SetResourceLimiter(c.resourceLimiter)
+	nodes := make(map[string]*apiv1.Node, len(record.Nodes))
+	for _, node := range record.Nodes {
+		nodes[node.Name] = node
+	}
+	for _, group := range record.NodeGroups {
+		provider.AddNodeGroup(group.Id, group.MinSize, group.MaxSize, group.TargetSize)
+		for _, name := range group.Nodes {
+			if node, found := nodes[name]; found {
+				provider.AddNode(group.Id, node)
+			}
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.record = record
+	c.cloudProvider.set(provider)
+}
+
+// TakeDecisions returns the decisions taken since the last call and forgets them.
+func (c *Cluster) TakeDecisions() []Decision {
+	c.Lock()
+	defer c.Unlock()
+	result := c.decisions
+	c.decisions = make([]Decision, 0)
+	return result
+}
+
+// CloudProvider returns the cloud provider backed by the node groups of the current record.
+func (c *Cluster) CloudProvider() cloudprovider.CloudProvider {
+	return c.cloudProvider
+}
+
+// ClientSet returns a fake Kubernetes client serving nodes and pods of the current record.
+// All writes succeed without changing the record.
+func (c *Cluster) ClientSet() kube_client.Interface {
+	return c.clientSet
+}
+
+// ListerRegistry returns listers serving the objects of the current record.
+func (c *Cluster) ListerRegistry() kube_util.ListerRegistry {
+	return kube_util.NewListerRegistry(
+		nodeListerFunc(func() []*apiv1.Node {
+			return c.current().Nodes
+		}),
+		nodeListerFunc(func() []*apiv1.Node {
+			readyNodes := make([]*apiv1.Node, 0)
+			for _, node := range c.current().Nodes {
+				if kube_util.IsNodeReadyAndSchedulable(node) {
+					readyNodes = append(readyNodes, node)
+				}
+			}
+			return readyNodes
+		}),
+		podListerFunc(func() []*apiv1.Pod {
+			return c.current().ScheduledPods
+		}),
+		podListerFunc(func() []*apiv1.Pod {
+			return c.current().UnschedulablePods
+		}),
+		pdbListerFunc(func() []*policyv1.PodDisruptionBudget {
+			return c.current().PodDisruptionBudgets
+		}),
+		daemonSetListerFunc(func() []*extensionsv1.DaemonSet {
+			return c.current().DaemonSets
+		}))
+}
+
+func (c *Cluster) current() *LoopRecord {
+	c.Lock()
+	defer c.Unlock()
+	return c.record
+}
+
+func (c *Cluster) addDecision(decision Decision) {
+	c.Lock()
+	defer c.Unlock()
+	decision.Timestamp = c.record.Timestamp
+	c.decisions = append(c.decisions, decision)
+}
+
+func (c *Cluster) buildClientSet() *fake.Clientset {
+	clientSet := &fake.Clientset{}
+	clientSet.Fake.AddReactor("get", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		name := action.(core.GetAction).GetName()
+		for _, node := range c.current().Nodes {
+			if node.Name == name {
+				return true, node.DeepCopy(), nil
+			}
+		}
+		return true, nil, kube_errors.NewNotFound(apiv1.Resource("node"), name)
+	})
+	clientSet.Fake.AddReactor("update", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		return true, action.(core.UpdateAction).GetObject(), nil
+	})
+	clientSet.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		nodeName, _ := action.(core.ListAction).GetListRestrictions().Fields.RequiresExactMatch("spec.nodeName")
+		result := &apiv1.PodList{}
+		for _, pod := range c.current().ScheduledPods {
+			if nodeName == "" || pod.Spec.NodeName == nodeName {
+				result.Items = append(result.Items, *pod)
+			}
+		}
+		return true, result, nil
+	})
+	// Evicted pods are gone immediately.
+	clientSet.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, kube_errors.NewNotFound(apiv1.Resource("pod"), action.(core.GetAction).GetName())
+	})
+	// Config maps aren't recorded, components configured through them use their defaults.
+	clientSet.Fake.AddReactor("list", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &apiv1.ConfigMapList{}, nil
+	})
+	clientSet.Fake.AddWatchReactor("*", func(action core.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+	return clientSet
+}
+
+type nodeListerFunc func() []*apiv1.Node
+
+func (f nodeListerFunc) List() ([]*apiv1.Node, error) {
+	return f(), nil
+}
+
+type podListerFunc func() []*apiv1.Pod
+
+func (f podListerFunc) List() ([]*apiv1.Pod, error) {
+	return f(), nil
+}
+
+type pdbListerFunc func() []*policyv1.PodDisruptionBudget
+
+func (f pdbListerFunc) List() ([]*policyv1.PodDisruptionBudget, error) {
+	return f(), nil
+}
+
+type daemonSetListerFunc func() []*extensionsv1.DaemonSet
+
+func (f daemonSetListerFunc) List() ([]*extensionsv1.DaemonSet, error) {
+	return f(), nil
+}
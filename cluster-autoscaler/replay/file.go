@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Recorder persists the inputs of autoscaler loops.
+type Recorder interface {
+	// Record persists a single loop record.
+	Record(record *LoopRecord) error
+	// Close releases resources held by the recorder.
+	Close() error
+}
+
+// FileRecorder appends loop records to a local file, one JSON document per line.
+type FileRecorder struct {
+	sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// NewFileRecorder builds a FileRecorder appending to the file at the given path.
+// The file is created if it doesn't exist. Once the file would grow past maxSize bytes,
+// it's renamed to <path>.1, replacing the previous one, and a new file is started.
+// The file grows without limit if maxSize is not positive.
+func NewFileRecorder(path string, maxSize int64) (*FileRecorder, error) {
+	recorder := &FileRecorder{
+		path:    path,
+		maxSize: maxSize,
+	}
+	if err := recorder.open(); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func (r *FileRecorder) open() error {
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open loop record file %s: %v", r.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat loop record file %s: %v", r.path, err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *FileRecorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close loop record file %s: %v", r.path, err)
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate loop record file %s: %v", r.path, err)
+	}
+	return r.open()
+}
+
+// Record appends the record to the file.
+func (r *FileRecorder) Record(record *LoopRecord) error {
+	r.Lock()
+	defer r.Unlock()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(data)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	written, err := r.file.Write(data)
+	r.size += int64(written)
+	return err
+}
+
+// Close closes the underlying file.
+func (r *FileRecorder) Close() error {
+	r.Lock()
+	defer r.Unlock()
+	return r.file.Close()
+}
+
+// ReadRecords reads all loop records from the given reader, in the order they were recorded.
+func ReadRecords(reader io.Reader) ([]*LoopRecord, error) {
+	result := make([]*LoopRecord, 0)
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for {
+		record := &LoopRecord{}
+		err := decoder.Decode(record)
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode loop record %d: %v", len(result)+1, err)
+		}
+		result = append(result, record)
+	}
+}
+
+// ReadRecordsFromFile reads all loop records from the file at the given path.
+func ReadRecordsFromFile(path string) ([]*LoopRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open loop record file %s: %v", path, err)
+	}
+	defer file.Close()
+	return ReadRecords(file)
+}
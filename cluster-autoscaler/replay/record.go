@@ -0,0 +1,170 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"reflect"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	extensionsv1 "k8s.io/api/extensions/v1beta1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// How long the template of a node group is reused in loop records before it's
+	// asked from the cloud provider again.
+	templateCacheTTL = time.Hour
+)
+
+// LoopRecord contains all the inputs of a single autoscaler loop.
+type LoopRecord struct {
+	// Timestamp is the current time passed to the recorded loop.
+	Timestamp time.Time `json:"timestamp"`
+	// Nodes are all nodes in the cluster, ready and unready.
+	Nodes []*apiv1.Node `json:"nodes"`
+	// ScheduledPods are pods bound to nodes.
+	ScheduledPods []*apiv1.Pod `json:"scheduledPods"`
+	// UnschedulablePods are pods marked as unschedulable by the scheduler.
+	UnschedulablePods []*apiv1.Pod `json:"unschedulablePods"`
+	// PodDisruptionBudgets are all pod disruption budgets in the cluster.
+	PodDisruptionBudgets []*policyv1.PodDisruptionBudget `json:"podDisruptionBudgets"`
+	// DaemonSets are all daemon sets in the cluster.
+	DaemonSets []*extensionsv1.DaemonSet `json:"daemonSets"`
+	// NodeGroups describe the node groups reported by the cloud provider.
+	NodeGroups []*NodeGroupRecord `json:"nodeGroups"`
+}
+
+// NodeGroupRecord describes a node group at the time of the recorded loop.
+type NodeGroupRecord struct {
+	// Id is the node group id.
+	Id string `json:"id"`
+	// MinSize is the minimum size of the node group.
+	MinSize int `json:"minSize"`
+	// MaxSize is the maximum size of the node group.
+	MaxSize int `json:"maxSize"`
+	// TargetSize is the target size of the node group.
+	TargetSize int `json:"targetSize"`
+	// Nodes are the names of the Kubernetes nodes belonging to the node group.
+	Nodes []string `json:"nodes"`
+	// Template is the template node of the node group, nil if not available.
+	Template *apiv1.Node `json:"template,omitempty"`
+	// TemplatePods are the pods started on the template node by default.
+	TemplatePods []*apiv1.Pod `json:"templatePods,omitempty"`
+}
+
+// TemplateCache keeps the templates of node groups between loop records, since building
+// a template may take calls to the cloud provider.
+type TemplateCache struct {
+	entries map[string]templateCacheEntry
+}
+
+type templateCacheEntry struct {
+	// nodeInfo is nil if the node group has no template.
+	nodeInfo *schedulercache.NodeInfo
+	added    time.Time
+}
+
+// NewTemplateCache builds an empty TemplateCache.
+func NewTemplateCache() *TemplateCache {
+	return &TemplateCache{
+		entries: make(map[string]templateCacheEntry),
+	}
+}
+
+// templateNodeInfo returns the template of the node group, nil if not available.
+func (c *TemplateCache) templateNodeInfo(nodeGroup cloudprovider.NodeGroup, now time.Time) *schedulercache.NodeInfo {
+	if entry, found := c.entries[nodeGroup.Id()]; found && now.Sub(entry.added) < templateCacheTTL {
+		return entry.nodeInfo
+	}
+	nodeInfo, err := nodeGroup.TemplateNodeInfo()
+	if err != nil {
+		nodeInfo = nil
+	}
+	c.entries[nodeGroup.Id()] = templateCacheEntry{nodeInfo: nodeInfo, added: now}
+	return nodeInfo
+}
+
+// removeExpired drops the templates that are too old to be reused, including those of removed node groups.
+func (c *TemplateCache) removeExpired(now time.Time) {
+	for id, entry := range c.entries {
+		if now.Sub(entry.added) >= templateCacheTTL {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// NewLoopRecord builds a LoopRecord from the state observed by the autoscaler. Node groups
+// are read from the cloud provider, errors while reading them are logged and the affected
+// information is left out of the record. Templates of node groups are taken from the cache
+// when possible.
+func NewLoopRecord(timestamp time.Time, cloudProvider cloudprovider.CloudProvider, templates *TemplateCache,
+	nodes []*apiv1.Node, scheduledPods []*apiv1.Pod, unschedulablePods []*apiv1.Pod,
+	pdbs []*policyv1.PodDisruptionBudget, daemonSets []*extensionsv1.DaemonSet) *LoopRecord {
+
+	nodeGroups := make(map[string]*NodeGroupRecord)
+	result := &LoopRecord{
+		Timestamp:            timestamp,
+		Nodes:                nodes,
+		ScheduledPods:        scheduledPods,
+		UnschedulablePods:    unschedulablePods,
+		PodDisruptionBudgets: pdbs,
+		DaemonSets:           daemonSets,
+		NodeGroups:           make([]*NodeGroupRecord, 0),
+	}
+
+	templates.removeExpired(timestamp)
+	for _, nodeGroup := range cloudProvider.NodeGroups() {
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			glog.Warningf("Failed to get target size of %s for loop record: %v", nodeGroup.Id(), err)
+			continue
+		}
+		record := &NodeGroupRecord{
+			Id:         nodeGroup.Id(),
+			MinSize:    nodeGroup.MinSize(),
+			MaxSize:    nodeGroup.MaxSize(),
+			TargetSize: targetSize,
+			Nodes:      make([]string, 0),
+		}
+		if nodeInfo := templates.templateNodeInfo(nodeGroup, timestamp); nodeInfo != nil {
+			record.Template = nodeInfo.Node()
+			record.TemplatePods = nodeInfo.Pods()
+		}
+		nodeGroups[record.Id] = record
+		result.NodeGroups = append(result.NodeGroups, record)
+	}
+
+	for _, node := range nodes {
+		nodeGroup, err := cloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			glog.Warningf("Failed to get node group for %s for loop record: %v", node.Name, err)
+			continue
+		}
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		if record, found := nodeGroups[nodeGroup.Id()]; found {
+			record.Nodes = append(record.Nodes, node.Name)
+		}
+	}
+	return result
+}
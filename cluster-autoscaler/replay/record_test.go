@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	template := BuildTestNode("ng1-template", 1000, 1000)
+	templateInfo := schedulercache.NewNodeInfo()
+	templateInfo.SetNode(template)
+	p1 := BuildTestPod("p1", 500, 0)
+	p1.Spec.NodeName = "n1"
+	p2 := BuildTestPod("p2", 500, 0)
+
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil,
+		map[string]*schedulercache.NodeInfo{"ng1": templateInfo})
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNode("ng1", n1)
+
+	timestamp := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	record := NewLoopRecord(timestamp, provider, NewTemplateCache(), []*apiv1.Node{n1, n2}, []*apiv1.Pod{p1}, []*apiv1.Pod{p2}, nil, nil)
+	assert.Equal(t, 1, len(record.NodeGroups))
+	assert.Equal(t, "ng1", record.NodeGroups[0].Id)
+	assert.Equal(t, 1, record.NodeGroups[0].TargetSize)
+	assert.Equal(t, []string{"n1"}, record.NodeGroups[0].Nodes)
+	assert.Equal(t, "ng1-template", record.NodeGroups[0].Template.Name)
+
+	dir, err := ioutil.TempDir("", "replay")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "records")
+
+	recorder, err := NewFileRecorder(path, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Record(record))
+	assert.NoError(t, recorder.Record(&LoopRecord{Timestamp: timestamp.Add(time.Minute)}))
+	assert.NoError(t, recorder.Close())
+
+	records, err := ReadRecordsFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.True(t, records[0].Timestamp.Equal(timestamp))
+	assert.Equal(t, []string{"n1", "n2"}, []string{records[0].Nodes[0].Name, records[0].Nodes[1].Name})
+	assert.Equal(t, "p2", records[0].UnschedulablePods[0].Name)
+	assert.Equal(t, []string{"n1"}, records[0].NodeGroups[0].Nodes)
+	assert.True(t, records[1].Timestamp.Equal(timestamp.Add(time.Minute)))
+}
+
+func TestRecordTemplateCache(t *testing.T) {
+	template := BuildTestNode("ng1-template", 1000, 1000)
+	templateInfo := schedulercache.NewNodeInfo()
+	templateInfo.SetNode(template)
+	newTemplate := BuildTestNode("ng1-new-template", 2000, 2000)
+	newTemplateInfo := schedulercache.NewNodeInfo()
+	newTemplateInfo.SetNode(newTemplate)
+
+	templates := map[string]*schedulercache.NodeInfo{"ng1": templateInfo}
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil, templates)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+
+	cache := NewTemplateCache()
+	timestamp := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	record := NewLoopRecord(timestamp, provider, cache, nil, nil, nil, nil, nil)
+	assert.Equal(t, "ng1-template", record.NodeGroups[0].Template.Name)
+
+	// The template is reused until it expires.
+	templates["ng1"] = newTemplateInfo
+	record = NewLoopRecord(timestamp.Add(time.Minute), provider, cache, nil, nil, nil, nil, nil)
+	assert.Equal(t, "ng1-template", record.NodeGroups[0].Template.Name)
+
+	record = NewLoopRecord(timestamp.Add(templateCacheTTL), provider, cache, nil, nil, nil, nil, nil)
+	assert.Equal(t, "ng1-new-template", record.NodeGroups[0].Template.Name)
+}
+
+func TestFileRecorderRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "records")
+
+	timestamp := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(&LoopRecord{Timestamp: timestamp})
+	assert.NoError(t, err)
+	recordSize := int64(len(data) + 1)
+
+	// Two records fit in the file.
+	recorder, err := NewFileRecorder(path, 2*recordSize)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, recorder.Record(&LoopRecord{Timestamp: timestamp.Add(time.Duration(i) * time.Minute)}))
+	}
+	assert.NoError(t, recorder.Close())
+
+	rotated, err := ReadRecordsFromFile(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rotated))
+	assert.True(t, rotated[0].Timestamp.Equal(timestamp))
+	records, err := ReadRecordsFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(records))
+	assert.True(t, records[0].Timestamp.Equal(timestamp.Add(2*time.Minute)))
+
+	// The size of the existing file counts after a restart.
+	recorder, err = NewFileRecorder(path, 2*recordSize)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Record(&LoopRecord{Timestamp: timestamp.Add(3 * time.Minute)}))
+	assert.NoError(t, recorder.Record(&LoopRecord{Timestamp: timestamp.Add(4 * time.Minute)}))
+	assert.NoError(t, recorder.Close())
+
+	rotated, err = ReadRecordsFromFile(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rotated))
+	assert.True(t, rotated[0].Timestamp.Equal(timestamp.Add(2*time.Minute)))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledownorder
+
+import (
+	"strconv"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// DeletionPriorityAnnotationKey is the name of the annotation giving the deletion priority of a node.
+	// The priority is an integer. Nodes with higher priority are removed first, nodes without the annotation
+	// have priority 0.
+	DeletionPriorityAnnotationKey = "cluster-autoscaler.kubernetes.io/scale-down-priority"
+)
+
+type utilizationPolicy struct{}
+
+// NewUtilizationPolicy returns a policy removing the least utilized nodes first.
+func NewUtilizationPolicy() Policy {
+	return &utilizationPolicy{}
+}
+
+// Scores returns the utilization of the candidates.
+func (p *utilizationPolicy) Scores(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) map[string]float64 {
+	scores := make(map[string]float64, len(candidates))
+	for _, node := range candidates {
+		scores[node.Name] = utilization[node.Name]
+	}
+	return scores
+}
+
+type pricePolicy struct {
+	pricingModel cloudprovider.PricingModel
+}
+
+// NewPricePolicy returns a policy removing the nodes with the highest hourly price first. Nodes whose
+// price is unknown are removed after all the nodes with a known price.
+func NewPricePolicy(pricingModel cloudprovider.PricingModel) Policy {
+	return &pricePolicy{pricingModel: pricingModel}
+}
+
+// Scores returns the negated hourly prices of the candidates.
+func (p *pricePolicy) Scores(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) map[string]float64 {
+	then := now.Add(time.Hour)
+	scores := make(map[string]float64, len(candidates))
+	for _, node := range candidates {
+		price, err := p.pricingModel.NodePrice(node, now, then)
+		if err != nil {
+			glog.Warningf("Failed to get price of node %s, it will be removed after priced nodes: %v", node.Name, err)
+			continue
+		}
+		scores[node.Name] = -price
+	}
+	return scores
+}
+
+type agePolicy struct{}
+
+// NewAgePolicy returns a policy removing the oldest nodes first.
+func NewAgePolicy() Policy {
+	return &agePolicy{}
+}
+
+// Scores returns the creation times of the candidates, in seconds.
+func (p *agePolicy) Scores(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) map[string]float64 {
+	scores := make(map[string]float64, len(candidates))
+	for _, node := range candidates {
+		scores[node.Name] = float64(node.CreationTimestamp.Unix())
+	}
+	return scores
+}
+
+type deletionPriorityPolicy struct{}
+
+// NewDeletionPriorityPolicy returns a policy removing the nodes with the highest deletion priority
+// annotation first.
+func NewDeletionPriorityPolicy() Policy {
+	return &deletionPriorityPolicy{}
+}
+
+// Scores returns the negated deletion priorities of the candidates.
+func (p *deletionPriorityPolicy) Scores(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) map[string]float64 {
+	scores := make(map[string]float64, len(candidates))
+	for _, node := range candidates {
+		value, found := node.Annotations[DeletionPriorityAnnotationKey]
+		if !found {
+			continue
+		}
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			glog.Warningf("Ignoring invalid %s annotation of node %s: %v", DeletionPriorityAnnotationKey, node.Name, err)
+			continue
+		}
+		scores[node.Name] = -float64(priority)
+	}
+	return scores
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledownorder
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+var (
+	// AvailablePolicies is a list of available scale-down order policies
+	AvailablePolicies = []string{UtilizationPolicyName, PricePolicyName, AgePolicyName, DeletionPriorityPolicyName}
+	// UtilizationPolicyName removes the least utilized nodes first
+	UtilizationPolicyName = "utilization"
+	// PricePolicyName removes the most expensive nodes first
+	PricePolicyName = "price"
+	// AgePolicyName removes the oldest nodes first
+	AgePolicyName = "age"
+	// DeletionPriorityPolicyName removes the nodes with the highest deletion priority annotation first
+	DeletionPriorityPolicyName = "deletion-priority"
+)
+
+// Strategy describes an interface for ordering the scale-down candidates.
+type Strategy interface {
+	// Order returns the candidates sorted so that the nodes that should be removed first come first.
+	// Utilization maps node names to their utilization, as computed by the scale-down.
+	Order(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) []*apiv1.Node
+}
+
+// Policy describes an interface for scoring the scale-down candidates according to some criteria.
+type Policy interface {
+	// Scores returns the scores of the candidates, by node name. Nodes with lower scores are removed first.
+	Scores(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) map[string]float64
+}
+
+type chain struct {
+	policies []Policy
+}
+
+// NewStrategy returns a strategy ordering the candidates by the scores of the given policies. Each policy
+// is only used to break the ties left by the previous ones, and candidates tied on all policies keep their
+// relative order.
+func NewStrategy(policies ...Policy) Strategy {
+	return &chain{policies: policies}
+}
+
+// Order sorts the candidates by the scores of the chained policies.
+func (c *chain) Order(candidates []*apiv1.Node, utilization map[string]float64, now time.Time) []*apiv1.Node {
+	scores := make([]map[string]float64, 0, len(c.policies))
+	for _, policy := range c.policies {
+		scores = append(scores, policy.Scores(candidates, utilization, now))
+	}
+	result := make([]*apiv1.Node, len(candidates))
+	copy(result, candidates)
+	sort.SliceStable(result, func(i, j int) bool {
+		for _, policyScores := range scores {
+			left, right := policyScores[result[i].Name], policyScores[result[j].Name]
+			if left != right {
+				return left < right
+			}
+		}
+		return false
+	})
+	return result
+}
+
+// StrategyFromStrings creates a Strategy according to an ordered list of policy names. Nil strategy is
+// returned if no policy is given, which means the candidates are not reordered.
+func StrategyFromStrings(policyNames []string, cloudProvider cloudprovider.CloudProvider) (Strategy, errors.AutoscalerError) {
+	if len(policyNames) == 0 {
+		return nil, nil
+	}
+	policies := []Policy{}
+	seen := make(map[string]bool)
+	for _, name := range policyNames {
+		if seen[name] {
+			return nil, errors.NewAutoscalerError(errors.InternalError, "Scale-down order policy %s is used more than once", name)
+		}
+		seen[name] = true
+		policy, err := policyFromString(name, cloudProvider)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return NewStrategy(policies...), nil
+}
+
+func policyFromString(policyName string, cloudProvider cloudprovider.CloudProvider) (Policy, errors.AutoscalerError) {
+	switch policyName {
+	case UtilizationPolicyName:
+		return NewUtilizationPolicy(), nil
+	case PricePolicyName:
+		pricing, err := cloudProvider.Pricing()
+		if err != nil {
+			return nil, err
+		}
+		return NewPricePolicy(pricing), nil
+	case AgePolicyName:
+		return NewAgePolicy(), nil
+	case DeletionPriorityPolicyName:
+		return NewDeletionPriorityPolicy(), nil
+	}
+	return nil, errors.NewAutoscalerError(errors.InternalError, "Scale-down order policy %s not supported", policyName)
+}
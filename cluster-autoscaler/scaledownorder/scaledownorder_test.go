@@ -0,0 +1,134 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledownorder
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPricingModel struct {
+	nodePrice map[string]float64
+}
+
+func (tpm *testPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	if price, found := tpm.nodePrice[node.Name]; found {
+		return price, nil
+	}
+	return 0.0, fmt.Errorf("price for node %v not found", node.Name)
+}
+
+func (tpm *testPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0.0, fmt.Errorf("price for pod %v not found", pod.Name)
+}
+
+func nodeNames(nodes []*apiv1.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestUtilizationPolicy(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	utilization := map[string]float64{"n1": 0.4, "n2": 0.1, "n3": 0.3}
+
+	ordered := NewStrategy(NewUtilizationPolicy()).Order([]*apiv1.Node{n1, n2, n3}, utilization, time.Now())
+	assert.Equal(t, []string{"n2", "n3", "n1"}, nodeNames(ordered))
+}
+
+func TestPricePolicy(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	n4 := BuildTestNode("n4", 1000, 1000)
+	pricing := &testPricingModel{nodePrice: map[string]float64{"n1": 0.1, "n2": 0.5, "n4": 0.2}}
+
+	ordered := NewStrategy(NewPricePolicy(pricing)).Order([]*apiv1.Node{n1, n2, n3, n4}, nil, time.Now())
+	assert.Equal(t, []string{"n2", "n4", "n1", "n3"}, nodeNames(ordered))
+}
+
+func TestAgePolicy(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n1.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n2.CreationTimestamp = metav1.NewTime(now.Add(-3 * time.Hour))
+	n3 := BuildTestNode("n3", 1000, 1000)
+	n3.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+
+	ordered := NewStrategy(NewAgePolicy()).Order([]*apiv1.Node{n1, n2, n3}, nil, now)
+	assert.Equal(t, []string{"n2", "n3", "n1"}, nodeNames(ordered))
+}
+
+func TestDeletionPriorityPolicy(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n2.Annotations = map[string]string{DeletionPriorityAnnotationKey: "10"}
+	n3 := BuildTestNode("n3", 1000, 1000)
+	n3.Annotations = map[string]string{DeletionPriorityAnnotationKey: "-5"}
+	n4 := BuildTestNode("n4", 1000, 1000)
+	n4.Annotations = map[string]string{DeletionPriorityAnnotationKey: "high"}
+
+	ordered := NewStrategy(NewDeletionPriorityPolicy()).Order([]*apiv1.Node{n1, n2, n3, n4}, nil, time.Now())
+	assert.Equal(t, []string{"n2", "n1", "n4", "n3"}, nodeNames(ordered))
+}
+
+func TestChainedPolicies(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	n4 := BuildTestNode("n4", 1000, 1000)
+	pricing := &testPricingModel{nodePrice: map[string]float64{"n1": 0.1, "n2": 0.5, "n3": 0.1, "n4": 0.5}}
+	utilization := map[string]float64{"n1": 0.4, "n2": 0.3, "n3": 0.2, "n4": 0.3}
+	candidates := []*apiv1.Node{n1, n2, n3, n4}
+
+	ordered := NewStrategy(NewPricePolicy(pricing), NewUtilizationPolicy()).Order(candidates, utilization, time.Now())
+	assert.Equal(t, []string{"n2", "n4", "n3", "n1"}, nodeNames(ordered))
+	// The candidates themselves are not reordered.
+	assert.Equal(t, []string{"n1", "n2", "n3", "n4"}, nodeNames(candidates))
+}
+
+func TestStrategyFromStrings(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+
+	strategy, err := StrategyFromStrings(nil, provider)
+	assert.NoError(t, err)
+	assert.Nil(t, strategy)
+
+	strategy, err = StrategyFromStrings([]string{DeletionPriorityPolicyName, AgePolicyName, UtilizationPolicyName}, provider)
+	assert.NoError(t, err)
+	assert.NotNil(t, strategy)
+
+	_, err = StrategyFromStrings([]string{AgePolicyName, AgePolicyName}, provider)
+	assert.Error(t, err)
+
+	_, err = StrategyFromStrings([]string{"cheapest"}, provider)
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is the set of values matched by a single field of a cron expression.
+type cronField map[int]bool
+
+// Cron is a parsed cron expression with minute, hour, day of month, month and day of week fields.
+type Cron struct {
+	minute     cronField
+	hour       cronField
+	dayOfMonth cronField
+	month      cronField
+	dayOfWeek  cronField
+	// Like in cron, if both day of month and day of week are restricted, matching either is enough.
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+// ParseCron parses a cron expression made of five space separated fields: minute (0-59),
+// hour (0-23), day of month (1-31), month (1-12) and day of week (0-7, 0 and 7 are Sunday).
+// A field is a comma separated list of `*`, values and ranges (`a-b`), each optionally
+// followed by a step (`/n`).
+func ParseCron(expression string) (*Cron, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, got %d", expression, len(fields))
+	}
+	cron := &Cron{
+		dayOfMonthRestricted: fields[2] != "*",
+		dayOfWeekRestricted:  fields[4] != "*",
+	}
+	var err error
+	if cron.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute in %q: %v", expression, err)
+	}
+	if cron.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour in %q: %v", expression, err)
+	}
+	if cron.dayOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month in %q: %v", expression, err)
+	}
+	if cron.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month in %q: %v", expression, err)
+	}
+	if cron.dayOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week in %q: %v", expression, err)
+	}
+	if cron.dayOfWeek[7] {
+		cron.dayOfWeek[0] = true
+	}
+	return cron, nil
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	result := make(cronField)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		first, last := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if first, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			last = first
+			if len(bounds) == 2 {
+				if last, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step != 1 {
+				// a/n means from a to the maximum every n.
+				last = max
+			}
+		}
+		if first < min || last > max || first > last {
+			return nil, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for value := first; value <= last; value += step {
+			result[value] = true
+		}
+	}
+	return result, nil
+}
+
+// Matches tells whether the minute of the given time matches the expression.
+func (c *Cron) Matches(t time.Time) bool {
+	return c.minute[t.Minute()] && c.hour[t.Hour()] && c.matchesDay(t)
+}
+
+// matchesDay tells whether the day of the given time matches the expression.
+func (c *Cron) matchesDay(t time.Time) bool {
+	if !c.month[int(t.Month())] {
+		return false
+	}
+	dayOfMonth, dayOfWeek := c.dayOfMonth[t.Day()], c.dayOfWeek[int(t.Weekday())]
+	if c.dayOfMonthRestricted && c.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// Prev returns the latest minute matching the expression at or before t, in the location of t.
+// Minutes before notBefore are not looked at, false is returned if none of the others match. Only
+// the matching days are searched minute by minute, so the cost doesn't grow with the length of
+// the searched period.
+func (c *Cron) Prev(t time.Time, notBefore time.Time) (time.Time, bool) {
+	location := t.Location()
+	year, month, day := t.Date()
+	lastHour, lastMinute := t.Hour(), t.Minute()
+	for {
+		if time.Date(year, month, day, lastHour, lastMinute, 0, 0, location).Before(notBefore) {
+			return time.Time{}, false
+		}
+		if c.matchesDay(time.Date(year, month, day, 0, 0, 0, 0, location)) {
+			for hour := lastHour; hour >= 0; hour-- {
+				if !c.hour[hour] {
+					continue
+				}
+				minute := 59
+				if hour == lastHour {
+					minute = lastMinute
+				}
+				for ; minute >= 0; minute-- {
+					if !c.minute[minute] {
+						continue
+					}
+					// Wall clock times skipped by daylight saving changes don't exist, those repeated
+					// by them may fall after t.
+					start := time.Date(year, month, day, hour, minute, 0, 0, location)
+					if start.Hour() == hour && start.Minute() == minute && !start.After(t) {
+						if start.Before(notBefore) {
+							return time.Time{}, false
+						}
+						return start, true
+					}
+				}
+			}
+		}
+		year, month, day = time.Date(year, month, day-1, 0, 0, 0, 0, location).Date()
+		lastHour, lastMinute = 23, 59
+	}
+}
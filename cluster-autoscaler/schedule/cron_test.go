@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := ParseCron(expression)
+		assert.Error(t, err, "expression %q", expression)
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	// 2018-06-04 is a Monday.
+	monday := time.Date(2018, 6, 4, 8, 0, 0, 0, time.UTC)
+	saturday := time.Date(2018, 6, 9, 8, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		expression string
+		time       time.Time
+		matches    bool
+	}{
+		{"* * * * *", monday, true},
+		{"0 8 * * 1-5", monday, true},
+		{"0 8 * * 1-5", saturday, false},
+		{"0 8 * * 1-5", monday.Add(time.Minute), false},
+		{"*/15 8-9 * * *", monday.Add(45 * time.Minute), true},
+		{"*/15 8-9 * * *", monday.Add(50 * time.Minute), false},
+		{"10/20 * * * *", monday.Add(50 * time.Minute), true},
+		{"0 8 * * 0,6", saturday, true},
+		{"0 8 * * 7", saturday.Add(24 * time.Hour), true},
+		{"0 8 1 6 *", monday, false},
+		// Either day of month or day of week is enough if both are restricted.
+		{"0 8 1 * 1", monday, true},
+		{"0 8 4 * 0", monday, true},
+	}
+	for _, tc := range testCases {
+		cron, err := ParseCron(tc.expression)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.matches, cron.Matches(tc.time), "expression %q at %v", tc.expression, tc.time)
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	// 2018-06-04 is a Monday.
+	monday := time.Date(2018, 6, 4, 8, 30, 15, 0, time.UTC)
+	cron, err := ParseCron("0 22 * * 5")
+	assert.NoError(t, err)
+	start, found := cron.Prev(monday, monday.Add(-7*24*time.Hour))
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2018, 6, 1, 22, 0, 0, 0, time.UTC), start)
+	_, found = cron.Prev(monday, time.Date(2018, 6, 1, 22, 1, 0, 0, time.UTC))
+	assert.False(t, found)
+
+	// Prev finds the same minute as checking every minute, also across daylight saving changes.
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	for _, expression := range []string{"* * * * *", "*/15 8-9 * * *", "0 8 * * 1-5", "30 2 * * *", "0 0 1 * 1", "0 12 29 2 *"} {
+		cron, err := ParseCron(expression)
+		assert.NoError(t, err)
+		for _, now := range []time.Time{
+			monday,
+			time.Date(2018, 3, 11, 4, 0, 0, 0, newYork),
+			time.Date(2018, 11, 4, 1, 45, 0, 0, newYork),
+			time.Date(2018, 11, 4, 3, 0, 0, 0, newYork),
+		} {
+			notBefore := now.Add(-3 * 24 * time.Hour)
+			expected, expectedFound := time.Time{}, false
+			for minute := now.Truncate(time.Minute); !minute.Before(notBefore); minute = minute.Add(-time.Minute) {
+				if cron.Matches(minute) {
+					expected, expectedFound = minute, true
+					break
+				}
+			}
+			start, found := cron.Prev(now, notBefore)
+			assert.Equal(t, expectedFound, found, "expression %q at %v", expression, now)
+			assert.True(t, expected.Equal(start), "expression %q at %v: expected %v, got %v", expression, now, expected, start)
+		}
+	}
+}
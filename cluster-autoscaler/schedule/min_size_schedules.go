@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// MinSizeSchedulesConfigMapName is the name of the ConfigMap holding the min size schedules.
+	MinSizeSchedulesConfigMapName = "cluster-autoscaler-min-size-schedules"
+	// MinSizeSchedulesConfigMapKey is the key under which the schedules are stored in the ConfigMap.
+	MinSizeSchedulesConfigMapKey = "schedules"
+)
+
+// minSizeScheduleConfig is a single schedule as written in the ConfigMap.
+type minSizeScheduleConfig struct {
+	windowConfig
+	// NodeGroup is a regular expression that has to match the whole node group id.
+	NodeGroup string `json:"nodeGroup"`
+	// MinSize is the min size of matching node groups during a window.
+	MinSize int `json:"minSize"`
+}
+
+// minSizeSchedule is a parsed schedule.
+type minSizeSchedule struct {
+	window
+	nodeGroup *regexp.Regexp
+	minSize   int
+}
+
+// MinSizeSchedules provides the effective min sizes of node groups, read from a ConfigMap with
+// schedules that raise or lower the min size of node groups during recurring time windows.
+// Nil MinSizeSchedules always return the min size of the node group.
+type MinSizeSchedules struct {
+	reader    *kube_util.ConfigMapReader
+	schedules []minSizeSchedule
+}
+
+// NewMinSizeSchedules returns MinSizeSchedules reading the schedules ConfigMap through the given
+// lister, so changes are picked up without a restart.
+func NewMinSizeSchedules(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder) *MinSizeSchedules {
+	return &MinSizeSchedules{
+		reader: kube_util.NewConfigMapReader(configMapLister, logRecorder, MinSizeSchedulesConfigMapName,
+			MinSizeSchedulesConfigMapKey, "MinSizeSchedulesConfigMapInvalid"),
+	}
+}
+
+// Refresh parses the ConfigMap again if it changed since it was last read. An invalid configuration
+// is reported and the previous one is kept.
+func (s *MinSizeSchedules) Refresh() {
+	if s == nil {
+		return
+	}
+	s.reader.Refresh(func(data string) error {
+		schedules, err := parseMinSizeSchedules(data)
+		if err != nil {
+			return err
+		}
+		glog.V(1).Infof("Loaded %d min size schedules from ConfigMap %s", len(schedules), MinSizeSchedulesConfigMapName)
+		s.schedules = schedules
+		return nil
+	}, func() {
+		s.schedules = nil
+	})
+}
+
+// MinSize returns the min size of the node group at the given time. A window counts if it overlaps
+// with the lead time following now, so that nodes can be provisioned before the window starts.
+// If several windows apply, the highest min size is used. The result never exceeds the max size.
+func (s *MinSizeSchedules) MinSize(nodeGroup cloudprovider.NodeGroup, now time.Time, leadTime time.Duration) int {
+	if s == nil {
+		return nodeGroup.MinSize()
+	}
+	minSize, found := 0, false
+	for _, schedule := range s.schedules {
+		if !schedule.nodeGroup.MatchString(nodeGroup.Id()) || !schedule.activeBetween(now, now.Add(leadTime)) {
+			continue
+		}
+		if !found || schedule.minSize > minSize {
+			minSize, found = schedule.minSize, true
+		}
+	}
+	if !found {
+		return nodeGroup.MinSize()
+	}
+	if minSize > nodeGroup.MaxSize() {
+		return nodeGroup.MaxSize()
+	}
+	return minSize
+}
+
+// parseMinSizeSchedules parses a YAML list of schedules.
+func parseMinSizeSchedules(config string) ([]minSizeSchedule, error) {
+	var raw []minSizeScheduleConfig
+	if err := yaml.Unmarshal([]byte(config), &raw); err != nil {
+		return nil, fmt.Errorf("can't parse schedules: %v", err)
+	}
+
+	result := make([]minSizeSchedule, 0, len(raw))
+	for i, entry := range raw {
+		nodeGroup, err := compileNodeGroupRegexp(entry.NodeGroup)
+		if err != nil {
+			return nil, fmt.Errorf("can't compile node group expression %q of schedule %d: %v", entry.NodeGroup, i, err)
+		}
+		window, err := parseWindow(entry.windowConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window of schedule %d: %v", i, err)
+		}
+		if entry.MinSize < 0 {
+			return nil, fmt.Errorf("min size of schedule %d can't be negative", i)
+		}
+		result = append(result, minSizeSchedule{
+			window:    window,
+			nodeGroup: nodeGroup,
+			minSize:   entry.MinSize,
+		})
+	}
+	return result, nil
+}
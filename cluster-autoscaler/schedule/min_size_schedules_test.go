@@ -0,0 +1,156 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const (
+	testNamespace = "kube-system"
+	config        = `
+- nodeGroup: web-.*
+  start: "0 8 * * 1-5"
+  duration: 10h
+  minSize: 5
+- nodeGroup: web-1
+  start: "0 12 * * *"
+  duration: 1h
+  minSize: 8
+- nodeGroup: batch
+  start: "0 20 * * *"
+  duration: 12h
+  minSize: 0
+`
+)
+
+func buildConfigMap(resourceVersion string, schedules string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            MinSizeSchedulesConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{
+			MinSizeSchedulesConfigMapKey: schedules,
+		},
+	}
+}
+
+func setUp(t *testing.T) (*MinSizeSchedules, cache.Indexer) {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps(testNamespace)
+	logRecorder, err := utils.NewStatusMapRecorder(fake.NewSimpleClientset(), testNamespace, kube_record.NewFakeRecorder(10), false)
+	assert.NoError(t, err)
+	return NewMinSizeSchedules(lister, logRecorder), store
+}
+
+func TestMinSize(t *testing.T) {
+	schedules, store := setUp(t)
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+	schedules.Refresh()
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("web-1", 1, 10, 1)
+	provider.AddNodeGroup("web-2", 1, 4, 1)
+	provider.AddNodeGroup("batch", 2, 10, 2)
+	web1, web2, batch := provider.GetNodeGroup("web-1"), provider.GetNodeGroup("web-2"), provider.GetNodeGroup("batch")
+
+	// 2018-06-04 is a Monday.
+	morning := time.Date(2018, 6, 4, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, 5, schedules.MinSize(web1, morning, 0))
+	// Capped at the max size.
+	assert.Equal(t, 4, schedules.MinSize(web2, morning, 0))
+	// The highest min size wins.
+	assert.Equal(t, 8, schedules.MinSize(web1, morning.Add(3*time.Hour), 0))
+	// Windows can lower the min size.
+	assert.Equal(t, 0, schedules.MinSize(batch, morning.Add(-3*time.Hour), 0))
+	assert.Equal(t, 2, schedules.MinSize(batch, morning, 0))
+
+	// Windows apply ahead of time with a lead time.
+	early := time.Date(2018, 6, 4, 7, 50, 0, 0, time.UTC)
+	assert.Equal(t, 1, schedules.MinSize(web1, early, 0))
+	assert.Equal(t, 5, schedules.MinSize(web1, early, 15*time.Minute))
+	// The window ends at 18:00.
+	assert.Equal(t, 5, schedules.MinSize(web1, time.Date(2018, 6, 4, 17, 59, 0, 0, time.UTC), 0))
+	assert.Equal(t, 1, schedules.MinSize(web1, time.Date(2018, 6, 4, 18, 0, 0, 0, time.UTC), 0))
+	// No window on Saturday.
+	assert.Equal(t, 1, schedules.MinSize(web1, time.Date(2018, 6, 9, 9, 0, 0, 0, time.UTC), 0))
+}
+
+func TestMinSizeSchedulesReload(t *testing.T) {
+	schedules, store := setUp(t)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("web-1", 1, 10, 1)
+	web1 := provider.GetNodeGroup("web-1")
+	morning := time.Date(2018, 6, 4, 9, 0, 0, 0, time.UTC)
+
+	// No ConfigMap.
+	schedules.Refresh()
+	assert.Equal(t, 1, schedules.MinSize(web1, morning, 0))
+
+	assert.NoError(t, store.Add(buildConfigMap("1", config)))
+	schedules.Refresh()
+	assert.Equal(t, 5, schedules.MinSize(web1, morning, 0))
+
+	// Invalid configuration is ignored.
+	assert.NoError(t, store.Update(buildConfigMap("2", "- nodeGroup: web-1\n  start: 0 8\n  duration: 1h\n  minSize: 3\n")))
+	schedules.Refresh()
+	assert.Equal(t, 5, schedules.MinSize(web1, morning, 0))
+
+	assert.NoError(t, store.Update(buildConfigMap("3", "- nodeGroup: web-1\n  start: 0 8 * * *\n  duration: 2h\n  minSize: 3\n")))
+	schedules.Refresh()
+	assert.Equal(t, 3, schedules.MinSize(web1, morning, 0))
+
+	assert.NoError(t, store.Delete(buildConfigMap("3", "")))
+	schedules.Refresh()
+	assert.Equal(t, 1, schedules.MinSize(web1, morning, 0))
+}
+
+func TestParseMinSizeSchedulesInvalid(t *testing.T) {
+	for _, config := range []string{
+		"not a list",
+		"- nodeGroup: \"(\"\n  start: 0 8 * * *\n  duration: 1h\n",
+		"- nodeGroup: a\n  start: 0 8 * * *\n  duration: soon\n",
+		"- nodeGroup: a\n  start: 0 8 * * *\n  duration: 200h\n",
+		"- nodeGroup: a\n  start: 0 8 * * *\n  duration: 1h\n  timeZone: Nowhere/Special\n",
+		"- nodeGroup: a\n  start: 0 8 * * *\n  duration: 1h\n  minSize: -1\n",
+	} {
+		_, err := parseMinSizeSchedules(config)
+		assert.Error(t, err, "config %q", config)
+	}
+}
+
+func TestNilMinSizeSchedules(t *testing.T) {
+	var schedules *MinSizeSchedules
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 3, 10, 3)
+	schedules.Refresh()
+	assert.Equal(t, 3, schedules.MinSize(provider.GetNodeGroup("ng1"), time.Now(), time.Hour))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// ScaleDownBlackoutsConfigMapName is the name of the ConfigMap holding the scale-down blackout windows.
+	ScaleDownBlackoutsConfigMapName = "cluster-autoscaler-scale-down-blackouts"
+	// ScaleDownBlackoutsConfigMapKey is the key under which the blackout windows are stored in the ConfigMap.
+	ScaleDownBlackoutsConfigMapKey = "blackouts"
+)
+
+// scaleDownBlackoutConfig is a single blackout as written in the ConfigMap.
+type scaleDownBlackoutConfig struct {
+	windowConfig
+	// NodeGroup is a regular expression that has to match the whole node group id. The blackout
+	// applies to the whole cluster if it is empty.
+	NodeGroup string `json:"nodeGroup,omitempty"`
+}
+
+// scaleDownBlackout is a parsed blackout.
+type scaleDownBlackout struct {
+	window
+	// nodeGroup is nil for blackouts of the whole cluster.
+	nodeGroup *regexp.Regexp
+}
+
+// ScaleDownBlackouts provides time windows during which nodes must not be removed, either from the
+// whole cluster or from some node groups, read from a ConfigMap. Nil ScaleDownBlackouts never
+// block scale-down.
+type ScaleDownBlackouts struct {
+	reader    *kube_util.ConfigMapReader
+	blackouts []scaleDownBlackout
+}
+
+// NewScaleDownBlackouts returns ScaleDownBlackouts reading the blackouts ConfigMap through the given
+// lister, so changes are picked up without a restart.
+func NewScaleDownBlackouts(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder) *ScaleDownBlackouts {
+	return &ScaleDownBlackouts{
+		reader: kube_util.NewConfigMapReader(configMapLister, logRecorder, ScaleDownBlackoutsConfigMapName,
+			ScaleDownBlackoutsConfigMapKey, "ScaleDownBlackoutsConfigMapInvalid"),
+	}
+}
+
+// Refresh parses the ConfigMap again if it changed since it was last read. An invalid configuration
+// is reported and the previous one is kept.
+func (b *ScaleDownBlackouts) Refresh() {
+	if b == nil {
+		return
+	}
+	b.reader.Refresh(func(data string) error {
+		blackouts, err := parseScaleDownBlackouts(data)
+		if err != nil {
+			return err
+		}
+		glog.V(1).Infof("Loaded %d scale-down blackouts from ConfigMap %s", len(blackouts), ScaleDownBlackoutsConfigMapName)
+		b.blackouts = blackouts
+		return nil
+	}, func() {
+		b.blackouts = nil
+	})
+}
+
+// ClusterBlackedOut tells whether scale-down of the whole cluster is blocked at the given time.
+func (b *ScaleDownBlackouts) ClusterBlackedOut(now time.Time) bool {
+	if b == nil {
+		return false
+	}
+	for _, blackout := range b.blackouts {
+		if blackout.nodeGroup == nil && blackout.activeBetween(now, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeGroupBlackedOut tells whether scale-down of the node group is blocked at the given time,
+// either by a blackout of the node group or of the whole cluster.
+func (b *ScaleDownBlackouts) NodeGroupBlackedOut(nodeGroup cloudprovider.NodeGroup, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+	for _, blackout := range b.blackouts {
+		if blackout.nodeGroup != nil && !blackout.nodeGroup.MatchString(nodeGroup.Id()) {
+			continue
+		}
+		if blackout.activeBetween(now, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseScaleDownBlackouts parses a YAML list of blackouts.
+func parseScaleDownBlackouts(config string) ([]scaleDownBlackout, error) {
+	var raw []scaleDownBlackoutConfig
+	if err := yaml.Unmarshal([]byte(config), &raw); err != nil {
+		return nil, fmt.Errorf("can't parse blackouts: %v", err)
+	}
+
+	result := make([]scaleDownBlackout, 0, len(raw))
+	for i, entry := range raw {
+		blackout := scaleDownBlackout{}
+		if entry.NodeGroup != "" {
+			nodeGroup, err := compileNodeGroupRegexp(entry.NodeGroup)
+			if err != nil {
+				return nil, fmt.Errorf("can't compile node group expression %q of blackout %d: %v", entry.NodeGroup, i, err)
+			}
+			blackout.nodeGroup = nodeGroup
+		}
+		window, err := parseWindow(entry.windowConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window of blackout %d: %v", i, err)
+		}
+		blackout.window = window
+		result = append(result, blackout)
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const blackoutsConfig = `
+# End of month batch.
+- start: "0 0 28 * *"
+  duration: 96h
+- nodeGroup: web-.*
+  start: "0 8 * * 1-5"
+  duration: 10h
+  timeZone: America/New_York
+`
+
+func buildBlackoutsConfigMap(resourceVersion string, blackouts string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            ScaleDownBlackoutsConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{
+			ScaleDownBlackoutsConfigMapKey: blackouts,
+		},
+	}
+}
+
+func TestScaleDownBlackouts(t *testing.T) {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps(testNamespace)
+	logRecorder, err := utils.NewStatusMapRecorder(fake.NewSimpleClientset(), testNamespace, kube_record.NewFakeRecorder(10), false)
+	assert.NoError(t, err)
+	blackouts := NewScaleDownBlackouts(lister, logRecorder)
+	assert.NoError(t, store.Add(buildBlackoutsConfigMap("1", blackoutsConfig)))
+	blackouts.Refresh()
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("web-1", 1, 10, 1)
+	provider.AddNodeGroup("batch", 1, 10, 1)
+	web, batch := provider.GetNodeGroup("web-1"), provider.GetNodeGroup("batch")
+
+	// 2018-06-04 is a Monday, 14:00 UTC is 10:00 in New York.
+	monday := time.Date(2018, 6, 4, 14, 0, 0, 0, time.UTC)
+	assert.False(t, blackouts.ClusterBlackedOut(monday))
+	assert.True(t, blackouts.NodeGroupBlackedOut(web, monday))
+	assert.False(t, blackouts.NodeGroupBlackedOut(batch, monday))
+	// 12:00 UTC is 8:00 in New York.
+	assert.False(t, blackouts.NodeGroupBlackedOut(web, monday.Add(-2*time.Hour-time.Minute)))
+	assert.True(t, blackouts.NodeGroupBlackedOut(web, monday.Add(-2*time.Hour)))
+
+	// The cluster wide blackout applies to all node groups.
+	endOfMonth := time.Date(2018, 6, 30, 14, 0, 0, 0, time.UTC)
+	assert.True(t, blackouts.ClusterBlackedOut(endOfMonth))
+	assert.True(t, blackouts.NodeGroupBlackedOut(batch, endOfMonth))
+	assert.False(t, blackouts.ClusterBlackedOut(time.Date(2018, 7, 2, 0, 0, 0, 0, time.UTC)))
+
+	// Invalid configuration is ignored.
+	assert.NoError(t, store.Update(buildBlackoutsConfigMap("2", "- nodeGroup: batch\n")))
+	blackouts.Refresh()
+	assert.True(t, blackouts.ClusterBlackedOut(endOfMonth))
+
+	assert.NoError(t, store.Delete(buildBlackoutsConfigMap("2", "")))
+	blackouts.Refresh()
+	assert.False(t, blackouts.ClusterBlackedOut(endOfMonth))
+	assert.False(t, blackouts.NodeGroupBlackedOut(web, monday))
+}
+
+func TestNilScaleDownBlackouts(t *testing.T) {
+	var blackouts *ScaleDownBlackouts
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	blackouts.Refresh()
+	assert.False(t, blackouts.ClusterBlackedOut(time.Now()))
+	assert.False(t, blackouts.NodeGroupBlackedOut(provider.GetNodeGroup("ng1"), time.Now()))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	// MaxWindowDuration is the maximum duration of a scheduled window.
+	MaxWindowDuration = 7 * 24 * time.Hour
+)
+
+// windowConfig is a recurring time window as written in a ConfigMap.
+type windowConfig struct {
+	// Start is a cron expression describing when windows start.
+	Start string `json:"start"`
+	// Duration is how long a window lasts, in the Go duration format.
+	Duration string `json:"duration"`
+	// TimeZone the cron expression is evaluated in, UTC if empty.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// window is a parsed recurring time window.
+type window struct {
+	start    *Cron
+	duration time.Duration
+	location *time.Location
+}
+
+// parseWindow parses a window config.
+func parseWindow(config windowConfig) (window, error) {
+	start, err := ParseCron(config.Start)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid start: %v", err)
+	}
+	duration, err := time.ParseDuration(config.Duration)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid duration: %v", err)
+	}
+	if duration <= 0 || duration > MaxWindowDuration {
+		return window{}, fmt.Errorf("duration must be positive and at most %v", MaxWindowDuration)
+	}
+	location, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid time zone: %v", err)
+	}
+	return window{start: start, duration: duration, location: location}, nil
+}
+
+// activeBetween tells whether any occurrence of the window overlaps with the [from, to] period.
+func (w *window) activeBetween(from, to time.Time) bool {
+	// The latest occurrence starting by the end of the period is the last one to end.
+	start, found := w.start.Prev(to.In(w.location), from.Add(-w.duration))
+	return found && start.Add(w.duration).After(from)
+}
+
+// compileNodeGroupRegexp compiles a regular expression that has to match the whole node group id.
+func compileNodeGroupRegexp(expression string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expression + ")$")
+}
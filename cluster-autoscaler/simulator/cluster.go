@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"time"
 
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/glogx"
 	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
@@ -58,15 +59,20 @@ type NodeToBeRemoved struct {
 }
 
 // FindNodesToRemove finds nodes that can be removed. Returns also an information about good
-// rescheduling location for each of the pods.
+// rescheduling location for each of the pods. The nodes are removed together: pods from a node are
+// never moved to another node that is removed, and all the moved pods fit in the remaining nodes
+// at the same time.
 func FindNodesToRemove(candidates []*apiv1.Node, allNodes []*apiv1.Node, pods []*apiv1.Pod,
 	client client.Interface, predicateChecker *PredicateChecker, maxCount int,
 	fastCheck bool, oldHints map[string]string, usageTracker *UsageTracker,
 	timestamp time.Time,
+	scratchPods *drain.ScratchPodSelector,
 	podDisruptionBudgets []*policyv1.PodDisruptionBudget,
 ) (nodesToRemove []NodeToBeRemoved, unremovableNodes []*apiv1.Node, podReschedulingHints map[string]string, finalError errors.AutoscalerError) {
 
 	nodeNameToNodeInfo := scheduler_util.CreateNodeNameToInfoMap(pods, allNodes)
+	// Node infos after removing the nodes found so far and moving their pods.
+	simulatedNodeInfos := nodeNameToNodeInfo
 	result := make([]NodeToBeRemoved, 0)
 	unremovable := make([]*apiv1.Node, 0)
 
@@ -84,13 +90,7 @@ candidateloop:
 		var err error
 
 		if nodeInfo, found := nodeNameToNodeInfo[node.Name]; found {
-			if fastCheck {
-				podsToRemove, err = FastGetPodsToMove(nodeInfo, *skipNodesWithSystemPods, *skipNodesWithLocalStorage,
-					podDisruptionBudgets)
-			} else {
-				podsToRemove, err = DetailedGetPodsForMove(nodeInfo, *skipNodesWithSystemPods, *skipNodesWithLocalStorage, client, int32(*minReplicaCount),
-					podDisruptionBudgets)
-			}
+			podsToRemove, err = GetPodsToMove(nodeInfo, client, fastCheck, scratchPods, podDisruptionBudgets)
 			if err != nil {
 				glog.V(2).Infof("%s: node %s cannot be removed: %v", evaluationType, node.Name, err)
 				unremovable = append(unremovable, node)
@@ -101,14 +101,27 @@ candidateloop:
 			unremovable = append(unremovable, node)
 			continue candidateloop
 		}
-		findProblems := findPlaceFor(node.Name, podsToRemove, allNodes, nodeNameToNodeInfo, predicateChecker, oldHints, newHints,
-			usageTracker, timestamp)
+		// Pods moved to this node from the nodes found so far have to be moved again.
+		podsToPlace := podsToRemove
+		if simulatedNodeInfo, found := simulatedNodeInfos[node.Name]; found {
+			for _, pod := range simulatedNodeInfo.Pods() {
+				if pod.Spec.NodeName == "" {
+					podsToPlace = append(podsToPlace, pod)
+				}
+			}
+		}
+		nodeInfosAfterRemoval, findProblems := findPlaceFor(node.Name, podsToPlace, allNodes, simulatedNodeInfos, predicateChecker,
+			oldHints, newHints, usageTracker, timestamp)
 
 		if findProblems == nil {
 			result = append(result, NodeToBeRemoved{
 				Node:             node,
 				PodsToReschedule: podsToRemove,
 			})
+			// The following nodes are simulated with the pods of this node already moved and
+			// without this node, so that no pod is moved to a node that is going away.
+			delete(nodeInfosAfterRemoval, node.Name)
+			simulatedNodeInfos = nodeInfosAfterRemoval
 			glog.V(2).Infof("%s: node %s may be removed", evaluationType, node.Name)
 			if len(result) >= maxCount {
 				break candidateloop
@@ -121,6 +134,19 @@ candidateloop:
 	return result, unremovable, newHints, nil
 }
 
+// GetPodsToMove returns the pods that have to be moved elsewhere before the node can be removed,
+// following the same rules as FindNodesToRemove. An error is returned if some pod prevents the
+// removal of the node.
+func GetPodsToMove(nodeInfo *schedulercache.NodeInfo, client client.Interface, fastCheck bool,
+	scratchPods *drain.ScratchPodSelector, podDisruptionBudgets []*policyv1.PodDisruptionBudget) ([]*apiv1.Pod, error) {
+	if fastCheck {
+		return FastGetPodsToMove(nodeInfo, *skipNodesWithSystemPods, *skipNodesWithLocalStorage,
+			scratchPods, podDisruptionBudgets)
+	}
+	return DetailedGetPodsForMove(nodeInfo, *skipNodesWithSystemPods, *skipNodesWithLocalStorage, scratchPods, client, int32(*minReplicaCount),
+		podDisruptionBudgets)
+}
+
 // FindEmptyNodesToRemove finds empty nodes that can be removed.
 func FindEmptyNodesToRemove(candidates []*apiv1.Node, pods []*apiv1.Pod) []*apiv1.Node {
 	nodeNameToNodeInfo := scheduler_util.CreateNodeNameToInfoMap(pods, candidates)
@@ -128,7 +154,7 @@ func FindEmptyNodesToRemove(candidates []*apiv1.Node, pods []*apiv1.Pod) []*apiv
 	for _, node := range candidates {
 		if nodeInfo, found := nodeNameToNodeInfo[node.Name]; found {
 			// Should block on all pods.
-			podsToRemove, err := FastGetPodsToMove(nodeInfo, true, true, nil)
+			podsToRemove, err := FastGetPodsToMove(nodeInfo, true, true, nil, nil)
 			if err == nil && len(podsToRemove) == 0 {
 				result = append(result, node)
 			}
@@ -173,10 +199,12 @@ func calculateUtilizationOfResource(node *apiv1.Node, nodeInfo *schedulercache.N
 	return float64(podsRequest.MilliValue()) / float64(nodeAllocatable.MilliValue()), nil
 }
 
+// findPlaceFor finds a place for the pods of the removed node among the nodes with node infos. Returns
+// a copy of the node infos with the pods added to the nodes they were moved to.
 // TODO: We don't need to pass list of nodes here as they are already available in nodeInfos.
 func findPlaceFor(removedNode string, pods []*apiv1.Pod, nodes []*apiv1.Node, nodeInfos map[string]*schedulercache.NodeInfo,
 	predicateChecker *PredicateChecker, oldHints map[string]string, newHints map[string]string, usageTracker *UsageTracker,
-	timestamp time.Time) error {
+	timestamp time.Time) (map[string]*schedulercache.NodeInfo, error) {
 
 	newNodeInfos := make(map[string]*schedulercache.NodeInfo)
 	for k, v := range nodeInfos {
@@ -254,13 +282,13 @@ func findPlaceFor(removedNode string, pods []*apiv1.Pod, nodes []*apiv1.Node, no
 			}
 			if !foundPlace {
 				glogx.V(4).Over(loggingQuota).Infof("%v other nodes evaluated for %s/%s", -loggingQuota.Left(), pod.Namespace, pod.Name)
-				return fmt.Errorf("failed to find place for %s", podKey(pod))
+				return nil, fmt.Errorf("failed to find place for %s", podKey(pod))
 			}
 		}
 
 		usageTracker.RegisterUsage(removedNode, targetNode, timestamp)
 	}
-	return nil
+	return newNodeInfos, nil
 }
 
 func shuffleNodes(nodes []*apiv1.Node) []*apiv1.Node {
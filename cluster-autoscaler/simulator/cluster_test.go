@@ -23,6 +23,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
@@ -68,7 +69,7 @@ func TestFindPlaceAllOk(t *testing.T) {
 	newHints := make(map[string]string)
 	tracker := NewUsageTracker()
 
-	err := findPlaceFor(
+	_, err := findPlaceFor(
 		"x",
 		[]*apiv1.Pod{new1, new2},
 		[]*apiv1.Node{node1, node2},
@@ -107,7 +108,7 @@ func TestFindPlaceAllBas(t *testing.T) {
 	newHints := make(map[string]string)
 	tracker := NewUsageTracker()
 
-	err := findPlaceFor(
+	_, err := findPlaceFor(
 		"nbad",
 		[]*apiv1.Pod{new1, new2, new3},
 		[]*apiv1.Node{nodebad, node1, node2},
@@ -136,7 +137,7 @@ func TestFindNone(t *testing.T) {
 	nodeInfos["n1"].SetNode(node1)
 	nodeInfos["n2"].SetNode(node2)
 
-	err := findPlaceFor(
+	_, err := findPlaceFor(
 		"x",
 		[]*apiv1.Pod{},
 		[]*apiv1.Node{node1, node2},
@@ -285,7 +286,7 @@ func TestFindNodesToRemove(t *testing.T) {
 		toRemove, unremovable, _, err := FindNodesToRemove(
 			test.candidates, test.allNodes, pods, nil,
 			predicateChecker, len(test.allNodes), true, map[string]string{},
-			tracker, time.Now(), []*policyv1.PodDisruptionBudget{})
+			tracker, time.Now(), nil, []*policyv1.PodDisruptionBudget{})
 		assert.NoError(t, err)
 		fmt.Printf("Test scenario: %s, found len(toRemove)=%v, expected len(test.toRemove)=%v\n", test.name, len(toRemove), len(test.toRemove))
 		assert.Equal(t, toRemove, test.toRemove)
@@ -293,3 +294,41 @@ func TestFindNodesToRemove(t *testing.T) {
 	}
 
 }
+
+func TestFindNodesToRemoveTogether(t *testing.T) {
+	ownerRefs := GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	n1 := BuildTestNode("n1", 1000, 2000000)
+	n2 := BuildTestNode("n2", 1000, 2000000)
+	n3 := BuildTestNode("n3", 1000, 2000000)
+	pods := make([]*apiv1.Pod, 0)
+	for _, node := range []*apiv1.Node{n1, n2, n3} {
+		SetNodeReadyState(node, true, time.Time{})
+		pod := BuildTestPod("p-"+node.Name, 400, 100000)
+		pod.OwnerReferences = ownerRefs
+		pod.Spec.NodeName = node.Name
+		pods = append(pods, pod)
+	}
+	pods[2].Spec.Containers[0].Resources.Requests[apiv1.ResourceCPU] = *resource.NewMilliQuantity(500, resource.DecimalSI)
+
+	// Each of n1 and n2 can be removed on its own, but n3 can't take the pods of both.
+	toRemove, unremovable, _, err := FindNodesToRemove(
+		[]*apiv1.Node{n1, n2}, []*apiv1.Node{n1, n2, n3}, pods, nil,
+		NewTestPredicateChecker(), 2, true, map[string]string{},
+		NewUsageTracker(), time.Now(), nil, []*policyv1.PodDisruptionBudget{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(toRemove))
+	assert.Equal(t, "n1", toRemove[0].Node.Name)
+	assert.Equal(t, []*apiv1.Pod{pods[0]}, toRemove[0].PodsToReschedule)
+	assert.Equal(t, []*apiv1.Node{n2}, unremovable)
+
+	// With more room on n3 both nodes can go.
+	pods[2].Spec.Containers[0].Resources.Requests[apiv1.ResourceCPU] = *resource.NewMilliQuantity(100, resource.DecimalSI)
+	toRemove, unremovable, _, err = FindNodesToRemove(
+		[]*apiv1.Node{n1, n2}, []*apiv1.Node{n1, n2, n3}, pods, nil,
+		NewTestPredicateChecker(), 2, true, map[string]string{},
+		NewUsageTracker(), time.Now(), nil, []*policyv1.PodDisruptionBudget{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(toRemove))
+	assert.Equal(t, []*apiv1.Pod{pods[1]}, toRemove[1].PodsToReschedule)
+	assert.Empty(t, unremovable)
+}
@@ -33,15 +33,16 @@ import (
 // is drained. Raises error if there is an unreplicated pod.
 // Based on kubectl drain code. It makes an assumption that RC, DS, Jobs and RS were deleted
 // along with their pods (no abandoned pods with dangling created-by annotation). Useful for fast
-// checks.
+// checks. Pods with local storage selected by scratchPods don't prevent the move.
 func FastGetPodsToMove(nodeInfo *schedulercache.NodeInfo, skipNodesWithSystemPods bool, skipNodesWithLocalStorage bool,
-	pdbs []*policyv1.PodDisruptionBudget) ([]*apiv1.Pod, error) {
+	scratchPods *drain.ScratchPodSelector, pdbs []*policyv1.PodDisruptionBudget) ([]*apiv1.Pod, error) {
 	pods, err := drain.GetPodsForDeletionOnNodeDrain(
 		nodeInfo.Pods(),
 		pdbs,
 		false,
 		skipNodesWithSystemPods,
 		skipNodesWithLocalStorage,
+		scratchPods,
 		false,
 		nil,
 		0,
@@ -60,9 +61,9 @@ func FastGetPodsToMove(nodeInfo *schedulercache.NodeInfo, skipNodesWithSystemPod
 // DetailedGetPodsForMove returns a list of pods that should be moved elsewhere if the node
 // is drained. Raises error if there is an unreplicated pod.
 // Based on kubectl drain code. It checks whether RC, DS, Jobs and RS that created these pods
-// still exist.
+// still exist. Pods with local storage selected by scratchPods don't prevent the move.
 func DetailedGetPodsForMove(nodeInfo *schedulercache.NodeInfo, skipNodesWithSystemPods bool,
-	skipNodesWithLocalStorage bool, client client.Interface, minReplicaCount int32,
+	skipNodesWithLocalStorage bool, scratchPods *drain.ScratchPodSelector, client client.Interface, minReplicaCount int32,
 	pdbs []*policyv1.PodDisruptionBudget) ([]*apiv1.Pod, error) {
 	pods, err := drain.GetPodsForDeletionOnNodeDrain(
 		nodeInfo.Pods(),
@@ -70,6 +71,7 @@ func DetailedGetPodsForMove(nodeInfo *schedulercache.NodeInfo, skipNodesWithSyst
 		false,
 		skipNodesWithSystemPods,
 		skipNodesWithLocalStorage,
+		scratchPods,
 		true,
 		client,
 		minReplicaCount,
@@ -23,6 +23,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
@@ -39,7 +40,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			Namespace: "ns",
 		},
 	}
-	_, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod1), true, true, nil)
+	_, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod1), true, true, nil, nil)
 	assert.Error(t, err)
 
 	// Replicated pod
@@ -50,7 +51,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			OwnerReferences: GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", ""),
 		},
 	}
-	r2, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod2), true, true, nil)
+	r2, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod2), true, true, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(r2))
 	assert.Equal(t, pod2, r2[0])
@@ -65,7 +66,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			},
 		},
 	}
-	r3, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod3), true, true, nil)
+	r3, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod3), true, true, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(r3))
 
@@ -77,7 +78,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			OwnerReferences: GenerateOwnerReferences("ds", "DaemonSet", "extensions/v1beta1", ""),
 		},
 	}
-	r4, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod2, pod3, pod4), true, true, nil)
+	r4, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod2, pod3, pod4), true, true, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(r4))
 	assert.Equal(t, pod2, r4[0])
@@ -90,7 +91,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			OwnerReferences: GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", ""),
 		},
 	}
-	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod5), true, true, nil)
+	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod5), true, true, nil, nil)
 	assert.Error(t, err)
 
 	// Local storage
@@ -110,7 +111,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			},
 		},
 	}
-	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod6), true, true, nil)
+	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod6), true, true, nil, nil)
 	assert.Error(t, err)
 
 	// Non-local storage
@@ -132,7 +133,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 			},
 		},
 	}
-	r7, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod7), true, true, nil)
+	r7, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod7), true, true, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(r7))
 
@@ -167,7 +168,7 @@ func TestFastGetPodsToMove(t *testing.T) {
 		},
 	}
 
-	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod8), true, true, []*policyv1.PodDisruptionBudget{pdb8})
+	_, err = FastGetPodsToMove(schedulercache.NewNodeInfo(pod8), true, true, nil, []*policyv1.PodDisruptionBudget{pdb8})
 	assert.Error(t, err)
 
 	// Pdb allowing
@@ -200,7 +201,38 @@ func TestFastGetPodsToMove(t *testing.T) {
 		},
 	}
 
-	r9, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod9), true, true, []*policyv1.PodDisruptionBudget{pdb9})
+	r9, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod9), true, true, nil, []*policyv1.PodDisruptionBudget{pdb9})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(r9))
 }
+
+func TestFastGetPodsToMoveWithScratchPods(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pod",
+			Namespace:       "batch",
+			OwnerReferences: GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", ""),
+		},
+		Spec: apiv1.PodSpec{
+			Volumes: []apiv1.Volume{
+				{
+					Name:         "scratch",
+					VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}},
+				},
+			},
+		},
+	}
+	_, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod), true, true, nil, nil)
+	assert.Error(t, err)
+
+	scratchPods := drain.NewScratchPodSelector([]string{"batch"}, nil)
+	r, err := FastGetPodsToMove(schedulercache.NewNodeInfo(pod), true, true, scratchPods, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(r))
+
+	annotatedPod := pod.DeepCopy()
+	annotatedPod.Annotations = map[string]string{drain.PodSafeToEvictLocalVolumesKey: "scratch"}
+	r, err = FastGetPodsToMove(schedulercache.NewNodeInfo(annotatedPod), true, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(r))
+}
@@ -51,6 +51,7 @@ func GetRequiredPodsForNode(nodename string, client kube_client.Interface) ([]*a
 		true, // Force all removals.
 		false,
 		false,
+		nil,
 		false, // Setting this to true requires client to be not-null.
 		nil,
 		0,
@@ -93,6 +93,14 @@ func NewPredicateChecker(kubeClient kube_client.Interface, stop <-chan struct{})
 	if err != nil {
 		return nil, err
 	}
+	// Volume zones are checked together with node affinity of volumes, explaining mismatches.
+	if _, found := predicateMap[volumeZonePredicateName]; found {
+		delete(predicateMap, volumeZonePredicateName)
+		predicateMap[volumeTopologyPredicateName] = NewVolumeTopologyPredicate(
+			informerFactory.Core().V1().PersistentVolumes().Lister(),
+			informerFactory.Core().V1().PersistentVolumeClaims().Lister(),
+			informerFactory.Storage().V1().StorageClasses().Lister())
+	}
 	// We always want to have PodFitsResources as a first predicate we run
 	// as this is cheap to check and it should be enough to fail predicates
 	// in most of our simulations (especially binpacking).
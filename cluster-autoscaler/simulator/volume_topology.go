@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+	"k8s.io/kubernetes/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/pkg/scheduler/algorithm/predicates"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// volumeTopologyPredicateName is the name of the predicate replacing NoVolumeZoneConflict.
+	volumeTopologyPredicateName = "VolumeTopology"
+	// volumeZonePredicateName is the name of the scheduler predicate checking volume zones.
+	volumeZonePredicateName = "NoVolumeZoneConflict"
+)
+
+// volumeTopologyChecker checks that the persistent volumes of a pod can be used on a node.
+// Zones of volumes are checked like the scheduler's NoVolumeZoneConflict predicate does,
+// and node affinity of volumes is checked as well. Unlike the scheduler, it explains which
+// volume doesn't match which zone, as the reasons end up in the events of pods that didn't
+// trigger scale-up.
+type volumeTopologyChecker struct {
+	pvLister           corelisters.PersistentVolumeLister
+	pvcLister          corelisters.PersistentVolumeClaimLister
+	storageClassLister storagelisters.StorageClassLister
+}
+
+// NewVolumeTopologyPredicate builds a predicate checking zones and node affinity of persistent
+// volumes used by pods.
+func NewVolumeTopologyPredicate(pvLister corelisters.PersistentVolumeLister, pvcLister corelisters.PersistentVolumeClaimLister,
+	storageClassLister storagelisters.StorageClassLister) algorithm.FitPredicate {
+	checker := &volumeTopologyChecker{
+		pvLister:           pvLister,
+		pvcLister:          pvcLister,
+		storageClassLister: storageClassLister,
+	}
+	return checker.predicate
+}
+
+func (c *volumeTopologyChecker) predicate(pod *apiv1.Pod, meta algorithm.PredicateMetadata, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+	if len(pod.Spec.Volumes) == 0 {
+		return true, nil, nil
+	}
+	node := nodeInfo.Node()
+	if node == nil {
+		return false, nil, fmt.Errorf("node not found")
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pv, err := c.boundVolume(pod.Namespace, volume.PersistentVolumeClaim.ClaimName)
+		if err != nil {
+			return false, nil, err
+		}
+		if pv == nil {
+			continue
+		}
+		if reason := volumeZoneMismatch(pv, node); reason != "" {
+			return false, []algorithm.PredicateFailureReason{predicates.NewFailureReason(reason)}, nil
+		}
+		if err := volumeutil.CheckNodeAffinity(pv, node.Labels); err != nil {
+			return false, []algorithm.PredicateFailureReason{
+				predicates.NewFailureReason(fmt.Sprintf("node(s) didn't match node affinity of volume %s", pv.Name)),
+			}, nil
+		}
+	}
+	return true, nil, nil
+}
+
+// boundVolume returns the volume bound to the claim, or nil if the claim will be bound
+// once the pod is scheduled.
+func (c *volumeTopologyChecker) boundVolume(namespace, claimName string) (*apiv1.PersistentVolume, error) {
+	if claimName == "" {
+		return nil, fmt.Errorf("PersistentVolumeClaim had no name")
+	}
+	pvc, err := c.pvcLister.PersistentVolumeClaims(namespace).Get(claimName)
+	if err != nil {
+		return nil, err
+	}
+	if pvc.Spec.VolumeName == "" {
+		if className := v1helper.GetPersistentVolumeClaimClass(pvc); className != "" {
+			class, err := c.storageClassLister.Get(className)
+			if err == nil && class.VolumeBindingMode != nil && *class.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("PersistentVolumeClaim is not bound: %q", claimName)
+	}
+	return c.pvLister.Get(pvc.Spec.VolumeName)
+}
+
+// volumeZoneMismatch explains why the node is not in the zone or region of the volume, or
+// returns an empty string if it is. Nodes without zone labels match any volume, as in
+// the scheduler.
+func volumeZoneMismatch(pv *apiv1.PersistentVolume, node *apiv1.Node) string {
+	if node.Labels[kubeletapis.LabelZoneFailureDomain] == "" && node.Labels[kubeletapis.LabelZoneRegion] == "" {
+		return ""
+	}
+	for _, label := range []struct {
+		key  string
+		kind string
+	}{
+		{kubeletapis.LabelZoneFailureDomain, "zone"},
+		{kubeletapis.LabelZoneRegion, "region"},
+	} {
+		value, found := pv.Labels[label.key]
+		if !found {
+			continue
+		}
+		zones, err := volumeutil.LabelZonesToSet(value)
+		if err != nil {
+			glog.Warningf("Failed to parse label %s=%s of volume %s, ignoring it: %v", label.key, value, pv.Name, err)
+			continue
+		}
+		if nodeValue := node.Labels[label.key]; !zones.Has(nodeValue) {
+			return fmt.Sprintf("node(s) in %s %q didn't match volume %s in %s %q", label.kind, nodeValue, pv.Name, label.kind, value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/cache"
+	kubeletapis "k8s.io/kubernetes/pkg/kubelet/apis"
+	"k8s.io/kubernetes/pkg/scheduler/algorithm"
+	schedulercache "k8s.io/kubernetes/pkg/scheduler/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestVolumeTopologyPredicate(objects ...interface{}) algorithm.FitPredicate {
+	pvs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	pvcs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	classes := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *apiv1.PersistentVolume:
+			pvs.Add(obj)
+		case *apiv1.PersistentVolumeClaim:
+			pvcs.Add(obj)
+		case *storagev1.StorageClass:
+			classes.Add(obj)
+		}
+	}
+	return NewVolumeTopologyPredicate(corelisters.NewPersistentVolumeLister(pvs),
+		corelisters.NewPersistentVolumeClaimLister(pvcs), storagelisters.NewStorageClassLister(classes))
+}
+
+func buildTestClaim(name, volumeName, className string) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			VolumeName:       volumeName,
+			StorageClassName: &className,
+		},
+	}
+}
+
+func buildTestPodWithClaim(name, claimName string) *apiv1.Pod {
+	pod := BuildTestPod(name, 100, 0)
+	pod.Spec.Volumes = []apiv1.Volume{
+		{
+			Name: "data",
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		},
+	}
+	return pod
+}
+
+func buildTestNodeInZone(name, zone string) *schedulercache.NodeInfo {
+	node := BuildTestNode(name, 1000, 1000)
+	if zone != "" {
+		node.Labels = map[string]string{
+			kubeletapis.LabelZoneRegion:        "us-east-1",
+			kubeletapis.LabelZoneFailureDomain: zone,
+		}
+	}
+	nodeInfo := schedulercache.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func TestVolumeTopologyPredicateZones(t *testing.T) {
+	zonalVolume := &apiv1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pv-zonal",
+			Labels: map[string]string{
+				kubeletapis.LabelZoneRegion:        "us-east-1",
+				kubeletapis.LabelZoneFailureDomain: "us-east-1b",
+			},
+		},
+	}
+	predicate := buildTestVolumeTopologyPredicate(zonalVolume, buildTestClaim("claim", "pv-zonal", "standard"))
+	pod := buildTestPodWithClaim("p1", "claim")
+
+	fits, _, err := predicate(pod, nil, buildTestNodeInZone("n1", "us-east-1b"))
+	assert.NoError(t, err)
+	assert.True(t, fits)
+
+	fits, reasons, err := predicate(pod, nil, buildTestNodeInZone("n2", "us-east-1a"))
+	assert.NoError(t, err)
+	assert.False(t, fits)
+	assert.Equal(t, 1, len(reasons))
+	assert.Equal(t, `node(s) in zone "us-east-1a" didn't match volume pv-zonal in zone "us-east-1b"`, reasons[0].GetReason())
+
+	// Nodes without zone labels match any zone, like in the scheduler.
+	fits, _, err = predicate(pod, nil, buildTestNodeInZone("n3", ""))
+	assert.NoError(t, err)
+	assert.True(t, fits)
+
+	// Pods without volumes fit anywhere.
+	fits, _, err = predicate(BuildTestPod("p2", 100, 0), nil, buildTestNodeInZone("n2", "us-east-1a"))
+	assert.NoError(t, err)
+	assert.True(t, fits)
+}
+
+func TestVolumeTopologyPredicateNodeAffinity(t *testing.T) {
+	localVolume := &apiv1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-local"},
+		Spec: apiv1.PersistentVolumeSpec{
+			NodeAffinity: &apiv1.VolumeNodeAffinity{
+				Required: &apiv1.NodeSelector{
+					NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+						{
+							MatchExpressions: []apiv1.NodeSelectorRequirement{
+								{
+									Key:      kubeletapis.LabelZoneFailureDomain,
+									Operator: apiv1.NodeSelectorOpIn,
+									Values:   []string{"us-east-1b"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	predicate := buildTestVolumeTopologyPredicate(localVolume, buildTestClaim("claim", "pv-local", "local"))
+	pod := buildTestPodWithClaim("p1", "claim")
+
+	fits, _, err := predicate(pod, nil, buildTestNodeInZone("n1", "us-east-1b"))
+	assert.NoError(t, err)
+	assert.True(t, fits)
+
+	fits, reasons, err := predicate(pod, nil, buildTestNodeInZone("n2", "us-east-1a"))
+	assert.NoError(t, err)
+	assert.False(t, fits)
+	assert.Equal(t, 1, len(reasons))
+	assert.Equal(t, "node(s) didn't match node affinity of volume pv-local", reasons[0].GetReason())
+}
+
+func TestVolumeTopologyPredicateUnboundClaims(t *testing.T) {
+	waitForFirstConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	delayedClass := &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: "delayed"},
+		VolumeBindingMode: &waitForFirstConsumer,
+	}
+	predicate := buildTestVolumeTopologyPredicate(delayedClass,
+		buildTestClaim("delayed-claim", "", "delayed"),
+		buildTestClaim("unbound-claim", "", "standard"))
+
+	// Claims bound once the pod is scheduled don't restrict nodes.
+	fits, _, err := predicate(buildTestPodWithClaim("p1", "delayed-claim"), nil, buildTestNodeInZone("n1", "us-east-1a"))
+	assert.NoError(t, err)
+	assert.True(t, fits)
+
+	_, _, err = predicate(buildTestPodWithClaim("p2", "unbound-claim"), nil, buildTestNodeInZone("n1", "us-east-1a"))
+	assert.Error(t, err)
+
+	_, _, err = predicate(buildTestPodWithClaim("p3", "missing-claim"), nil, buildTestNodeInZone("n1", "us-east-1a"))
+	assert.Error(t, err)
+}
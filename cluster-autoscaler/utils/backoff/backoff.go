@@ -50,16 +50,23 @@ func (b *Backoff) RemoveStaleBackoffData(currentTime time.Time) {
 
 // Backoff execution for the given key. Returns time till execution is backed off.
 func (b *Backoff) Backoff(key string, currentTime time.Time) time.Time {
-	duration := b.initialBackoffDuration
+	return b.BackoffWithDurations(key, b.initialBackoffDuration, b.maxBackoffDuration, currentTime)
+}
+
+// BackoffWithDurations backs off execution for the given key like Backoff, but with the given
+// initial and max backoff durations instead of the default ones. Returns time till execution
+// is backed off.
+func (b *Backoff) BackoffWithDurations(key string, initialBackoffDuration time.Duration, maxBackoffDuration time.Duration, currentTime time.Time) time.Time {
+	duration := initialBackoffDuration
 	if backoffInfo, found := b.backoffInfo[key]; found {
 		// Multiple concurrent scale-ups failing shouldn't cause backoff
 		// duration to increase, so we only increase it if we're not in
 		// backoff right now.
-		if backoffInfo.backoffUntil.Before(currentTime) {
+		if backoffInfo.backoffUntil.Before(currentTime) && 2*backoffInfo.duration > duration {
 			duration = 2 * backoffInfo.duration
-			if duration > b.maxBackoffDuration {
-				duration = b.maxBackoffDuration
-			}
+		}
+		if duration > maxBackoffDuration {
+			duration = maxBackoffDuration
 		}
 	}
 	backoffUntil := currentTime.Add(duration)
@@ -48,6 +48,25 @@ func TestMaxBackoff(t *testing.T) {
 	assert.False(t, backoff.IsBackedOff("key1", startTime.Add(6*time.Minute)))
 }
 
+func TestBackoffWithDurations(t *testing.T) {
+	backoff := NewBackoff(1*time.Minute, 3*time.Minute, 3*time.Hour)
+	startTime := time.Now()
+	backoff.BackoffWithDurations("key1", 10*time.Minute, 30*time.Minute, startTime)
+	assert.True(t, backoff.IsBackedOff("key1", startTime.Add(9*time.Minute)))
+	assert.False(t, backoff.IsBackedOff("key1", startTime.Add(10*time.Minute)))
+	backoff.BackoffWithDurations("key1", 10*time.Minute, 30*time.Minute, startTime.Add(11*time.Minute))
+	assert.True(t, backoff.IsBackedOff("key1", startTime.Add(30*time.Minute)))
+	assert.False(t, backoff.IsBackedOff("key1", startTime.Add(31*time.Minute)))
+	// The default durations cap the duration again.
+	backoff.Backoff("key1", startTime.Add(32*time.Minute))
+	assert.True(t, backoff.IsBackedOff("key1", startTime.Add(34*time.Minute)))
+	assert.False(t, backoff.IsBackedOff("key1", startTime.Add(35*time.Minute)))
+	// A longer initial duration isn't shortened by a previous short backoff.
+	backoff.BackoffWithDurations("key1", 20*time.Minute, 30*time.Minute, startTime.Add(36*time.Minute))
+	assert.True(t, backoff.IsBackedOff("key1", startTime.Add(55*time.Minute)))
+	assert.False(t, backoff.IsBackedOff("key1", startTime.Add(56*time.Minute)))
+}
+
 func TestRemoveBackoff(t *testing.T) {
 	backoff := NewBackoff(1*time.Minute, 3*time.Minute, 3*time.Hour)
 	startTime := time.Now()
@@ -18,12 +18,14 @@ package drain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 )
@@ -37,16 +39,51 @@ const (
 	// PodSafeToEvictKey - annotation that ignores constraints to evict a pod like not being replicated, being on
 	// kube-system namespace or having a local storage.
 	PodSafeToEvictKey = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// PodSafeToEvictLocalVolumesKey - annotation listing comma separated names of local volumes of a pod
+	// that can be lost, so that they don't prevent node drain.
+	PodSafeToEvictLocalVolumesKey = "cluster-autoscaler.kubernetes.io/safe-to-evict-local-volumes"
 )
 
+// ScratchPodSelector selects pods keeping only scratch data in their local storage, which
+// don't prevent node drain even if nodes with local storage are skipped. A nil selector
+// doesn't select any pods.
+type ScratchPodSelector struct {
+	// Namespaces of scratch pods.
+	Namespaces sets.String
+	// Selector of scratch pods in any namespace, nil if pods are selected by namespace only.
+	Selector labels.Selector
+}
+
+// NewScratchPodSelector builds a ScratchPodSelector from a list of namespaces and a label selector,
+// returning nil if neither is set.
+func NewScratchPodSelector(namespaces []string, selector labels.Selector) *ScratchPodSelector {
+	if len(namespaces) == 0 && selector == nil {
+		return nil
+	}
+	return &ScratchPodSelector{Namespaces: sets.NewString(namespaces...), Selector: selector}
+}
+
+// Matches checks whether the pod is a scratch pod.
+func (s *ScratchPodSelector) Matches(pod *apiv1.Pod) bool {
+	if s == nil {
+		return false
+	}
+	if s.Namespaces.Has(pod.Namespace) {
+		return true
+	}
+	return s.Selector != nil && s.Selector.Matches(labels.Set(pod.Labels))
+}
+
 // GetPodsForDeletionOnNodeDrain returns pods that should be deleted on node drain as well as some extra information
-// about possibly problematic pods (unreplicated and daemonsets).
+// about possibly problematic pods (unreplicated and daemonsets). Pods with local storage selected by scratchPods
+// don't prevent the drain even if skipNodesWithLocalStorage is set.
 func GetPodsForDeletionOnNodeDrain(
 	podList []*apiv1.Pod,
 	pdbs []*policyv1.PodDisruptionBudget,
 	deleteAll bool,
 	skipNodesWithSystemPods bool,
 	skipNodesWithLocalStorage bool,
+	scratchPods *ScratchPodSelector,
 	checkReferences bool, // Setting this to true requires client to be not-null.
 	client client.Interface,
 	minReplica int32,
@@ -194,7 +231,7 @@ func GetPodsForDeletionOnNodeDrain(
 					return []*apiv1.Pod{}, fmt.Errorf("non-daemonset, non-mirrored, non-pdb-assigned kube-system pod present: %s", pod.Name)
 				}
 			}
-			if HasLocalStorage(pod) && skipNodesWithLocalStorage {
+			if HasLocalStorage(pod) && skipNodesWithLocalStorage && !scratchPods.Matches(pod) {
 				return []*apiv1.Pod{}, fmt.Errorf("pod with local storage present: %s", pod.Name)
 			}
 			if hasNotSafeToEvictAnnotation(pod) {
@@ -231,10 +268,12 @@ func isPodTerminal(pod *apiv1.Pod) bool {
 	return pod.Status.Phase == apiv1.PodFailed
 }
 
-// HasLocalStorage returns true if pod has any local storage.
+// HasLocalStorage returns true if pod has any local storage, except for volumes listed
+// in the PodSafeToEvictLocalVolumesKey annotation.
 func HasLocalStorage(pod *apiv1.Pod) bool {
+	safeVolumes := safeToEvictLocalVolumes(pod)
 	for _, volume := range pod.Spec.Volumes {
-		if isLocalVolume(&volume) {
+		if isLocalVolume(&volume) && !safeVolumes.Has(volume.Name) {
 			return true
 		}
 	}
@@ -245,6 +284,16 @@ func isLocalVolume(volume *apiv1.Volume) bool {
 	return volume.HostPath != nil || volume.EmptyDir != nil
 }
 
+func safeToEvictLocalVolumes(pod *apiv1.Pod) sets.String {
+	volumes := sets.NewString()
+	for _, name := range strings.Split(pod.GetAnnotations()[PodSafeToEvictLocalVolumesKey], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			volumes.Insert(name)
+		}
+	}
+	return volumes
+}
+
 // This only checks if a matching PDB exist and therefore if it makes sense to attempt drain simulation,
 // as we check for allowed-disruptions later anyway (for all pods with PDB, not just in kube-system)
 func checkKubeSystemPDBs(pod *apiv1.Pod, pdbs []*policyv1.PodDisruptionBudget) (bool, error) {
@@ -27,6 +27,7 @@ import (
 	extensions "k8s.io/api/extensions/v1beta1"
 	policyv1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -309,6 +310,41 @@ func TestDrain(t *testing.T) {
 		},
 	}
 
+	emptydirRcPod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "bar",
+			Namespace:       "default",
+			OwnerReferences: GenerateOwnerReferences(rc.Name, "ReplicationController", "extensions/v1beta1", ""),
+			Labels: map[string]string{
+				"workload": "batch",
+			},
+		},
+		Spec: apiv1.PodSpec{
+			NodeName: "node",
+			Volumes: []apiv1.Volume{
+				{
+					Name:         "scratch",
+					VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{Medium: ""}},
+				},
+			},
+		},
+	}
+
+	emptydirSafeVolumesPod := emptydirRcPod.DeepCopy()
+	emptydirSafeVolumesPod.Annotations = map[string]string{
+		PodSafeToEvictLocalVolumesKey: "scratch",
+	}
+
+	emptydirPartlySafeVolumesPod := emptydirSafeVolumesPod.DeepCopy()
+	emptydirPartlySafeVolumesPod.Spec.Volumes = append(emptydirPartlySafeVolumesPod.Spec.Volumes, apiv1.Volume{
+		Name:         "cache",
+		VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/var/cache"}},
+	})
+
+	scratchNamespaces := NewScratchPodSelector([]string{"batch", "default"}, nil)
+	scratchLabels := NewScratchPodSelector(nil, labels.SelectorFromSet(labels.Set{"workload": "batch"}))
+	otherScratchLabels := NewScratchPodSelector([]string{"batch"}, labels.SelectorFromSet(labels.Set{"workload": "web"}))
+
 	emptyPDB := &policyv1.PodDisruptionBudget{}
 
 	kubeSystemPDB := &policyv1.PodDisruptionBudget{
@@ -356,6 +392,7 @@ func TestDrain(t *testing.T) {
 		pdbs        []*policyv1.PodDisruptionBudget
 		rcs         []apiv1.ReplicationController
 		replicaSets []extensions.ReplicaSet
+		scratchPods *ScratchPodSelector
 		expectFatal bool
 		expectPods  []*apiv1.Pod
 	}{
@@ -464,6 +501,51 @@ func TestDrain(t *testing.T) {
 			expectFatal: false,
 			expectPods:  []*apiv1.Pod{emptydirSafePod},
 		},
+		{
+			description: "RC-managed pod with EmptyDir",
+			pods:        []*apiv1.Pod{emptydirRcPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			expectFatal: true,
+			expectPods:  []*apiv1.Pod{},
+		},
+		{
+			description: "RC-managed pod with EmptyDir listed in PodSafeToEvictLocalVolumes annotation",
+			pods:        []*apiv1.Pod{emptydirSafeVolumesPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			expectFatal: false,
+			expectPods:  []*apiv1.Pod{emptydirSafeVolumesPod},
+		},
+		{
+			description: "RC-managed pod with HostPath not listed in PodSafeToEvictLocalVolumes annotation",
+			pods:        []*apiv1.Pod{emptydirPartlySafeVolumesPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			expectFatal: true,
+			expectPods:  []*apiv1.Pod{},
+		},
+		{
+			description: "RC-managed pod with EmptyDir in scratch namespace",
+			pods:        []*apiv1.Pod{emptydirRcPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			scratchPods: scratchNamespaces,
+			expectFatal: false,
+			expectPods:  []*apiv1.Pod{emptydirRcPod},
+		},
+		{
+			description: "RC-managed pod with EmptyDir matching scratch selector",
+			pods:        []*apiv1.Pod{emptydirRcPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			scratchPods: scratchLabels,
+			expectFatal: false,
+			expectPods:  []*apiv1.Pod{emptydirRcPod},
+		},
+		{
+			description: "RC-managed pod with EmptyDir not matching scratch selector",
+			pods:        []*apiv1.Pod{emptydirRcPod},
+			rcs:         []apiv1.ReplicationController{rc},
+			scratchPods: otherScratchLabels,
+			expectFatal: true,
+			expectPods:  []*apiv1.Pod{},
+		},
 		{
 			description: "RC-managed pod with PodSafeToEvict=false annotation",
 			pods:        []*apiv1.Pod{unsafeRcPod},
@@ -544,7 +626,7 @@ func TestDrain(t *testing.T) {
 			register("replicasets", &test.replicaSets[0], test.replicaSets[0].ObjectMeta)
 		}
 		pods, err := GetPodsForDeletionOnNodeDrain(test.pods, test.pdbs,
-			false, true, true, true, fakeClient, 0, time.Now())
+			false, true, true, test.scratchPods, true, fakeClient, 0, time.Now())
 
 		if test.expectFatal {
 			if err == nil {
@@ -563,3 +645,26 @@ func TestDrain(t *testing.T) {
 		}
 	}
 }
+
+func TestNewScratchPodSelector(t *testing.T) {
+	if selector := NewScratchPodSelector(nil, nil); selector != nil {
+		t.Fatalf("expected no selector, got %v", selector)
+	}
+
+	tierSelector, err := labels.Parse("tier in (scratch)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selector := NewScratchPodSelector([]string{"batch", "ci"}, tierSelector)
+	for _, namespace := range []string{"batch", "ci"} {
+		if !selector.Matches(&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}) {
+			t.Errorf("pod in namespace %s should match", namespace)
+		}
+	}
+	if !selector.Matches(&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Labels: map[string]string{"tier": "scratch"}}}) {
+		t.Errorf("pod with matching labels should match")
+	}
+	if selector.Matches(&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}) {
+		t.Errorf("pod in other namespace without labels shouldn't match")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpccodec encodes gRPC messages embedding Kubernetes objects.
+//
+// Kubernetes objects are generated with gogo/protobuf and the golang/protobuf version used here
+// doesn't delegate to their unmarshalers when they are nested in other messages. Messages embedding
+// them implement Marshal and Unmarshal with the helpers of this package, and Codec uses these
+// methods in place of the generated ones.
+package grpccodec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Codec is a gRPC codec using the Marshal and Unmarshal methods of messages that have them, and the
+// golang/protobuf encoding otherwise. The wire format is the regular protobuf one.
+type Codec struct{}
+
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type unmarshaler interface {
+	Unmarshal(data []byte) error
+}
+
+// Marshal returns the wire format of v.
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	if m, ok := v.(marshaler); ok {
+		return m.Marshal()
+	}
+	return proto.Marshal(v.(proto.Message))
+}
+
+// Unmarshal parses the wire format into v.
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	message := v.(proto.Message)
+	message.Reset()
+	if u, ok := v.(unmarshaler); ok {
+		return u.Unmarshal(data)
+	}
+	return proto.Unmarshal(data, message)
+}
+
+// String returns the name of the codec, the same as the name of the default gRPC codec.
+func (Codec) String() string {
+	return "proto"
+}
+
+// AppendBytesField appends a length-delimited field to the encoded message.
+func AppendBytesField(data []byte, field uint64, value []byte) []byte {
+	data = append(data, proto.EncodeVarint(field<<3|proto.WireBytes)...)
+	data = append(data, proto.EncodeVarint(uint64(len(value)))...)
+	return append(data, value...)
+}
+
+// AppendVarintField appends a varint field to the encoded message.
+func AppendVarintField(data []byte, field uint64, value uint64) []byte {
+	data = append(data, proto.EncodeVarint(field<<3|proto.WireVarint)...)
+	return append(data, proto.EncodeVarint(value)...)
+}
+
+// ReadFields calls onBytes for every length-delimited field and onVarint for every varint field.
+// Fields of other wire types are skipped, as are varint fields if onVarint is nil.
+func ReadFields(data []byte, onBytes func(field uint64, value []byte) error, onVarint func(field uint64, value uint64) error) error {
+	for len(data) > 0 {
+		key, n := proto.DecodeVarint(data)
+		if n == 0 {
+			return io.ErrUnexpectedEOF
+		}
+		data = data[n:]
+		field, wireType := key>>3, key&0x7
+		switch wireType {
+		case proto.WireVarint:
+			value, n := proto.DecodeVarint(data)
+			if n == 0 {
+				return io.ErrUnexpectedEOF
+			}
+			data = data[n:]
+			if onVarint != nil {
+				if err := onVarint(field, value); err != nil {
+					return err
+				}
+			}
+		case proto.WireBytes:
+			length, n := proto.DecodeVarint(data)
+			if n == 0 || uint64(len(data)-n) < length {
+				return io.ErrUnexpectedEOF
+			}
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if err := onBytes(field, value); err != nil {
+				return err
+			}
+		case proto.WireFixed64:
+			if len(data) < 8 {
+				return io.ErrUnexpectedEOF
+			}
+			data = data[8:]
+		case proto.WireFixed32:
+			if len(data) < 4 {
+				return io.ErrUnexpectedEOF
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d of field %d", wireType, field)
+		}
+	}
+	return nil
+}
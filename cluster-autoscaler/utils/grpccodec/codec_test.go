@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpccodec
+
+import (
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeMessage embeds a node and encodes it by hand.
+type nodeMessage struct {
+	Count int32
+	Node  *apiv1.Node
+}
+
+func (m *nodeMessage) Reset()         { *m = nodeMessage{} }
+func (m *nodeMessage) String() string { return "" }
+func (*nodeMessage) ProtoMessage()    {}
+
+func (m *nodeMessage) Marshal() ([]byte, error) {
+	data := AppendVarintField(nil, 1, uint64(m.Count))
+	encoded, err := m.Node.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return AppendBytesField(data, 2, encoded), nil
+}
+
+func (m *nodeMessage) Unmarshal(data []byte) error {
+	return ReadFields(data, func(field uint64, value []byte) error {
+		if field == 2 {
+			m.Node = &apiv1.Node{}
+			return m.Node.Unmarshal(value)
+		}
+		return nil
+	}, func(field uint64, value uint64) error {
+		if field == 1 {
+			m.Count = int32(value)
+		}
+		return nil
+	})
+}
+
+func TestCodecUsesMessageMethods(t *testing.T) {
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1", CreationTimestamp: metav1.Unix(1000, 0)},
+		Status: apiv1.NodeStatus{
+			Capacity: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("2")},
+		},
+	}
+	data, err := Codec{}.Marshal(&nodeMessage{Count: 3, Node: node})
+	assert.NoError(t, err)
+
+	decoded := &nodeMessage{Count: 7}
+	assert.NoError(t, Codec{}.Unmarshal(data, decoded))
+	assert.Equal(t, int32(3), decoded.Count)
+	assert.Equal(t, "n1", decoded.Node.Name)
+	assert.True(t, node.CreationTimestamp.Equal(&decoded.Node.CreationTimestamp))
+	cpu := decoded.Node.Status.Capacity[apiv1.ResourceCPU]
+	assert.Equal(t, int64(2), cpu.Value())
+}
+
+func TestCodecFallsBackToProto(t *testing.T) {
+	data, err := Codec{}.Marshal(&duration.Duration{Seconds: 5})
+	assert.NoError(t, err)
+
+	decoded := &duration.Duration{Nanos: 1}
+	assert.NoError(t, Codec{}.Unmarshal(data, decoded))
+	assert.Equal(t, &duration.Duration{Seconds: 5}, decoded)
+}
+
+func TestReadFields(t *testing.T) {
+	data := AppendBytesField(nil, 1, []byte("abc"))
+	data = AppendVarintField(data, 2, 300)
+	data = AppendBytesField(data, 3, nil)
+
+	bytesFields := make(map[uint64]string)
+	varintFields := make(map[uint64]uint64)
+	err := ReadFields(data, func(field uint64, value []byte) error {
+		bytesFields[field] = string(value)
+		return nil
+	}, func(field uint64, value uint64) error {
+		varintFields[field] = value
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]string{1: "abc", 3: ""}, bytesFields)
+	assert.Equal(t, map[uint64]uint64{2: 300}, varintFields)
+
+	// Varint fields are skipped without a callback.
+	assert.NoError(t, ReadFields(AppendVarintField(nil, 1, 1), nil, nil))
+
+	// Truncated fields.
+	for _, truncated := range [][]byte{data[:3], data[:6], data[:7]} {
+		err = ReadFields(truncated, func(uint64, []byte) error { return nil }, nil)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+}
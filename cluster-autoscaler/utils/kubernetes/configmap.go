@@ -0,0 +1,85 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	v1lister "k8s.io/client-go/listers/core/v1"
+)
+
+// ConfigMapReader reads a single key of a ConfigMap, parsing it again only when the ConfigMap changes.
+type ConfigMapReader struct {
+	configMapLister v1lister.ConfigMapNamespaceLister
+	logRecorder     *utils.LogEventRecorder
+	name            string
+	key             string
+	// invalidReason is the reason of the event emitted when the ConfigMap can't be parsed.
+	invalidReason string
+	// resourceVersion of the last ConfigMap that was parsed, whether successfully or not.
+	resourceVersion string
+}
+
+// NewConfigMapReader builds a ConfigMapReader of the key of the named ConfigMap. Invalid content
+// is reported with events of the given reason.
+func NewConfigMapReader(configMapLister v1lister.ConfigMapNamespaceLister, logRecorder *utils.LogEventRecorder,
+	name string, key string, invalidReason string) *ConfigMapReader {
+	return &ConfigMapReader{
+		configMapLister: configMapLister,
+		logRecorder:     logRecorder,
+		name:            name,
+		key:             key,
+		invalidReason:   invalidReason,
+	}
+}
+
+// Refresh calls load with the content of the key if the ConfigMap changed since it was last read,
+// and reset if the ConfigMap doesn't exist. If load fails, the error is reported.
+func (r *ConfigMapReader) Refresh(load func(data string) error, reset func()) {
+	configMap, err := r.configMapLister.Get(r.name)
+	if kube_errors.IsNotFound(err) {
+		if r.resourceVersion != "" {
+			glog.Warningf("ConfigMap %s was removed", r.name)
+		}
+		r.resourceVersion = ""
+		reset()
+		return
+	}
+	if err != nil {
+		glog.Errorf("Failed to get ConfigMap %s: %v", r.name, err)
+		return
+	}
+	if configMap.ResourceVersion == r.resourceVersion {
+		return
+	}
+	r.resourceVersion = configMap.ResourceVersion
+
+	data := configMap.Data[r.key]
+	if data == "" {
+		err = fmt.Errorf("key %s is missing or empty", r.key)
+	} else {
+		err = load(data)
+	}
+	if err != nil {
+		glog.Errorf("Invalid ConfigMap %s: %v", r.name, err)
+		r.logRecorder.Eventf(apiv1.EventTypeWarning, r.invalidReason, "Invalid ConfigMap %s: %v", r.name, err)
+	}
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package kubernetes
 
 import (
+	"sync"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
 	policyv1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	client "k8s.io/client-go/kubernetes"
 	v1lister "k8s.io/client-go/listers/core/v1"
 	v1extensionslister "k8s.io/client-go/listers/extensions/v1beta1"
@@ -296,3 +300,47 @@ func NewDaemonSetLister(kubeClient client.Interface, stopchannel <-chan struct{}
 		daemonSetLister: lister,
 	}
 }
+
+// NewConfigMapListerForNamespace builds a config map lister watching config maps in the given namespace.
+func NewConfigMapListerForNamespace(kubeClient client.Interface, stopchannel <-chan struct{}, namespace string) v1lister.ConfigMapNamespaceLister {
+	listWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return kubeClient.CoreV1().ConfigMaps(namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return kubeClient.CoreV1().ConfigMaps(namespace).Watch(options)
+		},
+	}
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store)
+	reflector := cache.NewReflector(listWatcher, &apiv1.ConfigMap{}, store, time.Hour)
+	go reflector.Run(stopchannel)
+	return lister.ConfigMaps(namespace)
+}
+
+// SharedConfigMapLister shares a single watch of the config maps in a namespace between all users
+// of the lister. The watch is started by the first call to Lister and runs until stopchannel is closed.
+type SharedConfigMapLister struct {
+	kubeClient  client.Interface
+	stopchannel <-chan struct{}
+	namespace   string
+	once        sync.Once
+	lister      v1lister.ConfigMapNamespaceLister
+}
+
+// NewSharedConfigMapLister builds a SharedConfigMapLister of config maps in the given namespace.
+func NewSharedConfigMapLister(kubeClient client.Interface, stopchannel <-chan struct{}, namespace string) *SharedConfigMapLister {
+	return &SharedConfigMapLister{
+		kubeClient:  kubeClient,
+		stopchannel: stopchannel,
+		namespace:   namespace,
+	}
+}
+
+// Lister returns the config map lister, starting the watch on first call.
+func (s *SharedConfigMapLister) Lister() v1lister.ConfigMapNamespaceLister {
+	s.once.Do(func() {
+		s.lister = NewConfigMapListerForNamespace(s.kubeClient, s.stopchannel, s.namespace)
+	})
+	return s.lister
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package units
 
 const (
+	// Megabyte is 2^20 bytes.
+	Megabyte = 1024 * 1024
 	// Gigabyte is 2^30 bytes.
 	Gigabyte = 1024 * 1024 * 1024
 )